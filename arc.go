@@ -0,0 +1,145 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+
+	"github.com/starboard-nz/units"
+)
+
+// turnArcStep is the maximum change of heading, in degrees, between consecutive points of TurnArc
+const turnArcStep = 2
+
+// SmallCircleArc returns `n` points along the arc of the circle of the given `radius` around `center`, from
+// `fromBearing` clockwise to `toBearing`, for example for the outline of a sector or of a search pattern. The first
+// and last points are at `fromBearing` and `toBearing`, and the others are spaced evenly by bearing. If the bearings
+// are the same the arc is the whole circle, and the last point is the same as the first, closing the ring.
+//
+// Arguments:
+//
+// center - the centre of the circle
+// radius - the distance of the points from the centre, measured along the paths of the model
+// fromBearing, toBearing - the bearings from the centre of the ends of the arc, in `Degrees` from North
+// n - the number of points, at least 2, otherwise nil is returned
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions. The model must implement DestinationPoint, so PlanarModel can't be used.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// Example:
+// // a 90° sector of radius 10km facing East
+// center := geod.NewLatLon(-41.29, 174.78)
+// arc := geod.SmallCircleArc(center, units.Km(10), 45, 135, 19, geod.VincentyModel)
+// sector := append(append([]geod.LatLon{center}, arc...), center)
+func SmallCircleArc(center LatLon, radius units.Distance, fromBearing, toBearing Degrees, n int, model EarthModel,
+	modelArgs ...interface{}) []LatLon {
+	if n < 2 {
+		return nil
+	}
+
+	from := Wrap360(fromBearing)
+	sweep := Wrap360(toBearing) - from
+	if sweep <= 0 {
+		sweep += 360
+	}
+
+	m := model(center, modelArgs...)
+	r := float64(radius.Metre())
+
+	points := make([]LatLon, n)
+	for i := range points {
+		θ := from + sweep*Degrees(i)/Degrees(n-1)
+		points[i] = m.DestinationPoint(r, Wrap360(θ))
+	}
+
+	if sweep == 360 {
+		points[n-1] = points[0]
+	}
+
+	return points
+}
+
+// TurnArc returns the points of the fly-by turn of the given radius joining the leg from `prev` to `vertex` and the
+// leg from `vertex` to `next` of a route, for example to draw the route as it is sailed or flown, or to predict a
+// track. The arc is tangent to both legs, starting on the first leg before `vertex` and ending on the second leg after
+// it, with points at most 2° of heading apart. The turn cuts the corner, so the arc doesn't go through `vertex`.
+//
+// Arguments:
+//
+// prev, vertex, next - the points of the route, with the turn at `vertex`
+// turnRadius - the radius of the turn, measured along the paths of the model
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions. The model must implement DestinationPoint, so PlanarModel can't be used.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// Returns just `vertex` if the legs are in line, and nil if the turn doesn't fit: if the arc would start before
+// `prev` or end after `next`, including for turns of 180°, or if any of the points are the same.
+//
+// Example:
+// route := []geod.LatLon{a}
+// route = append(route, geod.TurnArc(a, b, c, units.NM(1), geod.VincentyModel)...)
+// route = append(route, c)
+func TurnArc(prev, vertex, next LatLon, turnRadius units.Distance, model EarthModel,
+	modelArgs ...interface{}) []LatLon {
+	if prev.Equals(vertex) || vertex.Equals(next) {
+		return nil
+	}
+
+	mv := model(vertex, modelArgs...)
+	in := model(prev, modelArgs...).FinalBearingOn(vertex)
+	out := mv.InitialBearingTo(next)
+
+	// positive turning right
+	turn := float64(Wrap180(out - in))
+	if math.Abs(turn) < 1e-9 {
+		return []LatLon{vertex}
+	}
+
+	// the distance from the vertex to where the arc meets the legs
+	r := float64(turnRadius.Metre())
+	tangent := r * math.Tan(math.Abs(turn)*π/360)
+	if !(r > 0) || math.IsInf(tangent, 0) || tangent > float64(mv.DistanceTo(prev).Metre()) ||
+		tangent > float64(mv.DistanceTo(next).Metre()) {
+		return nil
+	}
+
+	start := mv.DestinationPoint(tangent, Wrap360(in+180))
+	end := mv.DestinationPoint(tangent, out)
+
+	// the centre of the turn is abeam the start of the arc, on the inside of the turn
+	side := Degrees(90)
+	if turn < 0 {
+		side = -90
+	}
+	centre := model(start, modelArgs...).DestinationPoint(r, Wrap360(in+side))
+
+	mc := model(centre, modelArgs...)
+	from, to := mc.InitialBearingTo(start), mc.InitialBearingTo(end)
+	n := int(math.Ceil(math.Abs(turn)/turnArcStep)) + 1
+
+	if turn > 0 {
+		return SmallCircleArc(centre, turnRadius, from, to, n, model, modelArgs...)
+	}
+
+	// SmallCircleArc goes clockwise
+	arc := SmallCircleArc(centre, turnRadius, to, from, n, model, modelArgs...)
+	for i, j := 0, len(arc)-1; i < j; i, j = i+1, j-1 {
+		arc[i], arc[j] = arc[j], arc[i]
+	}
+
+	return arc
+}
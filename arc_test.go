@@ -0,0 +1,75 @@
+package geod
+
+import (
+	"testing"
+
+	"github.com/starboard-nz/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSmallCircleArc(t *testing.T) {
+	center := NewLatLon(-41.29, 174.78)
+
+	for _, model := range []EarthModel{SphericalModel, VincentyModel} {
+		arc := SmallCircleArc(center, units.Metre(10000), 45, 135, 19, model)
+		assert.Len(t, arc, 19)
+		for i, p := range arc {
+			assert.InDelta(t, 10000, float64(Distance(center, p, model).Metre()), 1e-3)
+			assert.InDelta(t, 45+5*float64(i), float64(InitialBearing(center, p, model)), 1e-6)
+		}
+	}
+
+	// across North
+	arc := SmallCircleArc(center, units.Metre(5000), 350, 10, 3, SphericalModel)
+	assert.InDelta(t, 350, float64(InitialBearing(center, arc[0], SphericalModel)), 1e-6)
+	assert.InDelta(t, 0, float64(InitialBearing(center, arc[1], SphericalModel)), 1e-6)
+	assert.InDelta(t, 10, float64(InitialBearing(center, arc[2], SphericalModel)), 1e-6)
+
+	// whole circle
+	ring := SmallCircleArc(center, units.Metre(5000), 90, 90, 37, SphericalModel)
+	assert.Len(t, ring, 37)
+	assert.Equal(t, ring[0], ring[36])
+	assert.InDelta(t, 270, float64(InitialBearing(center, ring[18], SphericalModel)), 1e-6)
+
+	assert.Nil(t, SmallCircleArc(center, units.Metre(5000), 0, 90, 1, SphericalModel))
+}
+
+func TestTurnArc(t *testing.T) {
+	vertex := NewLatLon(-41.29, 174.78)
+
+	for _, model := range []EarthModel{SphericalModel, RhumbModel, VincentyModel} {
+		m := model(vertex)
+		prev := m.DestinationPoint(20000, 180) // heading North to the vertex
+		next := m.DestinationPoint(20000, 90)  // then East
+
+		arc := TurnArc(prev, vertex, next, units.Metre(2000), model)
+		require.Len(t, arc, 46)
+
+		// tangent to the legs, 2km before and after the vertex
+		assert.InDelta(t, 2000, float64(Distance(vertex, arc[0], model).Metre()), 1)
+		assert.InDelta(t, 2000, float64(Distance(vertex, arc[45], model).Metre()), 1)
+		assert.InDelta(t, 0, float64(Wrap180(InitialBearing(prev, arc[0], model)-InitialBearing(prev, vertex, model))), 1e-3)
+		assert.InDelta(t, 0, float64(Wrap180(InitialBearing(arc[45], next, model)-FinalBearing(vertex, arc[45], model))), 1e-3)
+
+		// turning right, with the heading changing smoothly
+		for i := 1; i < len(arc)-1; i++ {
+			turn := Wrap180(InitialBearing(arc[i], arc[i+1], model) - InitialBearing(arc[i-1], arc[i], model))
+			assert.InDelta(t, 2, float64(turn), 0.1)
+		}
+
+		// turning left, the other way
+		left := TurnArc(next, vertex, prev, units.Metre(2000), model)
+		require.Len(t, left, 46)
+		assert.True(t, left[0].EqualsWithin(arc[45], units.Metre(1), model))
+		assert.True(t, left[45].EqualsWithin(arc[0], units.Metre(1), model))
+	}
+
+	// in line, and turns that don't fit
+	prev := SphericalModel(vertex).DestinationPoint(20000, 180)
+	next := SphericalModel(vertex).DestinationPoint(20000, 0)
+	assert.Equal(t, []LatLon{vertex}, TurnArc(prev, vertex, next, units.Metre(2000), SphericalModel))
+	assert.Nil(t, TurnArc(prev, vertex, prev, units.Metre(2000), SphericalModel))
+	assert.Nil(t, TurnArc(prev, vertex, SphericalModel(vertex).DestinationPoint(20000, 90), units.Km(30), SphericalModel))
+	assert.Nil(t, TurnArc(vertex, vertex, next, units.Metre(2000), SphericalModel))
+}
@@ -0,0 +1,281 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+// Bound is a latitude/longitude box that may cross the antimeridian.
+//
+// `Min` is the south-west corner and `Max` the north-east corner. Longitudes are in the -180..+180 range, and when
+// `Min.Longitude` is greater than `Max.Longitude` the box crosses the antimeridian, for example a box from 170°E
+// to 170°W has `Min.Longitude` 170 and `Max.Longitude` -170.
+type Bound struct {
+	Min LatLon
+	Max LatLon
+}
+
+// NewBound returns the bound with south-west corner `sw` and north-east corner `ne`.
+// Longitudes are wrapped to -180..+180, and latitudes are swapped if needed.
+func NewBound(sw, ne LatLon) Bound {
+	b := Bound{
+		Min: LatLon{Latitude: sw.Latitude, Longitude: Wrap180(sw.Longitude)},
+		Max: LatLon{Latitude: ne.Latitude, Longitude: Wrap180(ne.Longitude)},
+	}
+	if b.Min.Latitude > b.Max.Latitude {
+		b.Min.Latitude, b.Max.Latitude = b.Max.Latitude, b.Min.Latitude
+	}
+
+	return b
+}
+
+// BoundFromOrb returns the Bound for an `orb.Bound`. Longitudes over 180, as used for geometries that cross the
+// antimeridian, are accepted, so a box from 170 to 190 becomes a box from 170°E to 170°W.
+// Boxes that are 360° or more wide cover all longitudes.
+func BoundFromOrb(b orb.Bound) Bound {
+	if b.Max[0]-b.Min[0] >= 360 {
+		return Bound{
+			Min: LatLon{Latitude: Degrees(b.Min[1]), Longitude: -180},
+			Max: LatLon{Latitude: Degrees(b.Max[1]), Longitude: 180},
+		}
+	}
+
+	return NewBound(
+		LatLon{Latitude: Degrees(b.Min[1]), Longitude: Degrees(b.Min[0])},
+		LatLon{Latitude: Degrees(b.Max[1]), Longitude: Degrees(b.Max[0])},
+	)
+}
+
+// CrossesAntimeridian returns true if the box crosses the antimeridian.
+func (b Bound) CrossesAntimeridian() bool {
+	return b.Min.Longitude > b.Max.Longitude
+}
+
+// lonSpan returns the width of the box in degrees of longitude.
+func (b Bound) lonSpan() float64 {
+	span := float64(b.Max.Longitude - b.Min.Longitude)
+	if span < 0 {
+		span += 360
+	}
+
+	return span
+}
+
+// lonOffset returns how many degrees east of `from` `lon` is, in the range 0..360
+func lonOffset(from, lon Degrees) float64 {
+	return math.Mod(math.Mod(float64(lon-from), 360)+360, 360)
+}
+
+// containsLon returns true if `lon` is within the box's longitude range.
+func (b Bound) containsLon(lon Degrees) bool {
+	return lonOffset(b.Min.Longitude, lon) <= b.lonSpan()
+}
+
+// containsLonRange returns true if the longitude range of `other` is within the longitude range of `b`.
+func (b Bound) containsLonRange(other Bound) bool {
+	if b.lonSpan() >= 360 {
+		return true
+	}
+
+	return lonOffset(b.Min.Longitude, other.Min.Longitude)+other.lonSpan() <= b.lonSpan()
+}
+
+// Contains returns true if the point is inside the box or on its edge.
+func (b Bound) Contains(ll LatLon) bool {
+	if ll.Latitude < b.Min.Latitude || ll.Latitude > b.Max.Latitude {
+		return false
+	}
+
+	return b.containsLon(ll.Longitude)
+}
+
+// Intersects returns true if the two boxes overlap or touch.
+func (b Bound) Intersects(other Bound) bool {
+	if other.Max.Latitude < b.Min.Latitude || other.Min.Latitude > b.Max.Latitude {
+		return false
+	}
+
+	return b.containsLon(other.Min.Longitude) || other.containsLon(b.Min.Longitude)
+}
+
+// Union returns the smallest box that contains both boxes.
+// When the boxes do not overlap the narrower of the two ways of joining them (east or west) is used.
+func (b Bound) Union(other Bound) Bound {
+	u := Bound{
+		Min: LatLon{Latitude: Degrees(math.Min(float64(b.Min.Latitude), float64(other.Min.Latitude)))},
+		Max: LatLon{Latitude: Degrees(math.Max(float64(b.Max.Latitude), float64(other.Max.Latitude)))},
+	}
+
+	candidates := [][2]Degrees{
+		{b.Min.Longitude, b.Max.Longitude},
+		{other.Min.Longitude, other.Max.Longitude},
+		{b.Min.Longitude, other.Max.Longitude},
+		{other.Min.Longitude, b.Max.Longitude},
+	}
+
+	best := -1.0
+	for _, c := range candidates {
+		cb := Bound{Min: LatLon{Longitude: c[0]}, Max: LatLon{Longitude: c[1]}}
+		if !cb.containsLonRange(b) || !cb.containsLonRange(other) {
+			continue
+		}
+		if best < 0 || cb.lonSpan() < best {
+			best = cb.lonSpan()
+			u.Min.Longitude, u.Max.Longitude = c[0], c[1]
+		}
+	}
+
+	if best < 0 {
+		// the boxes cover all longitudes between them
+		u.Min.Longitude, u.Max.Longitude = -180, 180
+	}
+
+	return u
+}
+
+// Extend returns the smallest box that contains both the box and the point.
+func (b Bound) Extend(ll LatLon) Bound {
+	return b.Union(Bound{Min: ll, Max: ll})
+}
+
+// Pad returns the box grown by `distance` in every direction, using a spherical Earth model.
+// The latitudes are limited to -90..+90 and if the box reaches a pole, or becomes 360° wide, it covers all
+// longitudes.
+func (b Bound) Pad(distance units.Distance) Bound {
+	δ := DegreesFromRadians(float64(distance.Metre()) / earthRadius)
+
+	p := Bound{
+		Min: LatLon{Latitude: b.Min.Latitude - δ},
+		Max: LatLon{Latitude: b.Max.Latitude + δ},
+	}
+	if p.Min.Latitude <= -90 || p.Max.Latitude >= 90 {
+		p.Min.Latitude = Degrees(math.Max(float64(p.Min.Latitude), -90))
+		p.Max.Latitude = Degrees(math.Min(float64(p.Max.Latitude), 90))
+		p.Min.Longitude, p.Max.Longitude = -180, 180
+
+		return p
+	}
+
+	// the longitude padding is largest at the latitude closest to a pole
+	maxLat := math.Max(math.Abs(float64(p.Min.Latitude)), math.Abs(float64(p.Max.Latitude)))
+	δLon := float64(δ) / math.Cos(Degrees(maxLat).Radians())
+	if b.lonSpan()+2*δLon >= 360 {
+		p.Min.Longitude, p.Max.Longitude = -180, 180
+
+		return p
+	}
+
+	p.Min.Longitude = Wrap180(b.Min.Longitude - Degrees(δLon))
+	p.Max.Longitude = Wrap180(b.Max.Longitude + Degrees(δLon))
+
+	return p
+}
+
+// ToOrb converts the box to `orb.Bound`s. Boxes that cross the antimeridian are split into two, one on each side.
+func (b Bound) ToOrb() []orb.Bound {
+	if !b.CrossesAntimeridian() {
+		return []orb.Bound{{
+			Min: orb.Point{float64(b.Min.Longitude), float64(b.Min.Latitude)},
+			Max: orb.Point{float64(b.Max.Longitude), float64(b.Max.Latitude)},
+		}}
+	}
+
+	return []orb.Bound{
+		{
+			Min: orb.Point{float64(b.Min.Longitude), float64(b.Min.Latitude)},
+			Max: orb.Point{180, float64(b.Max.Latitude)},
+		},
+		{
+			Min: orb.Point{-180, float64(b.Min.Latitude)},
+			Max: orb.Point{float64(b.Max.Longitude), float64(b.Max.Latitude)},
+		},
+	}
+}
+
+// angularDistanceTo returns the angular distance, in radians, from `ll` to the nearest point of the box, treating
+// the latitudes and longitudes as spherical coordinates
+func (b Bound) angularDistanceTo(ll LatLon) float64 {
+	φ := ll.Latitude.Radians()
+	φMin, φMax := b.Min.Latitude.Radians(), b.Max.Latitude.Radians()
+
+	if b.containsLon(ll.Longitude) {
+		// the nearest point is on the same meridian
+		return math.Max(math.Max(φMin-φ, φ-φMax), 0)
+	}
+
+	// the nearest point is on one of the sides of the box, along the meridians at its western and eastern edges
+	δ := math.Inf(1)
+	for _, λ := range []Degrees{b.Min.Longitude, b.Max.Longitude} {
+		Δλ := (λ - ll.Longitude).Radians()
+		candidates := []float64{φMin, φMax}
+		if cosΔλ := math.Cos(Δλ); cosΔλ > 0 {
+			// the nearest point of the whole meridian
+			φc := math.Atan2(math.Sin(φ), math.Cos(φ)*cosΔλ)
+			candidates = append(candidates, math.Min(math.Max(φc, φMin), φMax))
+		}
+
+		for _, φ2 := range candidates {
+			a := math.Sin((φ2-φ)/2)*math.Sin((φ2-φ)/2) + math.Cos(φ)*math.Cos(φ2)*math.Sin(Δλ/2)*math.Sin(Δλ/2)
+			δ = math.Min(δ, 2*math.Atan2(math.Sqrt(a), math.Sqrt(1-a)))
+		}
+	}
+
+	return δ
+}
+
+// MinDistanceToBound returns a lower bound of the distance from `ll` to any point inside the box `b`, according to
+// `model`, for example to skip boxes that are further than the best match found so far in a search. Returns 0 if the
+// point is inside the box. As for BoundFromOrb, boxes crossing the antimeridian may use longitudes over 180.
+//
+// The distance to the box is calculated on a sphere, which is much cheaper than measuring distances with the model.
+// For SphericalModel and RhumbModel (rhumb lines are never shorter than great circles) it is the distance on the
+// sphere of the model. For VincentyModel, and other models, the sphere with the smallest radius of curvature of the
+// ellipsoid (of WGS84 for other models) is used, so that the result is never more than the distance on the
+// ellipsoid.
+//
+// Arguments:
+//
+// ll - the point
+// b - the box
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// Example:
+// if geod.MinDistanceToBound(p, node.Bound, geod.VincentyModel).Metre() > best {
+// continue // nothing in the node can be closer
+// }
+func MinDistanceToBound(ll LatLon, b orb.Bound, model EarthModel, modelArgs ...interface{}) units.Distance {
+	R := minRadius(model(ll, modelArgs...))
+
+	return units.Metre(BoundFromOrb(b).angularDistanceTo(ll) * R)
+}
+
+// minRadius returns the radius of the sphere of spherical models, or the smallest radius of curvature of the
+// ellipsoid of VincentyModel (of WGS84 for other models), in metres, so that angular distances on the sphere of this
+// radius are never shorter than distances measured with the model
+func minRadius(m Model) float64 {
+	switch m := m.(type) {
+	case LatLonSpherical:
+		return m.sphereRadius()
+	case LatLonRhumb:
+		return m.sphereRadius()
+	case LatLonEllipsoidalVincenty:
+		return m.ellipsoid.b * m.ellipsoid.b / m.ellipsoid.a
+	default:
+		return math.Min(earthRadius, wgs84.b*wgs84.b/wgs84.a)
+	}
+}
@@ -0,0 +1,142 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"testing"
+
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBoundContains(t *testing.T) {
+	b := NewBound(NewLatLon(-20, 170), NewLatLon(-10, -170))
+	assert.True(t, b.CrossesAntimeridian())
+	assert.True(t, b.Contains(NewLatLon(-15, 175)))
+	assert.True(t, b.Contains(NewLatLon(-15, -175)))
+	assert.True(t, b.Contains(NewLatLon(-15, 180)))
+	assert.True(t, b.Contains(NewLatLon(-15, -180)))
+	assert.True(t, b.Contains(NewLatLon(-10, 170)))
+	assert.False(t, b.Contains(NewLatLon(-15, 0)))
+	assert.False(t, b.Contains(NewLatLon(-25, 175)))
+
+	b = NewBound(NewLatLon(-10, 10), NewLatLon(10, 20))
+	assert.False(t, b.CrossesAntimeridian())
+	assert.True(t, b.Contains(NewLatLon(0, 15)))
+	assert.False(t, b.Contains(NewLatLon(0, 175)))
+
+	assert.Equal(t, NewBound(NewLatLon(-20, 170), NewLatLon(-10, -170)),
+		BoundFromOrb(orb.Bound{Min: orb.Point{170, -20}, Max: orb.Point{190, -10}}))
+	assert.True(t, BoundFromOrb(orb.Bound{Min: orb.Point{0, -20}, Max: orb.Point{360, -10}}).Contains(NewLatLon(-15, -90)))
+}
+
+func TestBoundIntersects(t *testing.T) {
+	am := NewBound(NewLatLon(-20, 170), NewLatLon(-10, -170))
+
+	assert.True(t, am.Intersects(NewBound(NewLatLon(-15, -175), NewLatLon(0, -160))))
+	assert.True(t, am.Intersects(NewBound(NewLatLon(-15, 160), NewLatLon(0, 175))))
+	assert.True(t, am.Intersects(NewBound(NewLatLon(-15, 175), NewLatLon(0, 176))))
+	assert.True(t, am.Intersects(NewBound(NewLatLon(-30, 100), NewLatLon(0, -100))))
+	assert.True(t, NewBound(NewLatLon(-15, 175), NewLatLon(0, 176)).Intersects(am))
+	assert.False(t, am.Intersects(NewBound(NewLatLon(-15, -160), NewLatLon(0, 160))))
+	assert.False(t, am.Intersects(NewBound(NewLatLon(0, 175), NewLatLon(10, 176))))
+}
+
+func TestBoundUnion(t *testing.T) {
+	a := NewBound(NewLatLon(-20, 170), NewLatLon(-10, 175))
+	b := NewBound(NewLatLon(-15, -175), NewLatLon(0, -170))
+
+	// joined across the antimeridian, not the long way round
+	assert.Equal(t, NewBound(NewLatLon(-20, 170), NewLatLon(0, -170)), a.Union(b))
+	assert.Equal(t, NewBound(NewLatLon(-20, 170), NewLatLon(0, -170)), b.Union(a))
+
+	c := NewBound(NewLatLon(-15, 10), NewLatLon(0, 20))
+	assert.Equal(t, NewBound(NewLatLon(-20, 10), NewLatLon(0, 175)), a.Union(c))
+
+	// contained
+	assert.Equal(t, a, a.Union(NewBound(NewLatLon(-12, 171), NewLatLon(-11, 172))))
+
+	// together they cover all longitudes
+	d := NewBound(NewLatLon(0, -100), NewLatLon(1, 100))
+	e := NewBound(NewLatLon(0, 90), NewLatLon(1, -90))
+	assert.Equal(t, NewBound(NewLatLon(0, -180), NewLatLon(1, 180)), d.Union(e))
+
+	assert.Equal(t, NewBound(NewLatLon(-20, 170), NewLatLon(-10, -179)), a.Extend(NewLatLon(-15, -179)))
+}
+
+func TestBoundPad(t *testing.T) {
+	b := NewBound(NewLatLon(0, 179), NewLatLon(1, 179.5)).Pad(units.Km(111.19492664455873))
+	assert.InDelta(t, -1, float64(b.Min.Latitude), 1e-9)
+	assert.InDelta(t, 2, float64(b.Max.Latitude), 1e-9)
+	assert.InDelta(t, 177.99939, float64(b.Min.Longitude), 1e-4)
+	assert.InDelta(t, -179.49939, float64(b.Max.Longitude), 1e-4)
+	assert.True(t, b.CrossesAntimeridian())
+
+	b = NewBound(NewLatLon(88, 0), NewLatLon(89, 1)).Pad(units.Km(200))
+	assert.Equal(t, Degrees(90), b.Max.Latitude)
+	assert.Equal(t, Degrees(-180), b.Min.Longitude)
+	assert.Equal(t, Degrees(180), b.Max.Longitude)
+}
+
+func TestBoundToOrb(t *testing.T) {
+	bounds := NewBound(NewLatLon(-20, 170), NewLatLon(-10, -170)).ToOrb()
+	require.Len(t, bounds, 2)
+	assert.Equal(t, orb.Bound{Min: orb.Point{170, -20}, Max: orb.Point{180, -10}}, bounds[0])
+	assert.Equal(t, orb.Bound{Min: orb.Point{-180, -20}, Max: orb.Point{-170, -10}}, bounds[1])
+
+	bounds = NewBound(NewLatLon(-20, 10), NewLatLon(-10, 20)).ToOrb()
+	require.Len(t, bounds, 1)
+	assert.Equal(t, orb.Bound{Min: orb.Point{10, -20}, Max: orb.Point{20, -10}}, bounds[0])
+}
+
+func TestMinDistanceToBound(t *testing.T) {
+	boxes := []orb.Bound{
+		{Min: orb.Point{170, -20}, Max: orb.Point{190, -10}},
+		{Min: orb.Point{-10, 40}, Max: orb.Point{10, 60}},
+		{Min: orb.Point{100, 70}, Max: orb.Point{120, 85}},
+	}
+	points := []LatLon{
+		NewLatLon(-15, 175), NewLatLon(-15, 160), NewLatLon(0, -175), NewLatLon(-40, 180),
+		NewLatLon(50, 30), NewLatLon(80, 0), NewLatLon(-60, 0), NewLatLon(88, -70), NewLatLon(20, 110),
+	}
+
+	for _, tc := range []struct {
+		model EarthModel
+		tight bool
+	}{{SphericalModel, true}, {RhumbModel, false}, {VincentyModel, false}} {
+		model := tc.model
+		for _, b := range boxes {
+			for _, p := range points {
+				bound := float64(MinDistanceToBound(p, b, model).Metre())
+
+				// the smallest distance to a grid of points in the box
+				nearest := math.Inf(1)
+				for x := b.Min[0]; x <= b.Max[0]; x += 0.25 {
+					for y := b.Min[1]; y <= b.Max[1]; y += 0.25 {
+						q := NewLatLon(y, float64(Wrap180(Degrees(x))))
+						if !p.Equals(q) {
+							nearest = math.Min(nearest, float64(model(p).DistanceTo(q).Metre()))
+						} else {
+							nearest = 0
+						}
+					}
+				}
+
+				assert.LessOrEqual(t, bound, nearest+1e-6, "%v %v", p, b)
+				if tc.tight {
+					// within the spacing of the grid
+					assert.InDelta(t, nearest, bound, 20000, "%v %v", p, b)
+				}
+			}
+		}
+	}
+
+	assert.Zero(t, float64(MinDistanceToBound(NewLatLon(-15, -175), boxes[0], VincentyModel).Metre()))
+}
@@ -0,0 +1,70 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"github.com/starboard-nz/units"
+)
+
+// Intersecter is implemented by models that can calculate the intersection of two paths, each defined by a point and
+// a bearing, for example SphericalModel. See LatLonSpherical.Intersection.
+type Intersecter interface {
+	Intersection(bearing1 Degrees, ll2 LatLon, bearing2 Degrees) LatLon
+}
+
+// CrossTracker is implemented by models that can calculate the distances of the point across and along a path,
+// for example SphericalModel. See LatLonSpherical.CrossTrackDistanceTo and LatLonSpherical.AlongTrackDistanceTo.
+type CrossTracker interface {
+	CrossTrackDistanceTo(pathStart, pathEnd LatLon) units.Distance
+	AlongTrackDistanceTo(pathStart, pathEnd LatLon) units.Distance
+}
+
+// AreaComputer is implemented by models that can calculate the area of a polygon, in square metres, for example
+// SphericalModel. See LatLonSpherical.AreaOf.
+type AreaComputer interface {
+	AreaOf(polygon []LatLon) float64
+}
+
+// Capability is an optional feature of a model, beyond the methods of `Model`, see Supports.
+type Capability int
+
+const (
+	// IntersectionCapability - the model implements Intersecter
+	IntersectionCapability Capability = iota
+	// CrossTrackCapability - the model implements CrossTracker
+	CrossTrackCapability
+	// AreaCapability - the model implements AreaComputer
+	AreaCapability
+)
+
+// Supports returns true if the `Model` returned by `model` offers the `capability`, so that generic code can check
+// what a model supports before using it. Returns false if the model can't be used with the `modelArgs`.
+//
+// Example:
+// if geod.Supports(model, geod.CrossTrackCapability) {
+// d := model(p).(geod.CrossTracker).CrossTrackDistanceTo(p1, p2)
+// }
+func Supports(model EarthModel, capability Capability, modelArgs ...interface{}) bool {
+	m, err := NewModel(LatLon{}, model, modelArgs...)
+	if err != nil {
+		return false
+	}
+
+	switch capability {
+	case IntersectionCapability:
+		_, ok := m.(Intersecter)
+		return ok
+	case CrossTrackCapability:
+		_, ok := m.(CrossTracker)
+		return ok
+	case AreaCapability:
+		_, ok := m.(AreaComputer)
+		return ok
+	}
+
+	return false
+}
@@ -0,0 +1,66 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupports(t *testing.T) {
+	assert.True(t, Supports(SphericalModel, IntersectionCapability))
+	assert.True(t, Supports(SphericalModel, CrossTrackCapability))
+	assert.True(t, Supports(SphericalModel, AreaCapability))
+	assert.True(t, Supports(SphericalModel, AreaCapability, Radius(6378137)))
+
+	for _, model := range []EarthModel{RhumbModel, VincentyModel, PlanarModel} {
+		assert.False(t, Supports(model, IntersectionCapability))
+		assert.False(t, Supports(model, CrossTrackCapability))
+		assert.False(t, Supports(model, AreaCapability))
+	}
+
+	assert.False(t, Supports(SphericalModel, CrossTrackCapability, "invalid"))
+	assert.False(t, Supports(SphericalModel, Capability(-1)))
+}
+
+func TestCrossTrack(t *testing.T) {
+	p := NewLatLonSpherical(53.2611, -0.7972)
+	p1 := NewLatLon(53.3206, -1.7297)
+	p2 := NewLatLon(53.1887, 0.1334)
+
+	assert.InDelta(t, -307.5, float64(p.CrossTrackDistanceTo(p1, p2).Metre()), 0.1)
+	assert.InDelta(t, 62331, float64(p.AlongTrackDistanceTo(p1, p2).Metre()), 1)
+
+	// on the other side, and behind the start
+	assert.InDelta(t, 307.5, float64(p.CrossTrackDistanceTo(p2, p1).Metre()), 0.1)
+	behind := LatLonSpherical{ll: DestinationPoint(p1, 10000, InitialBearing(p1, p2, SphericalModel)+180, SphericalModel)}
+	assert.InDelta(t, -10000, float64(behind.AlongTrackDistanceTo(p1, p2).Metre()), 1e-3)
+	assert.InDelta(t, 0, float64(behind.CrossTrackDistanceTo(p1, p2).Metre()), 1e-3)
+
+	start := LatLonSpherical{ll: p1}
+	assert.Zero(t, float64(start.CrossTrackDistanceTo(p1, p2).Metre()))
+	assert.Zero(t, float64(start.AlongTrackDistanceTo(p1, p2).Metre()))
+}
+
+func TestAreaOf(t *testing.T) {
+	lls := NewLatLonSpherical(0, 0)
+
+	// an octant of the sphere
+	octant := []LatLon{{0, 0}, {0, 90}, {90, 0}}
+	R := EarthRadius()
+	assert.InDelta(t, 4*π*R*R/8, lls.AreaOf(octant), 1)
+	assert.InDelta(t, SphericalTriangle(octant[0], octant[1], octant[2]).Area, lls.AreaOf(octant), 1)
+
+	// closed, reversed and across the antimeridian
+	square := []LatLon{{-1, 179}, {-1, -179}, {1, -179}, {1, 179}, {-1, 179}}
+	area := lls.AreaOf(square)
+	assert.InDelta(t, 4*111195.0*111195.0, area, 0.01*area)
+	assert.InDelta(t, area, lls.AreaOf([]LatLon{square[3], square[2], square[1], square[0]}), 1e-3)
+
+	assert.Zero(t, lls.AreaOf(nil))
+}
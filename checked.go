@@ -0,0 +1,95 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"github.com/starboard-nz/units"
+)
+
+// The package-level functions (e.g. Distance) panic if the model arguments are invalid, like the model functions.
+// The variants below return an error wrapping ErrInvalidModelArgs instead, see NewModel, for models and arguments
+// that come from configuration.
+
+// DistanceChecked is the same as Distance, but returns an error if the model arguments are invalid.
+//
+// Example:
+// dist, err := geod.DistanceChecked(p1, p2, model, args...)
+// if err != nil {
+// ... handle the error
+// }
+func DistanceChecked(start, end LatLon, model EarthModel, modelArgs ...interface{}) (units.Distance, error) {
+	m, err := NewModel(start, model, modelArgs...)
+	if err != nil {
+		return units.Metre(0), err
+	}
+
+	return m.DistanceTo(end), nil
+}
+
+// InitialBearingChecked is the same as InitialBearing, but returns an error if the model arguments are invalid.
+func InitialBearingChecked(start, end LatLon, model EarthModel, modelArgs ...interface{}) (Degrees, error) {
+	m, err := NewModel(start, model, modelArgs...)
+	if err != nil {
+		return 0, err
+	}
+
+	return m.InitialBearingTo(end), nil
+}
+
+// FinalBearingChecked is the same as FinalBearing, but returns an error if the model arguments are invalid.
+func FinalBearingChecked(start, end LatLon, model EarthModel, modelArgs ...interface{}) (Degrees, error) {
+	m, err := NewModel(start, model, modelArgs...)
+	if err != nil {
+		return 0, err
+	}
+
+	return m.FinalBearingOn(end), nil
+}
+
+// MidPointChecked is the same as MidPoint, but returns an error if the model arguments are invalid.
+func MidPointChecked(start, end LatLon, model EarthModel, modelArgs ...interface{}) (LatLon, error) {
+	m, err := NewModel(start, model, modelArgs...)
+	if err != nil {
+		return LatLon{}, err
+	}
+
+	return m.MidPointTo(end), nil
+}
+
+// DestinationPointChecked is the same as DestinationPoint, but returns an error if the model arguments are invalid.
+func DestinationPointChecked(start LatLon, distance float64, bearing Degrees, model EarthModel,
+	modelArgs ...interface{}) (LatLon, error) {
+	m, err := NewModel(start, model, modelArgs...)
+	if err != nil {
+		return LatLon{}, err
+	}
+
+	return m.DestinationPoint(distance, bearing), nil
+}
+
+// IntermediatePointChecked is the same as IntermediatePoint, but returns an error if the model arguments are invalid.
+func IntermediatePointChecked(start, end LatLon, fraction float64, model EarthModel,
+	modelArgs ...interface{}) (LatLon, error) {
+	m, err := NewModel(start, model, modelArgs...)
+	if err != nil {
+		return LatLon{}, err
+	}
+
+	return m.IntermediatePointTo(end, fraction), nil
+}
+
+// IntermediatePointsChecked is the same as IntermediatePoints, but returns an error if the model arguments are
+// invalid.
+func IntermediatePointsChecked(start, end LatLon, fractions []float64, model EarthModel,
+	modelArgs ...interface{}) ([]LatLon, error) {
+	m, err := NewModel(start, model, modelArgs...)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.IntermediatePointsTo(end, fractions), nil
+}
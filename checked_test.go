@@ -0,0 +1,65 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckedFunctions(t *testing.T) {
+	p1 := NewLatLon(-41.29, 174.78)
+	p2 := NewLatLon(-43.49, 172.53)
+	args := []interface{}{HighPrecision}
+
+	dist, err := DistanceChecked(p1, p2, VincentyModel, args...)
+	require.NoError(t, err)
+	assert.Equal(t, Distance(p1, p2, VincentyModel, args...), dist)
+
+	b, err := InitialBearingChecked(p1, p2, VincentyModel, args...)
+	require.NoError(t, err)
+	assert.Equal(t, InitialBearing(p1, p2, VincentyModel, args...), b)
+
+	b, err = FinalBearingChecked(p1, p2, VincentyModel, args...)
+	require.NoError(t, err)
+	assert.Equal(t, FinalBearing(p1, p2, VincentyModel, args...), b)
+
+	p, err := MidPointChecked(p1, p2, VincentyModel, args...)
+	require.NoError(t, err)
+	assert.Equal(t, MidPoint(p1, p2, VincentyModel, args...), p)
+
+	p, err = DestinationPointChecked(p1, 1000, 45, VincentyModel, args...)
+	require.NoError(t, err)
+	assert.Equal(t, DestinationPoint(p1, 1000, 45, VincentyModel, args...), p)
+
+	p, err = IntermediatePointChecked(p1, p2, 0.25, VincentyModel, args...)
+	require.NoError(t, err)
+	assert.Equal(t, IntermediatePoint(p1, p2, 0.25, VincentyModel, args...), p)
+
+	ps, err := IntermediatePointsChecked(p1, p2, []float64{0.25, 0.5}, VincentyModel, args...)
+	require.NoError(t, err)
+	assert.Equal(t, IntermediatePoints(p1, p2, []float64{0.25, 0.5}, VincentyModel, args...), ps)
+
+	// invalid arguments return errors instead of panicking
+	_, err = DistanceChecked(p1, p2, SphericalModel, "bad")
+	assert.ErrorIs(t, err, ErrInvalidModelArgs)
+	_, err = InitialBearingChecked(p1, p2, SphericalModel, "bad")
+	assert.ErrorIs(t, err, ErrInvalidModelArgs)
+	_, err = FinalBearingChecked(p1, p2, RhumbModel, Radius(-1))
+	assert.ErrorIs(t, err, ErrInvalidModelArgs)
+	_, err = MidPointChecked(p1, p2, VincentyModel, Tolerance(0))
+	assert.ErrorIs(t, err, ErrInvalidModelArgs)
+	_, err = DestinationPointChecked(p1, 1000, 45, VincentyModel, MaxIterations(0))
+	assert.ErrorIs(t, err, ErrInvalidModelArgs)
+	_, err = IntermediatePointChecked(p1, p2, 0.25, PlanarModel, 1)
+	assert.ErrorIs(t, err, ErrInvalidModelArgs)
+	ps, err = IntermediatePointsChecked(p1, p2, []float64{0.5}, SphericalModel, "bad")
+	assert.ErrorIs(t, err, ErrInvalidModelArgs)
+	assert.Nil(t, ps)
+}
@@ -0,0 +1,215 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+
+	"github.com/starboard-nz/units"
+)
+
+const (
+	// circleTolerance is the distance in metres within which points are taken to be on a circle
+	circleTolerance = 1e-3
+	// circleIterations is the maximum number of Newton iterations refining the intersections of circles
+	circleIterations = 20
+)
+
+// CircleSegmentIntersections returns the points where the segment from `p1` to `p2` crosses the circle of the given
+// `radius` around `center`, in order from `p1`, using the given `model` for both the shape of the segment and the
+// distances from the centre. There are 0, 1 or 2 crossings; 2 if the segment passes through the circle.
+//
+// Arguments:
+//
+// center - the centre of the circle
+// radius - the radius of the circle, measured along the paths of the model
+// p1, p2 - the ends of the segment
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// As for CrossesParallel, touching the circle at `p2` counts as a crossing, but touching it at `p1` doesn't, so that
+// consecutive segments of a track don't report the same crossing twice. The segment is also split at its closest
+// point to the centre, so segments that only just reach the circle are found too.
+//
+// Example:
+// // where a vessel enters and leaves the 12NM range ring of a port
+// crossings := geod.CircleSegmentIntersections(port, units.NM(12), p1, p2, geod.VincentyModel)
+func CircleSegmentIntersections(center LatLon, radius units.Distance, p1, p2 LatLon, model EarthModel,
+	modelArgs ...interface{}) []LatLon {
+	r := float64(radius.Metre())
+	if !(r > 0) || p1.Equals(p2) {
+		return nil
+	}
+
+	mc := model(center, modelArgs...)
+	distance := func(ll LatLon) float64 {
+		return float64(mc.DistanceTo(ll).Metre())
+	}
+	offset := func(ll LatLon) float64 {
+		return distance(ll) - r
+	}
+
+	m := model(p1, modelArgs...)
+	closeness := func(f float64) float64 {
+		return -distance(m.IntermediatePointTo(p2, f))
+	}
+	closest, _ := maximiseAlong(closeness, -distance(p1), -distance(p2))
+
+	return findCrossings(p1, p2, offset, []float64{closest}, model, modelArgs...)
+}
+
+// CircleIntersections returns the points where the circle of radius `r1` around `c1` and the circle of radius `r2`
+// around `c2` intersect, using the given `model` for the distances from the centres, for example to find a position
+// from the ranges to 2 known points (trilateration). There are 2 points if the circles cross, the first on the left of
+// the path from `c1` to `c2`, 1 if they touch (within a millimetre), and none otherwise, including for circles around
+// the same or antipodal centres.
+//
+// Arguments:
+//
+// c1, r1 - the centre and radius of the first circle
+// c2, r2 - the centre and radius of the second circle
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// With SphericalModel the intersections are calculated directly. With other models they are calculated on a sphere
+// first, and refined with Newton's method using the distances of the model, so circles that only just touch or miss
+// on the sphere may not be found.
+//
+// Example:
+// fixes := geod.CircleIntersections(station1, units.NM(8.2), station2, units.NM(5.6), geod.VincentyModel)
+func CircleIntersections(c1 LatLon, r1 units.Distance, c2 LatLon, r2 units.Distance, model EarthModel,
+	modelArgs ...interface{}) []LatLon {
+	m1, m2 := model(c1, modelArgs...), model(c2, modelArgs...)
+
+	var R float64
+	exact := false
+	switch m := m1.(type) {
+	case LatLonSpherical:
+		R, exact = m.sphereRadius(), true
+	case LatLonRhumb:
+		R = m.sphereRadius()
+	case LatLonEllipsoidalVincenty:
+		R = (2*m.ellipsoid.a + m.ellipsoid.b) / 3
+	default:
+		R = earthRadius
+	}
+
+	ρ1, ρ2 := float64(r1.Metre())/R, float64(r2.Metre())/R
+	if !(ρ1 >= 0) || !(ρ2 >= 0) {
+		return nil
+	}
+
+	// the triangle c1, c2, intersection has sides θ, ρ1 and ρ2, and angle A at c1, with
+	// hav(ρ2) = hav(ρ1-θ) + sin ρ1 ⋅ sin θ ⋅ hav(A), which unlike the law of cosines is accurate for small circles
+	a := c1.ToNvector()
+	n := a.Cross(c2.ToNvector())
+	θ := math.Atan2(n.Length(), a.Dot(c2.ToNvector()))
+	if θ*R <= circleTolerance || (π-θ)*R <= circleTolerance {
+		return nil
+	}
+
+	// unit vectors at c1 towards c2 and to the left of it
+	u := n.Unit()
+	t := u.Cross(a)
+	pointAt := func(A float64) LatLon {
+		sinA, cosA := math.Sincos(A)
+		sinρ, cosρ := math.Sincos(ρ1)
+
+		return NvectorToLatLon(a.Times(cosρ).Plus(t.Times(sinρ * cosA)).Plus(u.Times(sinρ * sinA)))
+	}
+
+	var points []LatLon
+	switch {
+	case math.Abs(ρ1+ρ2-θ)*R <= circleTolerance:
+		// touching on the outside
+		points = []LatLon{pointAt(0)}
+	case math.Abs(math.Abs(ρ1-ρ2)-θ)*R <= circleTolerance:
+		// touching on the inside
+		if ρ1 > ρ2 {
+			points = []LatLon{pointAt(0)}
+		} else {
+			points = []LatLon{pointAt(π)}
+		}
+	default:
+		hav := func(x float64) float64 {
+			s := math.Sin(x / 2)
+
+			return s * s
+		}
+
+		havA := (hav(ρ2) - hav(ρ1-θ)) / (math.Sin(ρ1) * math.Sin(θ))
+		if !(havA >= 0 && havA <= 1) {
+			return nil
+		}
+
+		A := 2 * math.Asin(math.Sqrt(havA))
+		points = []LatLon{pointAt(A), pointAt(-A)}
+	}
+
+	if exact {
+		return points
+	}
+
+	residuals := func(ll LatLon) (float64, float64) {
+		return float64(m1.DistanceTo(ll).Metre()) - float64(r1.Metre()),
+			float64(m2.DistanceTo(ll).Metre()) - float64(r2.Metre())
+	}
+
+	refined := make([]LatLon, 0, len(points))
+	for _, p := range points {
+		p, ok := refineCircleIntersection(p, residuals)
+		if ok && (len(refined) == 0 || !refined[0].EqualsWithin(p, units.Metre(circleTolerance), model, modelArgs...)) {
+			refined = append(refined, p)
+		}
+	}
+
+	if len(refined) == 0 {
+		return nil
+	}
+
+	return refined
+}
+
+// refineCircleIntersection refines the point where both `residuals` are 0 with Newton's method, using finite
+// differences for the derivatives. Returns false if it doesn't converge.
+func refineCircleIntersection(p LatLon, residuals func(LatLon) (float64, float64)) (LatLon, bool) {
+	const h = 1e-7 // degrees
+
+	f1, f2 := residuals(p)
+	for i := 0; i < circleIterations && math.Hypot(f1, f2) > circleTolerance/10; i++ {
+		g1, g2 := residuals(LatLon{Latitude: p.Latitude + h, Longitude: p.Longitude})
+		k1, k2 := residuals(LatLon{Latitude: p.Latitude, Longitude: p.Longitude + h})
+
+		// the Jacobian by latitude and longitude, in metres per degree
+		j11, j12 := (g1-f1)/h, (k1-f1)/h
+		j21, j22 := (g2-f2)/h, (k2-f2)/h
+		det := j11*j22 - j12*j21
+		if det == 0 {
+			break
+		}
+
+		p = LatLon{
+			Latitude:  p.Latitude - Degrees((j22*f1-j12*f2)/det),
+			Longitude: Wrap180(p.Longitude - Degrees((j11*f2-j21*f1)/det)),
+		}
+		f1, f2 = residuals(p)
+	}
+
+	return p, math.Hypot(f1, f2) <= circleTolerance && math.Abs(float64(p.Latitude)) <= 90
+}
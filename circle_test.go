@@ -0,0 +1,100 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"testing"
+
+	"github.com/starboard-nz/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCircleSegmentIntersections(t *testing.T) {
+	center := NewLatLon(-41.29, 174.78)
+	radius := units.Km(20)
+
+	for _, model := range []EarthModel{SphericalModel, RhumbModel, VincentyModel} {
+		m := model(center)
+		p1 := m.DestinationPoint(50000, 270)
+		p2 := m.DestinationPoint(50000, 90)
+
+		// through the centre
+		crossings := CircleSegmentIntersections(center, radius, p1, p2, model)
+		require.Len(t, crossings, 2)
+		for _, c := range crossings {
+			assert.InDelta(t, 20000, float64(m.DistanceTo(c).Metre()), 1e-3)
+			assertOnSegment(t, p1, p2, c, model)
+		}
+		assert.Less(t, float64(crossings[0].Longitude), float64(crossings[1].Longitude))
+
+		// from inside
+		crossings = CircleSegmentIntersections(center, radius, center, p2, model)
+		require.Len(t, crossings, 1)
+		assert.InDelta(t, 20000, float64(m.DistanceTo(crossings[0]).Metre()), 1e-3)
+
+		// passing by
+		q1 := m.DestinationPoint(30000, 0)
+		q2 := model(q1).DestinationPoint(50000, 90)
+		assert.Nil(t, CircleSegmentIntersections(center, radius, q1, q2, model))
+
+		// entirely inside
+		assert.Nil(t, CircleSegmentIntersections(center, units.Km(100), p1, p2, model))
+	}
+
+	// a segment that only just reaches the circle, between samples
+	p1 := NewLatLon(-40, 170)
+	p2 := NewLatLon(-40, 180)
+	center = NewLatLon(-40.5, 175)
+	d := float64(SphericalModel(center).DistanceTo(NewLatLon(-40, 175)).Metre())
+	crossings := CircleSegmentIntersections(center, units.Metre(d+50), p1, p2, SphericalModel)
+	assert.Len(t, crossings, 2)
+
+	assert.Nil(t, CircleSegmentIntersections(center, units.Metre(0), p1, p2, SphericalModel))
+	assert.Nil(t, CircleSegmentIntersections(center, radius, p1, p1, SphericalModel))
+}
+
+func TestCircleIntersections(t *testing.T) {
+	c1 := NewLatLon(-41.29, 174.78)
+	c2 := NewLatLon(-41.0, 175.2)
+
+	for _, model := range []EarthModel{SphericalModel, RhumbModel, VincentyModel} {
+		r1, r2 := units.Km(30), units.Km(25)
+		points := CircleIntersections(c1, r1, c2, r2, model)
+		require.Len(t, points, 2)
+		for _, p := range points {
+			assert.InDelta(t, 30000, float64(model(c1).DistanceTo(p).Metre()), 1e-3)
+			assert.InDelta(t, 25000, float64(model(c2).DistanceTo(p).Metre()), 1e-3)
+		}
+
+		// the first point is on the left going from c1 to c2, here to the north west
+		assert.Greater(t, float64(points[0].Latitude), float64(points[1].Latitude))
+		swapped := CircleIntersections(c2, r2, c1, r1, model)
+		require.Len(t, swapped, 2)
+		assert.True(t, points[1].EqualsWithin(swapped[0], units.Metre(1e-3), model))
+
+		// too far apart, and one inside the other
+		assert.Nil(t, CircleIntersections(c1, units.Km(10), c2, units.Km(10), model))
+		assert.Nil(t, CircleIntersections(c1, units.Km(100), c2, units.Km(10), model))
+		assert.Nil(t, CircleIntersections(c1, r1, c1, r1, model))
+	}
+
+	// touching circles
+	d := SphericalModel(c1).DistanceTo(c2).Metre()
+	points := CircleIntersections(c1, units.Metre(d/2), c2, units.Metre(d/2), SphericalModel)
+	require.Len(t, points, 1)
+	assert.InDelta(t, float64(d/2), float64(SphericalModel(c1).DistanceTo(points[0]).Metre()), 1e-3)
+
+	// across the antimeridian, with a model argument
+	c3 := NewLatLon(-17.5, 179.9)
+	c4 := NewLatLon(-17.6, -179.8)
+	points = CircleIntersections(c3, units.Km(30), c4, units.Km(30), SphericalModel, Radius(6378137))
+	require.Len(t, points, 2)
+	for _, p := range points {
+		assert.InDelta(t, 30000, float64(SphericalModel(c3, Radius(6378137)).DistanceTo(p).Metre()), 1e-3)
+	}
+}
@@ -0,0 +1,115 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+
+	"github.com/starboard-nz/units"
+)
+
+// ModelComparison is the distance, bearings and midpoint between 2 points according to each of several models, and
+// the differences between the models, see CompareModels. The slices are in the same order as the models, and the
+// deltas are indexed by the pair of models, [i][j] being the value of model j minus the value of model i.
+type ModelComparison struct {
+	Distances       []units.Distance
+	InitialBearings []Degrees
+	FinalBearings   []Degrees
+	MidPoints       []LatLon
+
+	// DistanceDeltas are the differences between the distances
+	DistanceDeltas [][]units.Distance
+	// BearingDeltas are the differences between the initial bearings, -180..180
+	BearingDeltas [][]Degrees
+	// MidPointSeparations are the distances between the midpoints, measured with the first model. They show how far
+	// apart the paths of the models are, which is the error of using one model's path for another.
+	MidPointSeparations [][]units.Distance
+}
+
+// MaxDistanceDelta returns the largest difference between the distances of 2 models, and the indices of the models.
+// Returns 0 and -1, -1 for less than 2 models.
+func (c ModelComparison) MaxDistanceDelta() (units.Distance, int, int) {
+	return maxDelta(c.DistanceDeltas)
+}
+
+// MaxMidPointSeparation returns the largest distance between the midpoints of 2 models, and the indices of the
+// models, for example as a starting point for the tolerance of densification. Returns 0 and -1, -1 for less than 2
+// models.
+func (c ModelComparison) MaxMidPointSeparation() (units.Distance, int, int) {
+	return maxDelta(c.MidPointSeparations)
+}
+
+// maxDelta returns the largest absolute delta, and its indices
+func maxDelta(deltas [][]units.Distance) (units.Distance, int, int) {
+	best, bi, bj := 0.0, -1, -1
+	for i := range deltas {
+		for j := i + 1; j < len(deltas[i]); j++ {
+			if d := math.Abs(float64(deltas[i][j].Metre())); bi < 0 || d > best {
+				best, bi, bj = d, i, j
+			}
+		}
+	}
+
+	return units.Metre(best), bi, bj
+}
+
+// CompareModels returns the distance, bearings and midpoint between `p1` and `p2` according to each of the `models`,
+// and the differences between them, for example to choose tolerances for Densify or to validate data. If no models
+// are given, SphericalModel, RhumbModel and VincentyModel are compared.
+//
+// The bearings are NaN if the points are the same. Model arguments can be passed with closures, for example
+// func(ll geod.LatLon, _ ...interface{}) geod.Model { return geod.VincentyModel(ll, geod.HighPrecision) }.
+//
+// Example:
+// c := geod.CompareModels(geod.NewLatLon(-36.85, 174.76), geod.NewLatLon(-33.87, 151.21))
+// d, i, j := c.MaxDistanceDelta() // 5.1km between spherical (0) and rhumb (1)
+func CompareModels(p1, p2 LatLon, models ...EarthModel) ModelComparison {
+	if len(models) == 0 {
+		models = []EarthModel{SphericalModel, RhumbModel, VincentyModel}
+	}
+
+	n := len(models)
+	c := ModelComparison{
+		Distances:           make([]units.Distance, n),
+		InitialBearings:     make([]Degrees, n),
+		FinalBearings:       make([]Degrees, n),
+		MidPoints:           make([]LatLon, n),
+		DistanceDeltas:      make([][]units.Distance, n),
+		BearingDeltas:       make([][]Degrees, n),
+		MidPointSeparations: make([][]units.Distance, n),
+	}
+
+	for i, model := range models {
+		m := model(p1)
+		if p1.Equals(p2) {
+			// the bearings are undefined between identical points
+			c.Distances[i] = units.Metre(0)
+			c.InitialBearings[i] = Degrees(math.NaN())
+			c.FinalBearings[i] = Degrees(math.NaN())
+			c.MidPoints[i] = p1
+		} else {
+			c.Distances[i] = m.DistanceTo(p2)
+			c.InitialBearings[i] = m.InitialBearingTo(p2)
+			c.FinalBearings[i] = m.FinalBearingOn(p2)
+			c.MidPoints[i] = m.MidPointTo(p2)
+		}
+	}
+
+	for i := range models {
+		c.DistanceDeltas[i] = make([]units.Distance, n)
+		c.BearingDeltas[i] = make([]Degrees, n)
+		c.MidPointSeparations[i] = make([]units.Distance, n)
+
+		for j := range models {
+			c.DistanceDeltas[i][j] = units.Metre(c.Distances[j].Metre() - c.Distances[i].Metre())
+			c.BearingDeltas[i][j] = Wrap180(c.InitialBearings[j] - c.InitialBearings[i])
+			c.MidPointSeparations[i][j] = models[0](c.MidPoints[i]).DistanceTo(c.MidPoints[j])
+		}
+	}
+
+	return c
+}
@@ -0,0 +1,52 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"testing"
+
+	"github.com/starboard-nz/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareModels(t *testing.T) {
+	p1 := NewLatLon(-36.85, 174.76)
+	p2 := NewLatLon(-33.87, 151.21)
+
+	c := CompareModels(p1, p2)
+	require.Len(t, c.Distances, 3)
+	assert.Equal(t, Distance(p1, p2, RhumbModel), c.Distances[1])
+	assert.Equal(t, InitialBearing(p1, p2, VincentyModel), c.InitialBearings[2])
+	assert.Equal(t, FinalBearing(p1, p2, SphericalModel), c.FinalBearings[0])
+
+	for i := 0; i < 3; i++ {
+		assert.Equal(t, 0.0, float64(c.DistanceDeltas[i][i].Metre()))
+		assert.Equal(t, 0.0, float64(c.MidPointSeparations[i][i].Metre()))
+		for j := 0; j < 3; j++ {
+			assert.Equal(t, -c.DistanceDeltas[i][j].Metre(), c.DistanceDeltas[j][i].Metre())
+			assert.InDelta(t, -float64(c.BearingDeltas[i][j]), float64(c.BearingDeltas[j][i]), 1e-9)
+		}
+	}
+
+	// rhumb lines are longer than great circles, and bulge away from the pole
+	assert.Greater(t, float64(c.DistanceDeltas[0][1].Metre()), 0.0)
+	assert.Greater(t, float64(c.MidPoints[1].Latitude), float64(c.MidPoints[0].Latitude))
+	d, i, j := c.MaxDistanceDelta()
+	assert.Equal(t, math.Abs(float64(c.DistanceDeltas[i][j].Metre())), float64(d.Metre()))
+
+	// the same points
+	c = CompareModels(p1, p1, SphericalModel, VincentyModel)
+	assert.Equal(t, units.Metre(0), c.Distances[1])
+	assert.True(t, math.IsNaN(float64(c.InitialBearings[0])))
+	assert.Equal(t, p1, c.MidPoints[1])
+
+	_, i, j = CompareModels(p1, p2, SphericalModel).MaxDistanceDelta()
+	assert.Equal(t, -1, i)
+	assert.Equal(t, -1, j)
+}
@@ -0,0 +1,403 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// crossingSamples is the number of intervals a segment is split into when looking for crossings
+	crossingSamples = 32
+	// crossingTolerance is the fraction of the segment at which bisection stops
+	crossingTolerance = 1e-12
+	// extremeTolerance is the fraction of the segment at which the search for the extreme latitudes stops
+	extremeTolerance = 1e-9
+)
+
+// CrossesParallel returns the points where the segment from `start` to `end` crosses the parallel at latitude `lat`,
+// in order from `start`, using the given `model`. Returns nil if the segment doesn't cross the parallel.
+//
+// Arguments:
+//
+// start - starting point
+// end - end point
+// lat - latitude of the parallel
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// A great circle can cross a parallel twice. Touching the parallel at `end` counts as a crossing, but touching it at
+// `start` doesn't, so that consecutive segments of a track don't report the same crossing twice.
+// The segment is split at its northernmost and southernmost points (see LatitudeExtremes), so segments that only
+// just reach the parallel are found too.
+//
+// Example:
+// p1 := geod.NewLatLon(-38.5, 174.0)
+// p2 := geod.NewLatLon(-41.2, 176.3)
+// crossings := geod.CrossesParallel(p1, p2, -40, geod.VincentyModel)
+func CrossesParallel(start, end LatLon, lat Degrees, model EarthModel, modelArgs ...interface{}) []LatLon {
+	offset := func(ll LatLon) float64 {
+		return float64(ll.Latitude - lat)
+	}
+
+	if start.Equals(end) {
+		return nil
+	}
+
+	north, south := latitudeExtremeFractions(start, end, model(start, modelArgs...))
+	crossings := findCrossings(start, end, offset, []float64{north, south}, model, modelArgs...)
+	for i := range crossings {
+		crossings[i].Latitude = lat
+	}
+
+	return crossings
+}
+
+// CrossesMeridian returns the points where the segment from `start` to `end` crosses the meridian at longitude
+// `lon`, in order from `start`, using the given `model`. Returns nil if the segment doesn't cross the meridian.
+// See CrossesParallel for the arguments.
+//
+// Segments crossing the antimeridian are supported, and longitudes are compared modulo 360, so -180 and 180 are the
+// same meridian. A segment crosses a meridian at most once, unless it passes close to a pole. Touching the meridian at
+// `end` counts as a crossing, but touching it at `start` doesn't.
+//
+// Example:
+// p1 := geod.NewLatLon(-17.5, 178.0)
+// p2 := geod.NewLatLon(-16.0, -178.0)
+// crossings := geod.CrossesMeridian(p1, p2, 180, geod.SphericalModel)
+func CrossesMeridian(start, end LatLon, lon Degrees, model EarthModel, modelArgs ...interface{}) []LatLon {
+	offset := func(ll LatLon) float64 {
+		d := float64(Wrap180(ll.Longitude - lon))
+		// the meridian on the other side of the pole is not a crossing
+		if math.Abs(d) > 90 {
+			return math.NaN()
+		}
+
+		return d
+	}
+
+	crossings := findCrossings(start, end, offset, nil, model, modelArgs...)
+	for i := range crossings {
+		crossings[i].Longitude = Wrap180(lon)
+	}
+
+	return crossings
+}
+
+// mercatorψ returns the Mercator projected latitude ψ = ln(tan(π/4 + φ/2)) of latitude φ in radians
+func mercatorψ(φ float64) float64 {
+	return math.Log(math.Tan(π/4 + φ/2))
+}
+
+// RhumbCrossesParallel returns the point where the rhumb line from `start` to `end` crosses the parallel at latitude
+// `lat`, or nil if it doesn't. Rhumb lines are straight lines on the Mercator projection, so they cross each parallel
+// at most once, and the crossing is calculated directly. As for RhumbModel, the rhumb line takes the shorter way
+// around the Earth, across the antimeridian if needed.
+//
+// Touching the parallel at `end` counts as a crossing, but touching it at `start` doesn't, as for CrossesParallel.
+// Rhumb lines along the parallel (due East or West) don't cross it.
+//
+// Example:
+// p1 := geod.NewLatLon(-38.5, 174.0)
+// p2 := geod.NewLatLon(-41.2, 176.3)
+// crossing := geod.RhumbCrossesParallel(p1, p2, -40)
+func RhumbCrossesParallel(start, end LatLon, lat Degrees) *LatLon {
+	d1 := float64(start.Latitude - lat)
+	d2 := float64(end.Latitude - lat)
+	if d1 == 0 || d2 != 0 && (d1 < 0) == (d2 < 0) {
+		return nil
+	}
+
+	if d2 == 0 {
+		return &LatLon{Latitude: lat, Longitude: Wrap180(end.Longitude)}
+	}
+
+	ψ1 := mercatorψ(start.Latitude.Radians())
+	ψ2 := mercatorψ(end.Latitude.Radians())
+	t := (mercatorψ(lat.Radians()) - ψ1) / (ψ2 - ψ1)
+
+	return &LatLon{Latitude: lat, Longitude: Wrap180(start.Longitude + Degrees(t*rhumbΔλ(start, end)))}
+}
+
+// RhumbCrossesMeridian returns the point where the rhumb line from `start` to `end` crosses the meridian at longitude
+// `lon`, or nil if it doesn't. Rhumb lines take the shorter way around the Earth, so they cross each meridian at most
+// once. Longitudes are compared modulo 360, so -180 and 180 are the same meridian.
+//
+// Touching the meridian at `end` counts as a crossing, but touching it at `start` doesn't, as for CrossesMeridian.
+// Rhumb lines along the meridian (due North or South) don't cross it.
+//
+// Example:
+// p1 := geod.NewLatLon(-17.5, 178.0)
+// p2 := geod.NewLatLon(-16.0, -178.0)
+// crossing := geod.RhumbCrossesMeridian(p1, p2, 180)
+func RhumbCrossesMeridian(start, end LatLon, lon Degrees) *LatLon {
+	d, Δλ, ok := meridianOffset(start, end, lon)
+	if !ok {
+		return nil
+	}
+
+	if d == Δλ {
+		return &LatLon{Latitude: end.Latitude, Longitude: Wrap180(lon)}
+	}
+
+	ψ1 := mercatorψ(start.Latitude.Radians())
+	ψ2 := mercatorψ(end.Latitude.Radians())
+	ψ := ψ1 + d/Δλ*(ψ2-ψ1)
+
+	return &LatLon{Latitude: DegreesFromRadians(2*math.Atan(math.Exp(ψ)) - π/2), Longitude: Wrap180(lon)}
+}
+
+// rhumbΔλ returns the difference of longitude from `start` to `end` in degrees, taking the shorter way around
+func rhumbΔλ(start, end LatLon) float64 {
+	Δλ := float64(Wrap180(end.Longitude - start.Longitude))
+	if Δλ == 180 && end.Longitude < start.Longitude {
+		Δλ = -180
+	}
+
+	return Δλ
+}
+
+// meridianOffset returns the differences of longitude, in degrees, from `start` to the meridian at `lon` and to
+// `end`, taking the shorter way around, and whether the meridian is crossed: after `start` and no further than `end`
+func meridianOffset(start, end LatLon, lon Degrees) (float64, float64, bool) {
+	Δλ := rhumbΔλ(start, end)
+	d := float64(Wrap180(lon - start.Longitude))
+	if Δλ < 0 && d == 180 {
+		d = -180
+	}
+
+	ok := Δλ != 0 && d != 0 && (d < 0) == (Δλ < 0) && math.Abs(d) <= math.Abs(Δλ)
+
+	return d, Δλ, ok
+}
+
+// findCrossings returns the points along the segment where `offset` changes sign or becomes 0. The segment is
+// sampled at regular intervals and at the `extra` fractions. Intervals where `offset` returns NaN at either end are
+// skipped.
+func findCrossings(start, end LatLon, offset func(LatLon) float64, extra []float64, model EarthModel,
+	modelArgs ...interface{}) []LatLon {
+
+	if start.Equals(end) {
+		return nil
+	}
+
+	m := model(start, modelArgs...)
+	pointAt := func(f float64) LatLon {
+		switch f {
+		case 0:
+			return start
+		case 1:
+			return end
+		}
+
+		return m.IntermediatePointTo(end, f)
+	}
+
+	fractions := make([]float64, 0, crossingSamples+len(extra))
+	for i := 1; i <= crossingSamples; i++ {
+		fractions = append(fractions, float64(i)/crossingSamples)
+	}
+	for _, f := range extra {
+		if f > 0 && f < 1 {
+			fractions = append(fractions, f)
+		}
+	}
+	sort.Float64s(fractions)
+
+	var crossings []LatLon
+
+	f0 := 0.0
+	g0 := offset(start)
+	for _, f1 := range fractions {
+		if f1 == f0 {
+			continue
+		}
+
+		p1 := pointAt(f1)
+		g1 := offset(p1)
+
+		switch {
+		case g1 == 0:
+			crossings = append(crossings, p1)
+		case g0 == 0 || math.IsNaN(g0) || math.IsNaN(g1) || (g0 < 0) == (g1 < 0):
+			// no crossing, or already reported
+		default:
+			// bisect
+			lo, hi, glo := f0, f1, g0
+			for hi-lo > crossingTolerance {
+				mid := (lo + hi) / 2
+				gm := offset(pointAt(mid))
+				if gm == 0 {
+					lo, hi = mid, mid
+
+					break
+				}
+				if math.IsNaN(gm) || (gm < 0) != (glo < 0) {
+					hi = mid
+				} else {
+					lo, glo = mid, gm
+				}
+			}
+			crossings = append(crossings, pointAt((lo+hi)/2))
+		}
+
+		f0, g0 = f1, g1
+	}
+
+	return crossings
+}
+
+// LatitudeExtremes returns the northernmost and southernmost points reached along the segment from `start` to `end`
+// using the given `model`. These are the endpoints unless the path curves towards a pole, as great circles and
+// geodesics do, in which case one of them is the vertex of the path. See CrossesParallel for the arguments.
+//
+// Example:
+// p1 := geod.NewLatLon(50, -30)
+// p2 := geod.NewLatLon(50, 30)
+// north, south := geod.LatitudeExtremes(p1, p2, geod.SphericalModel) // 53.9948°N, 0°E and p1
+func LatitudeExtremes(start, end LatLon, model EarthModel, modelArgs ...interface{}) (LatLon, LatLon) {
+	if start.Equals(end) {
+		return start, start
+	}
+
+	m := model(start, modelArgs...)
+	north, south := latitudeExtremeFractions(start, end, m)
+	pointAt := func(f float64) LatLon {
+		switch f {
+		case 0:
+			return start
+		case 1:
+			return end
+		}
+
+		return m.IntermediatePointTo(end, f)
+	}
+
+	return pointAt(north), pointAt(south)
+}
+
+// latitudeExtremeFractions returns the fractions of the segment where it is furthest north and south. `m` is the
+// model of `start`.
+func latitudeExtremeFractions(start, end LatLon, m Model) (float64, float64) {
+	latAt := func(f float64) float64 {
+		return float64(m.IntermediatePointTo(end, f).Latitude)
+	}
+
+	north, _ := maximiseAlong(latAt, float64(start.Latitude), float64(end.Latitude))
+	south, _ := maximiseAlong(func(f float64) float64 { return -latAt(f) }, -float64(start.Latitude), -float64(end.Latitude))
+
+	return north, south
+}
+
+// maximiseAlong returns where `g` has its maximum in the 0..1 range, and the maximum. `g0` and `g1` are the values
+// at the ends. The range is sampled in crossingSamples steps and the best interior sample refined using
+// golden-section search, which finds the maximum if `g` has at most one interior maximum.
+func maximiseAlong(g func(float64) float64, g0, g1 float64) (float64, float64) {
+	best, bestG := 0.0, g0
+	if g1 > bestG {
+		best, bestG = 1, g1
+	}
+
+	step := 1.0 / crossingSamples
+	x, xg := -1.0, math.Inf(-1)
+	for i := 1; i < crossingSamples; i++ {
+		if v := g(float64(i) * step); v > xg {
+			x, xg = float64(i)*step, v
+		}
+	}
+
+	if xg <= bestG {
+		return best, bestG
+	}
+
+	φ := (math.Sqrt(5) - 1) / 2
+	a, b := x-step, x+step
+	c := b - φ*(b-a)
+	d := a + φ*(b-a)
+	gc, gd := g(c), g(d)
+	for b-a > extremeTolerance {
+		if gc > gd {
+			b, d, gd = d, c, gc
+			c = b - φ*(b-a)
+			gc = g(c)
+		} else {
+			a, c, gc = c, d, gd
+			d = a + φ*(b-a)
+			gd = g(d)
+		}
+	}
+
+	for _, v := range [][2]float64{{c, gc}, {d, gd}} {
+		if v[1] > xg {
+			x, xg = v[0], v[1]
+		}
+	}
+
+	return x, xg
+}
+
+// AntimeridianCrossing returns the latitude at which the segment from `p1` to `p2` crosses the antimeridian (±180°)
+// using the given `model`, or nil if it doesn't. See CrossesParallel for the arguments.
+//
+// The crossing is calculated directly for SphericalModel and RhumbModel, and found with CrossesMeridian for the other
+// models. As for CrossesMeridian, touching the antimeridian at `p2` counts as a crossing, but touching it at `p1`
+// doesn't. Segments passing over a pole are not supported.
+//
+// Example:
+// p1 := geod.NewLatLon(-17.5, 178.0)
+// p2 := geod.NewLatLon(-16.0, -178.0)
+// lat := geod.AntimeridianCrossing(p1, p2, geod.SphericalModel) // -16.7°
+func AntimeridianCrossing(p1, p2 LatLon, model EarthModel, modelArgs ...interface{}) *Degrees {
+	switch model(p1, modelArgs...).(type) {
+	case LatLonRhumb:
+		crossing := RhumbCrossesMeridian(p1, p2, 180)
+		if crossing == nil {
+			return nil
+		}
+
+		return &crossing.Latitude
+	case LatLonSpherical:
+		return greatCircleAntimeridianCrossing(p1, p2)
+	}
+
+	crossings := CrossesMeridian(p1, p2, 180, model, modelArgs...)
+	if len(crossings) == 0 {
+		return nil
+	}
+
+	return &crossings[0].Latitude
+}
+
+// greatCircleAntimeridianCrossing returns the latitude at which the great circle arc from `p1` to `p2` crosses the
+// antimeridian, or nil
+func greatCircleAntimeridianCrossing(p1, p2 LatLon) *Degrees {
+	d, Δλ, ok := meridianOffset(p1, p2, 180)
+	if !ok {
+		return nil
+	}
+
+	if d == Δλ {
+		lat := p2.Latitude
+		return &lat
+	}
+
+	// the intersection of the great circle with the plane of the antimeridian (y = 0), on the side of x < 0
+	n := GreatCircleNormal(p1, p2)
+	lat := DegreesFromRadians(math.Atan2(n.X, math.Abs(n.Z)))
+	if n.Z < 0 {
+		lat = -lat
+	}
+
+	return &lat
+}
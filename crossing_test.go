@@ -0,0 +1,191 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// assertOnSegment checks that `p` is on the segment, by comparing the distances via `p` with the length of the segment
+func assertOnSegment(t *testing.T, start, end, p LatLon, model EarthModel) {
+	t.Helper()
+
+	total := model(start).DistanceTo(end).Metre()
+	via := model(start).DistanceTo(p).Metre() + model(p).DistanceTo(end).Metre()
+	assert.InDelta(t, float64(total), float64(via), 1e-3)
+}
+
+func TestCrossesParallel(t *testing.T) {
+	p1 := NewLatLon(-38.5, 174.0)
+	p2 := NewLatLon(-41.2, 176.3)
+
+	for _, model := range []EarthModel{RhumbModel, SphericalModel, VincentyModel} {
+		crossings := CrossesParallel(p1, p2, -40, model)
+		require.Len(t, crossings, 1)
+		assert.Equal(t, Degrees(-40), crossings[0].Latitude)
+		assertOnSegment(t, p1, p2, crossings[0], model)
+	}
+
+	// the great circle between 2 points at 50°N reaches 54°N
+	p1 = NewLatLon(50, -30)
+	p2 = NewLatLon(50, 30)
+	crossings := CrossesParallel(p1, p2, 53, SphericalModel)
+	require.Len(t, crossings, 2)
+	assert.Less(t, float64(crossings[0].Longitude), 0.0)
+	assert.InDelta(t, -float64(crossings[0].Longitude), float64(crossings[1].Longitude), 1e-6)
+	assertOnSegment(t, p1, p2, crossings[0], SphericalModel)
+	assert.Nil(t, CrossesParallel(p1, p2, 53, RhumbModel))
+	assert.Nil(t, CrossesParallel(p1, p2, 55, SphericalModel))
+
+	// touching at the end counts, at the start doesn't
+	assert.Len(t, CrossesParallel(NewLatLon(10, 0), NewLatLon(11, 1), 11, SphericalModel), 1)
+	assert.Nil(t, CrossesParallel(NewLatLon(11, 1), NewLatLon(10, 0), 11, SphericalModel))
+	assert.Nil(t, CrossesParallel(p1, p1, 50, VincentyModel))
+}
+
+func TestCrossesMeridian(t *testing.T) {
+	p1 := NewLatLon(-17.5, 178.0)
+	p2 := NewLatLon(-16.0, -178.0)
+
+	for _, lon := range []Degrees{180, -180} {
+		crossings := CrossesMeridian(p1, p2, lon, SphericalModel)
+		require.Len(t, crossings, 1)
+		assert.Equal(t, Wrap180(lon), crossings[0].Longitude)
+		assert.InDelta(t, -16.76, float64(crossings[0].Latitude), 0.01)
+		assertOnSegment(t, p1, p2, crossings[0], SphericalModel)
+	}
+
+	crossings := CrossesMeridian(p2, p1, 179, RhumbModel)
+	require.Len(t, crossings, 1)
+	assertOnSegment(t, p2, p1, crossings[0], RhumbModel)
+
+	assert.Nil(t, CrossesMeridian(p1, p2, 0, SphericalModel))
+	assert.Nil(t, CrossesMeridian(p1, p2, 170, SphericalModel))
+
+	// great circles bulge towards the pole
+	crossings = CrossesMeridian(NewLatLon(80, -10), NewLatLon(80, 10), 0, SphericalModel)
+	require.Len(t, crossings, 1)
+	assert.Greater(t, float64(crossings[0].Latitude), 80.0)
+}
+
+func TestLatitudeExtremes(t *testing.T) {
+	p1 := NewLatLon(50, -30)
+	p2 := NewLatLon(50, 30)
+
+	north, south := LatitudeExtremes(p1, p2, SphericalModel)
+	assert.InDelta(t, 53.99479, float64(north.Latitude), 1e-5)
+	assert.InDelta(t, 0, float64(north.Longitude), 1e-4)
+	assert.Equal(t, p1, south)
+
+	// rhumb lines don't curve
+	north, south = LatitudeExtremes(p1, NewLatLon(40, 30), RhumbModel)
+	assert.Equal(t, p1, north)
+	assert.Equal(t, NewLatLon(40, 30), south)
+
+	// southern hemisphere geodesics curve south
+	north, south = LatitudeExtremes(NewLatLon(-45, 160), NewLatLon(-44, -170), VincentyModel)
+	assert.Equal(t, NewLatLon(-44, -170), north)
+	assert.Less(t, float64(south.Latitude), -45.5)
+
+	// a segment that only just reaches the parallel
+	crossings := CrossesParallel(p1, p2, 53.9947, SphericalModel)
+	require.Len(t, crossings, 2)
+	assert.InDelta(t, -float64(crossings[0].Longitude), float64(crossings[1].Longitude), 1e-6)
+
+	north, south = LatitudeExtremes(p1, p1, SphericalModel)
+	assert.Equal(t, p1, north)
+	assert.Equal(t, p1, south)
+}
+
+func TestRhumbCrossesParallel(t *testing.T) {
+	p1 := NewLatLon(-38.5, 174.0)
+	p2 := NewLatLon(-41.2, 176.3)
+
+	c := RhumbCrossesParallel(p1, p2, -40)
+	require.NotNil(t, c)
+	assert.Equal(t, Degrees(-40), c.Latitude)
+	assert.InDelta(t, float64(InitialBearing(p1, p2, RhumbModel)), float64(InitialBearing(p1, *c, RhumbModel)), 1e-9)
+	assert.InDelta(t, float64(InitialBearing(p1, p2, RhumbModel)), float64(InitialBearing(*c, p2, RhumbModel)), 1e-9)
+	assert.Equal(t, c, RhumbCrossesParallel(p2, p1, -40))
+
+	assert.Nil(t, RhumbCrossesParallel(p1, p2, -42))
+	assert.Nil(t, RhumbCrossesParallel(p1, p2, -38.5))
+	assert.Equal(t, &p2, RhumbCrossesParallel(p1, p2, -41.2))
+	assert.Nil(t, RhumbCrossesParallel(NewLatLon(-40, 170), NewLatLon(-40, 175), -40))
+
+	// across the antimeridian
+	c = RhumbCrossesParallel(NewLatLon(-17.5, 178.0), NewLatLon(-16.0, -178.0), -16.75)
+	require.NotNil(t, c)
+	assert.InDelta(t, 180, math.Abs(float64(c.Longitude)), 0.01)
+}
+
+func TestRhumbCrossesMeridian(t *testing.T) {
+	p1 := NewLatLon(-17.5, 178.0)
+	p2 := NewLatLon(-16.0, -178.0)
+
+	for _, lon := range []Degrees{180, -180} {
+		c := RhumbCrossesMeridian(p1, p2, lon)
+		require.NotNil(t, c)
+		assert.Equal(t, Wrap180(lon), c.Longitude)
+		assert.InDelta(t, float64(InitialBearing(p1, p2, RhumbModel)), float64(InitialBearing(p1, *c, RhumbModel)), 1e-9)
+		assert.InDelta(t, -16.75, float64(c.Latitude), 0.01)
+		assert.Equal(t, c, RhumbCrossesMeridian(p2, p1, lon))
+	}
+
+	assert.Nil(t, RhumbCrossesMeridian(p1, p2, 0))
+	assert.Nil(t, RhumbCrossesMeridian(p1, p2, 178))
+	assert.Nil(t, RhumbCrossesMeridian(p1, p2, 177))
+	assert.Equal(t, &p2, RhumbCrossesMeridian(p1, p2, -178))
+	assert.Nil(t, RhumbCrossesMeridian(NewLatLon(-10, 170), NewLatLon(-20, 170), 170))
+
+	// the crossing is on the rhumb line
+	p3 := NewLatLon(50, -5)
+	p4 := NewLatLon(58.6, 3.1)
+	c := RhumbCrossesMeridian(p3, p4, 0)
+	require.NotNil(t, c)
+	assert.Equal(t, c.Latitude, RhumbCrossesParallel(p3, p4, c.Latitude).Latitude)
+	assert.InDelta(t, 0, float64(RhumbCrossesParallel(p3, p4, c.Latitude).Longitude), 1e-9)
+}
+
+func TestAntimeridianCrossing(t *testing.T) {
+	p1 := NewLatLon(-17.5, 178.0)
+	p2 := NewLatLon(-16.0, -178.0)
+
+	for _, model := range []EarthModel{SphericalModel, RhumbModel, VincentyModel} {
+		lat := AntimeridianCrossing(p1, p2, model)
+		require.NotNil(t, lat)
+		assertOnSegment(t, p1, p2, LatLon{Latitude: *lat, Longitude: 180}, model)
+
+		back := AntimeridianCrossing(p2, p1, model)
+		require.NotNil(t, back)
+		assert.InDelta(t, float64(*lat), float64(*back), 1e-6)
+
+		assert.Nil(t, AntimeridianCrossing(p1, NewLatLon(-16, 179), model))
+		assert.Nil(t, AntimeridianCrossing(NewLatLon(10, -10), NewLatLon(20, 10), model))
+	}
+
+	// the same as CrossesMeridian
+	p3 := NewLatLon(50, 150)
+	p4 := NewLatLon(40, -120)
+	lat := AntimeridianCrossing(p3, p4, SphericalModel)
+	require.NotNil(t, lat)
+	crossings := CrossesMeridian(p3, p4, 180, SphericalModel)
+	require.Len(t, crossings, 1)
+	assert.InDelta(t, float64(crossings[0].Latitude), float64(*lat), 1e-6)
+
+	// ending on the antimeridian counts, starting on it doesn't
+	p5 := NewLatLon(-16, 180)
+	lat = AntimeridianCrossing(p1, p5, SphericalModel)
+	require.NotNil(t, lat)
+	assert.Equal(t, Degrees(-16), *lat)
+	assert.Nil(t, AntimeridianCrossing(p5, p1, SphericalModel))
+	assert.Nil(t, AntimeridianCrossing(p5, p2, SphericalModel))
+}
@@ -0,0 +1,269 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// CRS is a coordinate reference system: the datum the coordinates are given on, and for projected CRSs the map
+// projection from latitude and longitude to grid coordinates. CRSs are created with GeographicCRS and ProjectedCRS,
+// the common ones are predefined (e.g. WGS84CRS, WebMercatorCRS), and CRSs can be found by their EPSG codes with
+// CRSFromEPSG and LookupCRS.
+type CRS struct {
+	// Name is the usual name of the CRS, e.g. "WGS 84 / Pseudo-Mercator"
+	Name string
+	// EPSG is the EPSG code of the CRS, e.g. 3857, or 0 if it doesn't have one
+	EPSG int
+	// Datum is the datum of the coordinates
+	Datum Datum
+
+	projection Projection
+	inverse    func(x, y float64) LatLon
+}
+
+// Predefined CRSs. Sinusoidal and Robinson are projections of the sphere (see SetEarthRadius), so they are only
+// suitable for plotting.
+var (
+	WGS84CRS       = GeographicCRS(wgs84Datum)
+	WebMercatorCRS = ProjectedCRS("WGS 84 / Pseudo-Mercator", 3857, wgs84Datum, WebMercator, WebMercatorInverse)
+	SinusoidalCRS  = ProjectedCRS("World Sinusoidal", 0, wgs84Datum, Sinusoidal, SinusoidalInverse)
+	RobinsonCRS    = ProjectedCRS("World Robinson", 0, wgs84Datum, Robinson, RobinsonInverse)
+
+	// BritishNationalGridCRS is the Ordnance Survey National Grid, on OSGB36
+	BritishNationalGridCRS = newTransverseMercatorCRS("OSGB36 / British National Grid", 27700, osgb36Datum,
+		NewTransverseMercator(airy1830, 49, -2, 0.9996012717, 400000, -100000))
+)
+
+var (
+	crsMutex sync.RWMutex
+	// the CRSs registered with RegisterCRS, by EPSG code
+	registeredCRSs = map[int]CRS{}
+)
+
+// GeographicCRS returns the CRS of latitudes and longitudes on the datum, with the name and EPSG code of the datum.
+//
+// Example:
+// osgb36, _ := geod.LookupDatum("OSGB36")
+// crs := geod.GeographicCRS(osgb36)
+func GeographicCRS(datum Datum) CRS {
+	return CRS{Name: datum.Name, EPSG: datum.EPSG, Datum: datum}
+}
+
+// ProjectedCRS returns the CRS of the grid coordinates of the `projection` of latitudes and longitudes on the datum.
+// `inverse` returns the point at the grid coordinates, or an invalid point (see LatLon.Valid) if they are outside
+// the map. `epsg` is the EPSG code of the CRS, 0 if it doesn't have one.
+func ProjectedCRS(name string, epsg int, datum Datum, projection Projection, inverse func(x, y float64) LatLon) CRS {
+	return CRS{Name: name, EPSG: epsg, Datum: datum, projection: projection, inverse: inverse}
+}
+
+// newTransverseMercatorCRS returns the projected CRS using the Transverse Mercator projection
+func newTransverseMercatorCRS(name string, epsg int, datum Datum, tm TransverseMercator) CRS {
+	return ProjectedCRS(name, epsg, datum, tm.Project, tm.Inverse)
+}
+
+// UTMCRS returns the CRS of the UTM zone (1..60) of the northern or southern hemisphere, on WGS84, e.g. EPSG:32633
+// for zone 33N. Returns an error wrapping ErrUnknownCRS if the zone is not valid.
+//
+// Example:
+// crs, _ := geod.UTMCRS(60, false)                            // EPSG:32760, WGS 84 / UTM zone 60S
+func UTMCRS(zone int, north bool) (CRS, error) {
+	if zone < 1 || zone > 60 {
+		return CRS{}, fmt.Errorf("%w: UTM zone %d", ErrUnknownCRS, zone)
+	}
+
+	epsg, hemisphere, falseNorthing := 32600+zone, "N", 0.0
+	if !north {
+		epsg, hemisphere, falseNorthing = 32700+zone, "S", 10000000
+	}
+
+	lon0 := Degrees((zone-1)*6 - 180 + 3)
+	tm := NewTransverseMercator(wgs84, 0, lon0, 0.9996, 500000, falseNorthing)
+
+	return newTransverseMercatorCRS(fmt.Sprintf("WGS 84 / UTM zone %d%s", zone, hemisphere), epsg, wgs84Datum, tm), nil
+}
+
+// RegisterCRS registers the CRS with its EPSG code, so that it can be found with CRSFromEPSG and LookupCRS, for
+// example a national grid created with NewTransverseMercator and ProjectedCRS. Registering a code again replaces
+// the CRS, so the predefined CRSs can be replaced too.
+//
+// Panics with an error wrapping ErrInvalidArgument if the CRS doesn't have an EPSG code.
+func RegisterCRS(crs CRS) {
+	if crs.EPSG <= 0 {
+		panic(fmt.Errorf("%w: CRS %q without EPSG code", ErrInvalidArgument, crs.Name))
+	}
+
+	crsMutex.Lock()
+	defer crsMutex.Unlock()
+
+	registeredCRSs[crs.EPSG] = crs
+}
+
+// CRSFromEPSG returns the CRS with the given EPSG code. The supported CRSs are the geographic CRSs of the datums
+// (e.g. 4326 for WGS84, 4277 for OSGB36, see LookupDatum), Web Mercator (3857), the British National Grid (27700),
+// the UTM zones on WGS84 (32601..32660 and 32701..32760), and the CRSs registered with RegisterCRS. Returns an error
+// wrapping ErrUnknownCRS for other codes.
+//
+// Example:
+// crs, err := geod.CRSFromEPSG(27700)
+// if err != nil {
+// ... handle the error
+// }
+// grid, err := geod.NewCoordinate(p, 0, geod.WGS84CRS).Transform(crs)
+func CRSFromEPSG(code int) (CRS, error) {
+	crsMutex.RLock()
+	crs, ok := registeredCRSs[code]
+	crsMutex.RUnlock()
+
+	if ok {
+		return crs, nil
+	}
+
+	switch {
+	case code == WebMercatorCRS.EPSG:
+		return WebMercatorCRS, nil
+	case code == BritishNationalGridCRS.EPSG:
+		return BritishNationalGridCRS, nil
+	case code > 32600 && code <= 32660:
+		return UTMCRS(code-32600, true)
+	case code > 32700 && code <= 32760:
+		return UTMCRS(code-32700, false)
+	}
+
+	for _, d := range datums {
+		if d.EPSG == code {
+			return GeographicCRS(d), nil
+		}
+	}
+
+	return CRS{}, fmt.Errorf("%w: EPSG:%d", ErrUnknownCRS, code)
+}
+
+// LookupCRS returns the CRS identified by `name`, as found in data files: an EPSG code, e.g. "EPSG:27700",
+// "urn:ogc:def:crs:EPSG::27700" or "27700", "urn:ogc:def:crs:OGC:1.3:CRS84" or "CRS84" (WGS84 with longitudes
+// first), or the name of a datum, e.g. "OSGB36", for its geographic CRS. Names are not case sensitive. Returns an
+// error wrapping ErrUnknownCRS if the CRS isn't supported, see CRSFromEPSG.
+//
+// Example:
+// crs, err := geod.LookupCRS("urn:ogc:def:crs:EPSG::27700")
+// if err != nil {
+// ... handle the error
+// }
+// c, err := geod.NewGridCoordinate(easting, northing, 0, crs).Transform(geod.WGS84CRS)
+func LookupCRS(name string) (CRS, error) {
+	s := strings.TrimSpace(name)
+	lower := strings.ToLower(s)
+
+	if lower == "crs84" || lower == "urn:ogc:def:crs:ogc:1.3:crs84" || lower == "urn:ogc:def:crs:ogc::crs84" {
+		return WGS84CRS, nil
+	}
+
+	switch {
+	case strings.HasPrefix(lower, "urn:ogc:def:crs:epsg:"):
+		// the code is after the optional version, e.g. "urn:ogc:def:crs:EPSG:6.6:4326"
+		s = s[strings.LastIndex(s, ":")+1:]
+	case strings.HasPrefix(lower, "epsg:"):
+		s = strings.TrimSpace(s[len("epsg:"):])
+	}
+
+	if code, err := strconv.Atoi(s); err == nil {
+		return CRSFromEPSG(code)
+	}
+
+	if d, ok := LookupDatum(s); ok {
+		return GeographicCRS(d), nil
+	}
+
+	return CRS{}, fmt.Errorf("%w: %q", ErrUnknownCRS, name)
+}
+
+// Projected returns true if the coordinates of the CRS are grid coordinates, false if they are latitudes and
+// longitudes.
+func (c CRS) Projected() bool {
+	return c.projection != nil
+}
+
+// String returns the EPSG code of the CRS, e.g. "EPSG:3857", or its name if it doesn't have one.
+func (c CRS) String() string {
+	if c.EPSG != 0 {
+		return fmt.Sprintf("EPSG:%d", c.EPSG)
+	}
+
+	return c.Name
+}
+
+// Coordinate is a position in a CRS: a latitude and longitude for geographic CRSs, or grid coordinates for projected
+// CRSs, and the height above the ellipsoid of the datum.
+type Coordinate struct {
+	// LatLon is the position, for geographic CRSs
+	LatLon LatLon
+	// X and Y are the grid coordinates, for projected CRSs, usually in metres towards grid East and grid North
+	X, Y float64
+	// Height is the height above the ellipsoid, in metres
+	Height float64
+	// CRS is the coordinate reference system of the coordinates
+	CRS CRS
+}
+
+// NewCoordinate returns the coordinate of the point in a geographic CRS.
+//
+// Example:
+// c := geod.NewCoordinate(geod.NewLatLon(-41.29, 174.78), 0, geod.WGS84CRS)
+func NewCoordinate(ll LatLon, height float64, crs CRS) Coordinate {
+	return Coordinate{LatLon: ll, Height: height, CRS: crs}
+}
+
+// NewGridCoordinate returns the coordinate of the point at the grid coordinates of a projected CRS.
+//
+// Example:
+// c := geod.NewGridCoordinate(19456420.6, -5055211.2, 0, geod.WebMercatorCRS)
+func NewGridCoordinate(x, y, height float64, crs CRS) Coordinate {
+	return Coordinate{X: x, Y: y, Height: height, CRS: crs}
+}
+
+// Transform returns the coordinate converted to the `target` CRS. The point is unprojected if its CRS is projected,
+// converted to the datum of the target with the Helmert transformations of the datums (see Datum.ToWGS84), if
+// the datums are different, and projected if the target is projected. Returns ErrOutsideProjection if the point
+// can't be unprojected or projected, and ErrInvalidArgument if the latitude or longitude are NaN.
+//
+// Example:
+// c := geod.NewCoordinate(geod.NewLatLon(-41.29, 174.78), 0, geod.WGS84CRS)
+// grid, err := c.Transform(geod.WebMercatorCRS)
+// fmt.Printf("%.1f %.1f\n", grid.X, grid.Y)                             // 19456420.6 -5055211.2
+func (c Coordinate) Transform(target CRS) (Coordinate, error) {
+	ll := c.LatLon
+	if c.CRS.Projected() {
+		ll = c.CRS.inverse(c.X, c.Y)
+		if !ll.Valid() {
+			return Coordinate{}, fmt.Errorf("%w: %g, %g in %s", ErrOutsideProjection, c.X, c.Y, c.CRS)
+		}
+	} else if !ll.Valid() {
+		return Coordinate{}, fmt.Errorf("%w: invalid coordinates %v, %v", ErrInvalidArgument, ll.Latitude, ll.Longitude)
+	}
+
+	height := c.Height
+	if c.CRS.Datum.Name != target.Datum.Name {
+		wgs := c.CRS.Datum.ToWGS84(LatLonEllipsoidal{LatLon: ll, Height: height})
+		p := target.Datum.FromWGS84(wgs)
+		ll, height = p.LatLon, p.Height
+	}
+
+	if !target.Projected() {
+		return NewCoordinate(ll, height, target), nil
+	}
+
+	x, y := target.projection(ll)
+	if math.IsNaN(x) || math.IsNaN(y) {
+		return Coordinate{}, fmt.Errorf("%w: %v, %v in %s", ErrOutsideProjection, ll.Latitude, ll.Longitude, target)
+	}
+
+	return NewGridCoordinate(x, y, height, target), nil
+}
@@ -0,0 +1,164 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCoordinateTransform(t *testing.T) {
+	ll := NewLatLon(-41.29, 174.78)
+	c := NewCoordinate(ll, 10, WGS84CRS)
+
+	grid, err := c.Transform(WebMercatorCRS)
+	require.NoError(t, err)
+	assert.InDelta(t, 19456420.6, grid.X, 0.1)
+	assert.InDelta(t, -5055211.2, grid.Y, 0.1)
+	assert.Equal(t, 10.0, grid.Height)
+	assert.Equal(t, "EPSG:3857", grid.CRS.String())
+
+	back, err := grid.Transform(WGS84CRS)
+	require.NoError(t, err)
+	assert.InDelta(t, float64(ll.Latitude), float64(back.LatLon.Latitude), 1e-9)
+	assert.InDelta(t, float64(ll.Longitude), float64(back.LatLon.Longitude), 1e-9)
+	assert.False(t, back.CRS.Projected())
+
+	// the same as the datum transformation
+	osgb36, ok := LookupDatum("OSGB36")
+	require.True(t, ok)
+	greenwich := NewCoordinate(NewLatLon(51.47788, -0.00147), 0, WGS84CRS)
+	local, err := greenwich.Transform(GeographicCRS(osgb36))
+	require.NoError(t, err)
+	expected := osgb36.FromWGS84(NewLatLonEllipsodial(51.47788, -0.00147, 0))
+	assert.Equal(t, expected.LatLon, local.LatLon)
+	assert.Equal(t, expected.Height, local.Height)
+
+	// between projected CRSs, via the datums, the inverse Helmert transformation is approximate
+	onGrid, err := local.Transform(WebMercatorCRS)
+	require.NoError(t, err)
+	direct, err := greenwich.Transform(WebMercatorCRS)
+	require.NoError(t, err)
+	assert.InDelta(t, direct.X, onGrid.X, 0.01)
+	assert.InDelta(t, direct.Y, onGrid.Y, 0.01)
+
+	robinson, err := grid.Transform(RobinsonCRS)
+	require.NoError(t, err)
+	x, y := Robinson(back.LatLon)
+	assert.InDelta(t, x, robinson.X, 1e-3)
+	assert.InDelta(t, y, robinson.Y, 1e-3)
+	assert.Equal(t, "World Robinson", robinson.CRS.String())
+
+	_, err = NewGridCoordinate(0, 3*earthRadius, 0, SinusoidalCRS).Transform(WGS84CRS)
+	assert.ErrorIs(t, err, ErrOutsideProjection)
+	_, err = NewCoordinate(NewLatLon(90, 0), 0, WGS84CRS).Transform(WebMercatorCRS)
+	assert.ErrorIs(t, err, ErrOutsideProjection)
+	_, err = NewCoordinate(LatLon{Latitude: Degrees(math.NaN())}, 0, WGS84CRS).Transform(WebMercatorCRS)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
+
+func TestWebMercator(t *testing.T) {
+	x, y := WebMercator(NewLatLon(0, 180))
+	assert.InDelta(t, math.Pi*wgs84.a, x, 1e-6)
+	assert.InDelta(t, 0, y, 1e-9)
+
+	// the edge of the square map
+	x, y = WebMercator(LatLon{Latitude: MercatorMaxLat, Longitude: -180})
+	assert.InDelta(t, -x, y, 1e-3)
+
+	// the same as MercatorPoint, scaled
+	ll := NewLatLon(-41.29, 174.78)
+	mp := ll.MercatorPoint()
+	x, y = WebMercator(ll)
+	assert.InDelta(t, (mp.X-0.5)*2*math.Pi*wgs84.a, x, 1e-6)
+	assert.InDelta(t, (mp.Y-0.5)*2*math.Pi*wgs84.a, y, 1e-6)
+
+	back := WebMercatorInverse(x, y)
+	assert.InDelta(t, float64(ll.Latitude), float64(back.Latitude), 1e-9)
+	assert.InDelta(t, float64(ll.Longitude), float64(back.Longitude), 1e-9)
+	assert.False(t, WebMercatorInverse(4*wgs84.a, 0).Valid())
+
+	x, _ = WebMercator(NewLatLon(-90, 0))
+	assert.True(t, math.IsNaN(x))
+}
+
+func TestLookupCRS(t *testing.T) {
+	for name, epsg := range map[string]int{
+		"EPSG:4326":                     4326,
+		"epsg:3857":                     3857,
+		"urn:ogc:def:crs:EPSG::27700":   27700,
+		"urn:ogc:def:crs:EPSG:6.6:4277": 4277,
+		"urn:ogc:def:crs:OGC:1.3:CRS84": 4326,
+		" 32760 ":                       32760,
+		"OSGB36":                        4277,
+		"EPSG:32601":                    32601,
+	} {
+		crs, err := LookupCRS(name)
+		if assert.NoError(t, err, name) {
+			assert.Equal(t, epsg, crs.EPSG, name)
+		}
+	}
+
+	for _, name := range []string{"EPSG:32661", "EPSG:32700", "EPSG:1234", "Mars", ""} {
+		_, err := LookupCRS(name)
+		assert.ErrorIs(t, err, ErrUnknownCRS, name)
+	}
+
+	crs, err := CRSFromEPSG(32760)
+	require.NoError(t, err)
+	assert.Equal(t, "WGS 84 / UTM zone 60S", crs.Name)
+	assert.True(t, crs.Projected())
+
+	// the British National Grid, Ordnance Survey example on OSGB36
+	bng, err := CRSFromEPSG(27700)
+	require.NoError(t, err)
+	osgb36, err := CRSFromEPSG(4277)
+	require.NoError(t, err)
+	c, err := NewGridCoordinate(651409.903, 313177.270, 0, bng).Transform(osgb36)
+	require.NoError(t, err)
+	assert.InDelta(t, 52+39.0/60+27.2531/3600, float64(c.LatLon.Latitude), 1e-8)
+	assert.InDelta(t, 1+43.0/60+4.5177/3600, float64(c.LatLon.Longitude), 1e-8)
+
+	// WGS84 to the British National Grid goes via OSGB36
+	wgs, err := c.Transform(WGS84CRS)
+	require.NoError(t, err)
+	grid, err := wgs.Transform(bng)
+	require.NoError(t, err)
+	assert.InDelta(t, 651409.903, grid.X, 0.01)
+	assert.InDelta(t, 313177.270, grid.Y, 0.01)
+
+	// UTM zone 60S, Wellington, mirrors zone 60N
+	utm, err := LookupCRS("EPSG:32760")
+	require.NoError(t, err)
+	grid, err = NewCoordinate(NewLatLon(-41.29, 174.78), 0, WGS84CRS).Transform(utm)
+	require.NoError(t, err)
+	assert.InDelta(t, 314109.24, grid.X, 0.01)
+	utmNorth, err := UTMCRS(60, true)
+	require.NoError(t, err)
+	mirror, err := NewCoordinate(NewLatLon(41.29, 174.78), 0, WGS84CRS).Transform(utmNorth)
+	require.NoError(t, err)
+	assert.InDelta(t, grid.X, mirror.X, 1e-6)
+	assert.InDelta(t, 10000000-grid.Y, mirror.Y, 1e-6)
+
+	// registered CRSs, with a made up code
+	tm := NewTransverseMercator(wgs84, 0, 173, 0.9996, 1600000, 10000000)
+	testCRS := ProjectedCRS("WGS 84 / Test Transverse Mercator", 999001, wgs84Datum, tm.Project, tm.Inverse)
+	RegisterCRS(testCRS)
+	t.Cleanup(func() {
+		crsMutex.Lock()
+		defer crsMutex.Unlock()
+		delete(registeredCRSs, testCRS.EPSG)
+	})
+	crs, err = LookupCRS("EPSG:999001")
+	require.NoError(t, err)
+	assert.Equal(t, testCRS.Name, crs.Name)
+	assert.PanicsWithError(t, `invalid argument: CRS "World Robinson" without EPSG code`,
+		func() { RegisterCRS(RobinsonCRS) })
+}
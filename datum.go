@@ -0,0 +1,177 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Ellipsoids of the datums, from https://github.com/chrisveness/geodesy latlon-ellipsoidal-datum.js
+var (
+	airy1830      = Ellipsoid{a: 6377563.396, b: 6356256.909, f: 1 / 299.3249646}
+	airyModified  = Ellipsoid{a: 6377340.189, b: 6356034.448, f: 1 / 299.3249646}
+	bessel1841    = Ellipsoid{a: 6377397.155, b: 6356078.962818, f: 1 / 299.1528128}
+	clarke1866    = Ellipsoid{a: 6378206.4, b: 6356583.8, f: 1 / 294.978698214}
+	clarke1880IGN = Ellipsoid{a: 6378249.2, b: 6356515.0, f: 1 / 293.466021294}
+	grs80         = Ellipsoid{a: 6378137, b: 6356752.314140, f: 1 / 298.257222101}
+	intl1924      = Ellipsoid{a: 6378388, b: 6356911.946, f: 1 / 297}
+	wgs72         = Ellipsoid{a: 6378135, b: 6356750.52, f: 1 / 298.26}
+)
+
+// Datum is a geodetic datum: the ellipsoid that coordinates are given on, and the Helmert transformation from WGS84
+// to the datum.
+type Datum struct {
+	// Name is the usual name of the datum, e.g. "OSGB36"
+	Name string
+	// EPSG is the EPSG code of the geographic coordinate reference system using the datum, e.g. 4277
+	EPSG      int
+	ellipsoid Ellipsoid
+	// tx, ty, tz (metres), s (ppm), rx, ry, rz (arcseconds)
+	transform [7]float64
+}
+
+// datums are the supported datums, from https://github.com/chrisveness/geodesy latlon-ellipsoidal-datum.js
+var datums = []Datum{
+	{Name: "ED50", EPSG: 4230, ellipsoid: intl1924, transform: [7]float64{89.5, 93.8, 123.1, -1.2, 0.0, 0.0, 0.156}},
+	{Name: "ETRS89", EPSG: 4258, ellipsoid: grs80},
+	{Name: "Irl1975", EPSG: 4300, ellipsoid: airyModified, transform: [7]float64{-482.530, 130.596, -564.557, -8.150, 1.042, 0.214, 0.631}},
+	{Name: "NAD27", EPSG: 4267, ellipsoid: clarke1866, transform: [7]float64{8, -160, -176, 0, 0, 0, 0}},
+	{Name: "NAD83", EPSG: 4269, ellipsoid: grs80, transform: [7]float64{0.9956, -1.9103, -0.5215, -0.00062, 0.025915, 0.009426, 0.011599}},
+	{Name: "NTF", EPSG: 4275, ellipsoid: clarke1880IGN, transform: [7]float64{168, 60, -320, 0, 0, 0, 0}},
+	osgb36Datum,
+	{Name: "Potsdam", EPSG: 4314, ellipsoid: bessel1841, transform: [7]float64{-582, -105, -414, -8.3, 1.04, 0.35, -3.08}},
+	{Name: "TokyoJapan", EPSG: 4301, ellipsoid: bessel1841, transform: [7]float64{148, -507, -685, 0, 0, 0, 0}},
+	{Name: "WGS72", EPSG: 4322, ellipsoid: wgs72, transform: [7]float64{0, 0, -4.5, -0.22, 0, 0, 0.554}},
+	wgs84Datum,
+}
+
+// The datums of the predefined CRSs. WGS84 is the datum of LatLon and of the models.
+var (
+	osgb36Datum = Datum{Name: "OSGB36", EPSG: 4277, ellipsoid: airy1830, transform: [7]float64{-446.448, 125.157, -542.060, 20.4894, -0.1502, -0.2470, -0.8421}}
+	wgs84Datum  = Datum{Name: "WGS84", EPSG: 4326, ellipsoid: wgs84}
+)
+
+// LookupDatum returns the datum with the given name (case insensitive, e.g. "OSGB36") or EPSG code
+// (e.g. "EPSG:4277"), and false if the datum isn't supported.
+//
+// The supported datums are ED50, ETRS89, Irl1975, NAD27, NAD83, NTF, OSGB36, Potsdam, TokyoJapan, WGS72 and WGS84.
+func LookupDatum(name string) (Datum, bool) {
+	name = strings.TrimSpace(name)
+
+	if len(name) > 5 && strings.EqualFold(name[:5], "EPSG:") {
+		code, err := strconv.Atoi(strings.TrimSpace(name[5:]))
+		if err != nil {
+			return Datum{}, false
+		}
+
+		for _, d := range datums {
+			if d.EPSG == code {
+				return d, true
+			}
+		}
+
+		return Datum{}, false
+	}
+
+	for _, d := range datums {
+		if strings.EqualFold(d.Name, name) {
+			return d, true
+		}
+	}
+
+	return Datum{}, false
+}
+
+// Ellipsoid returns the ellipsoid of the datum.
+func (d Datum) Ellipsoid() Ellipsoid {
+	return d.ellipsoid
+}
+
+// ToWGS84 converts the point, with coordinates and height given on this datum, to WGS84, using the Helmert
+// transformation of the datum. The accuracy is typically a few metres.
+//
+// Example:
+// osgb36, _ := geod.LookupDatum("OSGB36")
+// p := osgb36.ToWGS84(geod.NewLatLonEllipsodial(51.47737, 0.00014, 0)) // 51.4779°N, 000.0015°W
+func (d Datum) ToWGS84(ll LatLonEllipsoidal) LatLonEllipsoidal {
+	ll.ellipsoid = d.ellipsoid
+	if d.transform == [7]float64{} {
+		ll.ellipsoid = wgs84
+
+		return ll
+	}
+
+	// the transformation from the datum to WGS84 is the inverse of the transformation from WGS84
+	var inverse [7]float64
+	for i, p := range d.transform {
+		inverse[i] = -p
+	}
+
+	return applyHelmert(ll.Cartesian(), inverse).LatLonEllipsoidal(wgs84)
+}
+
+// FromWGS84 converts the point, with coordinates and height given on WGS84, to this datum.
+func (d Datum) FromWGS84(ll LatLonEllipsoidal) LatLonEllipsoidal {
+	ll.ellipsoid = wgs84
+	if d.transform == [7]float64{} {
+		ll.ellipsoid = d.ellipsoid
+
+		return ll
+	}
+
+	return applyHelmert(ll.Cartesian(), d.transform).LatLonEllipsoidal(d.ellipsoid)
+}
+
+// applyHelmert applies the 7-parameter Helmert transformation `t` to the point
+func applyHelmert(c Cartesian, t [7]float64) Cartesian {
+	s := t[3]/1e6 + 1                    // scale: normalise parts-per-million to (s+1)
+	rx := Degrees(t[4] / 3600).Radians() // x-rotation: normalise arcseconds to radians
+	ry := Degrees(t[5] / 3600).Radians() // y-rotation: normalise arcseconds to radians
+	rz := Degrees(t[6] / 3600).Radians() // z-rotation: normalise arcseconds to radians
+	x1, y1, z1 := c.X, c.Y, c.Z
+
+	return Cartesian{
+		X: t[0] + x1*s - y1*rz + z1*ry,
+		Y: t[1] + x1*rz + y1*s - z1*rx,
+		Z: t[2] - x1*ry + y1*rx + z1*s,
+	}
+}
+
+// splitDatumPrefix splits a datum prefix, such as "OSGB36:" or "EPSG:4277", from the start of the string. Returns
+// the prefix and the rest of the string, or an empty prefix if there is none. A prefix starts with a letter, so
+// DMS values separated by colons aren't mistaken for one.
+func splitDatumPrefix(s string) (string, string) {
+	trimmed := strings.TrimLeftFunc(s, func(r rune) bool { return r == ' ' || r == '\t' })
+	if trimmed == "" || !(trimmed[0] >= 'a' && trimmed[0] <= 'z' || trimmed[0] >= 'A' && trimmed[0] <= 'Z') {
+		return "", s
+	}
+
+	end := strings.IndexAny(trimmed, ": \t")
+	if end < 0 {
+		return "", s
+	}
+
+	if strings.EqualFold(trimmed[:end], "EPSG") && trimmed[end] == ':' {
+		// "EPSG:nnnn", optionally followed by a colon
+		digits := end + 1
+		for digits < len(trimmed) && trimmed[digits] >= '0' && trimmed[digits] <= '9' {
+			digits++
+		}
+		end = digits
+	} else if trimmed[end] != ':' {
+		return "", s
+	}
+
+	prefixEnd := len(s) - len(trimmed) + end
+	rest := s[prefixEnd:]
+	if strings.HasPrefix(rest, ":") {
+		rest = rest[1:]
+	}
+
+	return strings.TrimSpace(s[:prefixEnd]), rest
+}
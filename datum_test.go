@@ -0,0 +1,73 @@
+package geod
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLookupDatum(t *testing.T) {
+	d, ok := LookupDatum("osgb36")
+	require.True(t, ok)
+	assert.Equal(t, "OSGB36", d.Name)
+	assert.Equal(t, airy1830, d.Ellipsoid())
+
+	d, ok = LookupDatum("EPSG:4277")
+	require.True(t, ok)
+	assert.Equal(t, "OSGB36", d.Name)
+
+	_, ok = LookupDatum("EPSG:9999")
+	assert.False(t, ok)
+	_, ok = LookupDatum("Mars2000")
+	assert.False(t, ok)
+}
+
+func TestDatumConversion(t *testing.T) {
+	osgb36, _ := LookupDatum("OSGB36")
+
+	// Greenwich, from https://github.com/chrisveness/geodesy tests
+	greenwich := NewLatLonEllipsodial(51.47788, -0.00147, 0)
+	p := osgb36.FromWGS84(greenwich)
+	assert.Equal(t, "51.4774°N, 000.0001°E", FormatLatLon(p.LatLon, FormatDeg, 4))
+
+	back := osgb36.ToWGS84(p)
+	assert.InDelta(t, 51.47788, float64(back.Latitude), 1e-7)
+	assert.InDelta(t, -0.00147, float64(back.Longitude), 1e-7)
+	assert.InDelta(t, 0, back.Height, 0.05)
+	assert.Equal(t, WGS84(), back.ellipsoid)
+
+	wgs84Datum, _ := LookupDatum("WGS84")
+	assert.Equal(t, greenwich, wgs84Datum.ToWGS84(greenwich))
+}
+
+func TestParseLatLonEllipsoidalDatum(t *testing.T) {
+	osgb36, _ := LookupDatum("OSGB36")
+	p := osgb36.FromWGS84(NewLatLonEllipsodial(51.47788, -0.00147, 0))
+
+	for _, args := range [][]interface{}{
+		{"OSGB36: " + FormatLatLon(p.LatLon, FormatDegMinSec, 4)},
+		{"EPSG:4277 " + FormatLatLonDecimal(p.LatLon, 8)},
+		{"osgb36:" + FormatLatLonDecimal(p.LatLon, 8), 0.0},
+	} {
+		ll, err := ParseLatLonEllipsoidal(args...)
+		require.NoError(t, err, "%v", args)
+		assert.InDelta(t, 51.47788, float64(ll.Latitude), 1e-6, "%v", args)
+		assert.InDelta(t, -0.00147, float64(ll.Longitude), 1e-6, "%v", args)
+	}
+
+	ll, err := ParseLatLonEllipsoidal("51.5, 0.1")
+	require.NoError(t, err)
+	assert.Equal(t, Degrees(51.5), ll.Latitude)
+
+	_, err = ParseLatLonEllipsoidal("Mars2000: 51.5, 0.1")
+	assert.ErrorIs(t, err, ErrUnknownDatum)
+
+	_, err = ParseLatLonEllipsoidal("OSGB36: 51.5, 0.1x")
+	assert.ErrorIs(t, err, ErrInvalidLongitude)
+	var pe *ParseError
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, "OSGB36: 51.5, 0.1x", pe.Input)
+	assert.Equal(t, 13, pe.Pos)
+}
@@ -0,0 +1,96 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"sync"
+
+	"github.com/starboard-nz/units"
+)
+
+var (
+	defaultModelMutex sync.RWMutex
+	defaultModel      EarthModel = SphericalModel
+	defaultModelArgs  []interface{}
+)
+
+// SetDefaultModel can be used to [globally] change the model, and its arguments, used by the convenience methods of
+// LatLon (DistanceTo, BearingTo and DestinationAt) when they are called without a model. Default is SphericalModel.
+// Panics if the arguments are invalid for the model, see CheckModel. It is safe to call concurrently with the
+// convenience methods, and the arguments are copied.
+//
+// Example:
+// geod.SetDefaultModel(geod.VincentyModel, geod.HighPrecision)
+func SetDefaultModel(model EarthModel, modelArgs ...interface{}) {
+	if err := CheckModel(model, modelArgs...); err != nil {
+		panic(err)
+	}
+
+	defaultModelMutex.Lock()
+	defer defaultModelMutex.Unlock()
+
+	defaultModel = model
+	defaultModelArgs = append([]interface{}(nil), modelArgs...)
+}
+
+// DefaultModel returns the model, and a copy of its arguments, set with SetDefaultModel.
+func DefaultModel() (EarthModel, []interface{}) {
+	defaultModelMutex.RLock()
+	defer defaultModelMutex.RUnlock()
+
+	return defaultModel, append([]interface{}(nil), defaultModelArgs...)
+}
+
+// resolveModel returns the optional `model` of `method`, or the default model and its arguments, which must not be
+// modified
+func resolveModel(method string, model []EarthModel) (EarthModel, []interface{}) {
+	switch len(model) {
+	case 0:
+		defaultModelMutex.RLock()
+		defer defaultModelMutex.RUnlock()
+
+		return defaultModel, defaultModelArgs
+	case 1:
+		return model[0], nil
+	default:
+		panic(modelArgsError(method, "at most one model expected"))
+	}
+}
+
+// modelFor returns the Model for `ll` using the optional `model`, or the default model
+func (ll LatLon) modelFor(method string, model []EarthModel) Model {
+	m, modelArgs := resolveModel("LatLon."+method, model)
+
+	return m(ll, modelArgs...)
+}
+
+// DistanceTo returns the distance to `other`, using `model` if given, otherwise the default model (see
+// SetDefaultModel). This is a shortcut for one-off calculations, see Distance for using model arguments.
+//
+// Example:
+// d := geod.NewLatLon(52.205, 0.119).DistanceTo(geod.NewLatLon(48.857, 2.351))     // 404.3km
+func (ll LatLon) DistanceTo(other LatLon, model ...EarthModel) units.Distance {
+	return ll.modelFor("DistanceTo", model).DistanceTo(other)
+}
+
+// BearingTo returns the initial bearing to `other`, in Degrees from North, using `model` if given, otherwise the
+// default model (see SetDefaultModel). Returns NaN if the points are the same.
+//
+// Example:
+// b := geod.NewLatLon(52.205, 0.119).BearingTo(geod.NewLatLon(48.857, 2.351))       // 156.2°
+func (ll LatLon) BearingTo(other LatLon, model ...EarthModel) Degrees {
+	return ll.modelFor("BearingTo", model).InitialBearingTo(other)
+}
+
+// DestinationAt returns the point `distance` metres away on the initial `bearing`, using `model` if given, otherwise
+// the default model (see SetDefaultModel).
+//
+// Example:
+// p := geod.NewLatLon(51.47788, -0.00147).DestinationAt(7794, 300.7)                // 51.5136°N, 000.0983°W
+func (ll LatLon) DestinationAt(distance float64, bearing Degrees, model ...EarthModel) LatLon {
+	return ll.modelFor("DestinationAt", model).DestinationPoint(distance, bearing)
+}
@@ -0,0 +1,74 @@
+package geod
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDefaultModelMethods(t *testing.T) {
+	defer SetDefaultModel(SphericalModel)
+
+	p1 := NewLatLon(52.205, 0.119)
+	p2 := NewLatLon(48.857, 2.351)
+
+	assert.InDelta(t, 404279, float64(p1.DistanceTo(p2).Metre()), 1)
+	assert.InDelta(t, 156.2, float64(p1.BearingTo(p2)), 0.05)
+	assert.Equal(t, "51.5136°N, 000.0983°W", FormatLatLon(NewLatLon(51.47788, -0.00147).DestinationAt(7794, 300.7), FormatDeg, 4))
+	assert.Equal(t, 0.0, float64(p1.DistanceTo(p1, VincentyModel).Metre()))
+
+	assert.Equal(t, Distance(p1, p2, RhumbModel), p1.DistanceTo(p2, RhumbModel))
+	assert.Equal(t, InitialBearing(p1, p2, VincentyModel), p1.BearingTo(p2, VincentyModel))
+
+	SetDefaultModel(VincentyModel, HighPrecision)
+	model, args := DefaultModel()
+	assert.NotNil(t, model)
+	assert.Equal(t, []interface{}{HighPrecision}, args)
+	assert.Equal(t, Distance(p1, p2, VincentyModel, HighPrecision), p1.DistanceTo(p2))
+	assert.Equal(t, DestinationPoint(p1, 1000, 45, VincentyModel, HighPrecision), p1.DestinationAt(1000, 45))
+
+	assert.Panics(t, func() { SetDefaultModel(SphericalModel, HighPrecision) })
+	assert.Equal(t, Distance(p1, p2, VincentyModel, HighPrecision), p1.DistanceTo(p2), "default model unchanged")
+	assert.Panics(t, func() { p1.DistanceTo(p2, SphericalModel, RhumbModel) })
+}
+
+func TestSetDefaultModelCopiesArgs(t *testing.T) {
+	defer SetDefaultModel(SphericalModel)
+
+	args := []interface{}{HighPrecision}
+	SetDefaultModel(VincentyModel, args...)
+	args[0] = 1.0
+	_, defaultArgs := DefaultModel()
+	assert.Equal(t, []interface{}{HighPrecision}, defaultArgs)
+
+	defaultArgs[0] = 1.0
+	_, defaultArgs = DefaultModel()
+	assert.Equal(t, []interface{}{HighPrecision}, defaultArgs)
+}
+
+func TestSetDefaultModelConcurrent(t *testing.T) {
+	defer SetDefaultModel(SphericalModel)
+
+	p1 := NewLatLon(52.205, 0.119)
+	p2 := NewLatLon(48.857, 2.351)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				SetDefaultModel(VincentyModel, HighPrecision)
+				SetDefaultModel(SphericalModel)
+			}
+		}()
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				assert.InDelta(t, 404279, float64(p1.DistanceTo(p2).Metre()), 500)
+			}
+		}()
+	}
+	wg.Wait()
+}
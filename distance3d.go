@@ -0,0 +1,57 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+
+	"github.com/starboard-nz/units"
+)
+
+// Distance3D returns the straight line (chord) distance through space between `p1` and `p2`, taking their heights
+// into account, calculated from their ECEF cartesian coordinates. This is the line-of-sight range, for example from
+// a radar or AIS antenna to a target, ignoring refraction and whether the Earth blocks the line.
+//
+// Both points should use the same ellipsoid (see Datum to convert between them).
+//
+// Example:
+// antenna := geod.NewLatLonEllipsodial(-41.29, 174.78, 120)
+// target := geod.NewLatLonEllipsodial(-41.10, 174.95, 0)
+// r := geod.Distance3D(antenna, target)
+func Distance3D(p1, p2 LatLonEllipsoidal) units.Distance {
+	c1 := p1.Cartesian()
+	c2 := p2.Cartesian()
+
+	return units.Metre(Vector3D(c2).Minus(Vector3D(c1)).Length())
+}
+
+// SlantDistance returns the distance between `p1` and `p2` combining the distance along the surface, using the given
+// `model`, with the difference of their heights: √(surface² + Δh²). Unlike Distance3D, it follows the curvature of
+// the Earth, so it is more suitable for longer ranges where the path (for example of a low flying aircraft) follows
+// the surface. The two are close for short ranges near the surface.
+//
+// Arguments:
+//
+// p1, p2 - the points, with heights in metres
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// Example:
+// p1 := geod.NewLatLonEllipsodial(-41.29, 174.78, 3000)
+// p2 := geod.NewLatLonEllipsodial(-43.49, 172.53, 0)
+// d := geod.SlantDistance(p1, p2, geod.VincentyModel)
+func SlantDistance(p1, p2 LatLonEllipsoidal, model EarthModel, modelArgs ...interface{}) units.Distance {
+	surface := float64(Distance(p1.LatLon, p2.LatLon, model, modelArgs...).Metre())
+
+	return units.Metre(math.Hypot(surface, p2.Height-p1.Height))
+}
@@ -0,0 +1,37 @@
+package geod
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDistance3D(t *testing.T) {
+	p := NewLatLonEllipsodial(-41.29, 174.78, 0)
+	up := NewLatLonEllipsodial(-41.29, 174.78, 1000)
+	assert.InDelta(t, 1000, float64(Distance3D(p, up).Metre()), 1e-6)
+	assert.Equal(t, 0.0, float64(Distance3D(p, p).Metre()))
+
+	// short ranges are close to the surface distance
+	q := NewLatLonEllipsodial(-41.2, 174.8, 0)
+	surface := float64(Distance(p.LatLon, q.LatLon, VincentyModel).Metre())
+	assert.InDelta(t, surface, float64(Distance3D(p, q).Metre()), 0.01)
+
+	// the chord through the Earth is shorter than the surface distance
+	a := NewLatLonEllipsodial(0, 0, 0)
+	b := NewLatLonEllipsodial(0, 90, 0)
+	assert.InDelta(t, WGS84().a*math.Sqrt2, float64(Distance3D(a, b).Metre()), 1e-6)
+	assert.Less(t, float64(Distance3D(a, b).Metre()), float64(Distance(a.LatLon, b.LatLon, VincentyModel).Metre()))
+}
+
+func TestSlantDistance(t *testing.T) {
+	p := NewLatLonEllipsodial(-41.29, 174.78, 0)
+	up := NewLatLonEllipsodial(-41.29, 174.78, 1000)
+	assert.Equal(t, 1000.0, float64(SlantDistance(p, up, VincentyModel).Metre()))
+
+	q := NewLatLonEllipsodial(-43.49, 172.53, 3000)
+	surface := float64(Distance(p.LatLon, q.LatLon, SphericalModel).Metre())
+	assert.InDelta(t, math.Hypot(surface, 3000), float64(SlantDistance(p, q, SphericalModel).Metre()), 1e-6)
+	assert.InDelta(t, float64(SlantDistance(p, q, SphericalModel).Metre()), float64(SlantDistance(q, p, SphericalModel).Metre()), 1e-6)
+}
@@ -14,6 +14,8 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+
+	"github.com/starboard-nz/units"
 )
 
 // FormatDeg, FormatDegMin and FormatDegMinSec are constants the control how FormatDMS should format the degree value.
@@ -27,10 +29,57 @@ const dmsSeparator = 0x202f // U+202F = 'narrow no-break space'
 var dmsRE *regexp.Regexp = regexp.MustCompile(
 	`^-?(?:([0-9.,]+)(?:[°º]|\s|[nwseNWSE]?$))?\s*(?:([0-9.,]+)(?:[′’']|\s|[nwseNWSE]?$))?\s*(?:([0-9.,]+)[″”"]?)?\s*[nwseNWSE]?$`)
 
+// FormatOption is an option for FormatDMS and FormatLatLon.
+type FormatOption func(*formatConfig)
+
+type formatConfig struct {
+	decimalComma bool
+	symbols      [3]string
+}
+
+// WithDecimalComma formats decimals with a comma instead of a point, as in many European locales, for example
+// "51,4779°". FormatLatLon separates the latitude and longitude with a semicolon instead of a comma, which ParseLatLon
+// accepts.
+func WithDecimalComma() FormatOption {
+	return func(c *formatConfig) {
+		c.decimalComma = true
+	}
+}
+
+// WithSymbols replaces the degree, prime and double-prime symbols (°′″) added after the degrees, minutes and seconds,
+// for example WithSymbols("d", "m", "s") for "051d28m40s".
+func WithSymbols(deg, min, sec string) FormatOption {
+	return func(c *formatConfig) {
+		c.symbols = [3]string{deg, min, sec}
+	}
+}
+
+// newFormatConfig returns the default config, with the options applied
+func newFormatConfig(opts []FormatOption) formatConfig {
+	config := formatConfig{symbols: [3]string{"°", "′", "″"}}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return config
+}
+
+// formatNumber formats the number with `dp` decimal places, using a decimal comma if configured
+func (c formatConfig) formatNumber(v float64, dp int) string {
+	s := strconv.FormatFloat(v, 'f', dp, 64)
+	if c.decimalComma {
+		s = strings.Replace(s, ".", ",", 1)
+	}
+
+	return s
+}
+
 // ParseDMS parses a string representing Degrees-Minutes-Seconds into decimal degrees
 // This is very flexible on formats, allowing signed decimal degrees, or deg-min-sec optionally
 // suffixed by compass direction (NSEW); a variety of separators are accepted. Examples -3.62,
-// '3 37 12W', '3°37′12″W'.
+// '3 37 12W', '3°37′12″W'. Decimal commas are accepted as well as decimal points, for example '3,62' or
+// '3°37,2′W'.
+// Errors are returned as *ParseError.
 // Example:
 // lat := geod.ParseDMS("51° 28′ 40.37″ N")
 // lon := geod.ParseDMS("000° 00′ 05.29″ W")
@@ -40,7 +89,7 @@ func ParseDMS(dms string) (Degrees, error) {
 	var nanDegrees = Degrees(math.NaN())
 
 	if dms == "" {
-		return 0.0, fmt.Errorf("ParseDMS: Empty string")
+		return 0.0, parseError(ErrEmptyInput, dms, "")
 	}
 
 	// check for signed decimal degrees without NSEW, if so return it directly
@@ -48,36 +97,62 @@ func ParseDMS(dms string) (Degrees, error) {
 		return Degrees(fl), nil
 	}
 
+	input := dms
 	dms = strings.TrimSpace(dms)
+	if dms == "" {
+		return nanDegrees, parseError(ErrEmptyInput, input, "")
+	}
+
 	// strip off any sign or compass dir'n & split out separate d/m/s
 
-	dmsParts := dmsRE.FindStringSubmatch(dms)
+	dmsParts := dmsRE.FindStringSubmatchIndex(dms)
 	if len(dmsParts) == 0 {
-		return nanDegrees, fmt.Errorf("Failed to parse DMS string %q", dms)
+		return nanDegrees, parseError(ErrInvalidDMS, input, "")
 	}
 
-	var deg float64
-	if dmsParts[1] != "" {
-		deg, err = strconv.ParseFloat(dmsParts[1], 64)
-		if err != nil {
-			return nanDegrees, fmt.Errorf("Failed to parse degrees (%v) in DMS string %q", dmsParts[1], dms)
+	// offset of the trimmed string in the input
+	offset := strings.Index(input, dms)
+
+	// parsePart parses the n-th group of the regular expression
+	parsePart := func(n int, name string) (float64, error) {
+		start, end := dmsParts[2*n], dmsParts[2*n+1]
+		if start < 0 {
+			return 0, nil
 		}
-	}
 
-	var min float64
-	if dmsParts[2] != "" {
-		min, err = strconv.ParseFloat(dmsParts[2], 64)
-		if err != nil {
-			return nanDegrees, fmt.Errorf("Failed to parse minutes (%v) in DMS string %q", dmsParts[2], dms)
+		part := dms[start:end]
+		if !strings.Contains(part, ".") {
+			// decimal comma
+			part = strings.Replace(part, ",", ".", 1)
 		}
-	}
 
-	var sec float64
-	if dmsParts[3] != "" {
-		sec, err = strconv.ParseFloat(dmsParts[3], 64)
+		v, err := strconv.ParseFloat(part, 64)
 		if err != nil {
-			return nanDegrees, fmt.Errorf("Failed to parse seconds (%v) in DMS string %q", dmsParts[3], dms)
+			return 0, &ParseError{
+				Err:    ErrInvalidDMS,
+				Input:  input,
+				Token:  -1,
+				Pos:    offset + start,
+				Detail: fmt.Sprintf("failed to parse %s (%v)", name, dms[start:end]),
+			}
 		}
+
+		return v, nil
+	}
+
+	deg, err := parsePart(1, "degrees")
+	if err != nil {
+		return nanDegrees, err
+	}
+
+	min, err := parsePart(2, "minutes")
+	if err != nil {
+		return nanDegrees, err
+	}
+
+	sec, err := parsePart(3, "seconds")
+	if err != nil {
+		return nanDegrees, err
 	}
 
 	// and convert to decimal degrees...
@@ -99,7 +174,10 @@ func ParseDMS(dms string) (Degrees, error) {
 // `deg` - degrees to be formatted as specified.
 // `format` - one of FormatDeg, FormatDegMin or FormatDegMinSec (degrees, degrees+minutes, degrees+minutes+seconds)
 // `dp` - number of decimal places to use - use -1 for defaults: 4 for d, 2 for dm, 0 for dms.
-func FormatDMS(deg Degrees, format, dp int) string {
+// `opts` - options for locales, see WithDecimalComma and WithSymbols.
+func FormatDMS(deg Degrees, format, dp int, opts ...FormatOption) string {
+	config := newFormatConfig(opts)
+
 	degf := float64(deg)
 	if math.IsNaN(degf) || math.IsInf(degf, 0) {
 		// give up here if we can't make a number from degf
@@ -142,11 +220,13 @@ func FormatDMS(deg Degrees, format, dp int) string {
 		if m < 10 {
 			mpad = 1
 		}
-		dms = fmt.Sprintf("%s%s°%s%s′",
+		dms = fmt.Sprintf("%s%s%s%s%s%s",
 			"00"[0:dpad], // left-pad with leading zeros
-			strconv.FormatFloat(d, 'f', 0, 64),
-			"0"[0:mpad],                         // left-pad with leading zeros (note may include decimals)
-			strconv.FormatFloat(m, 'f', dp, 64)) // round/right-pad minutes
+			config.formatNumber(d, 0),
+			config.symbols[0],
+			"0"[0:mpad],                // left-pad with leading zeros (note may include decimals)
+			config.formatNumber(m, dp), // round/right-pad minutes
+			config.symbols[1])
 	case FormatDegMinSec:
 		d := math.Floor(degf)                                                      // get component deg
 		m := math.Mod(math.Floor(degf*3600/60), 60.0)                              // get component min
@@ -173,13 +253,16 @@ func FormatDMS(deg Degrees, format, dp int) string {
 		if s < 10 {
 			spad = 1
 		}
-		dms = fmt.Sprintf("%s%s°%s%s′%s%s″",
+		dms = fmt.Sprintf("%s%s%s%s%s%s%s%s%s",
 			"00"[0:dpad], // left-pad with leading zeros
-			strconv.FormatFloat(d, 'f', 0, 64),
+			config.formatNumber(d, 0),
+			config.symbols[0],
 			"0"[0:mpad], // left-pad with leading zeros
-			strconv.FormatFloat(m, 'f', 0, 64),
-			"0"[0:spad],                         // left-pad with leading zeros (note may include decimals)
-			strconv.FormatFloat(s, 'f', dp, 64)) // round/right-pad minutes
+			config.formatNumber(m, 0),
+			config.symbols[1],
+			"0"[0:spad],                // left-pad with leading zeros (note may include decimals)
+			config.formatNumber(s, dp), // round/right-pad minutes
+			config.symbols[2])
 	default: // FormatDeg falls under this as well
 		dpad := 0
 		if degf < 10 {
@@ -187,14 +270,96 @@ func FormatDMS(deg Degrees, format, dp int) string {
 		} else if degf < 100 {
 			dpad = 1
 		}
-		dms = fmt.Sprintf("%s%s°",
-			"00"[0:dpad],                           // left-pad with leading zeros (note may include decimals)
-			strconv.FormatFloat(degf, 'f', dp, 64)) // round/right-pad degrees
+		dms = fmt.Sprintf("%s%s%s",
+			"00"[0:dpad],                  // left-pad with leading zeros (note may include decimals)
+			config.formatNumber(degf, dp), // round/right-pad degrees
+			config.symbols[0])
 	}
 
 	return dms
 }
 
+// FormatLatLon formats the point as a latitude/longitude pair in deg/min/sec format with compass directions, for
+// example "50°21′59″N, 004°08′02″W". Latitude degrees are zero-padded to 2 digits, longitude degrees to 3.
+//
+// Arguments:
+//
+// `ll` - the point to be formatted.
+// `format` - one of FormatDeg, FormatDegMin or FormatDegMinSec (degrees, degrees+minutes, degrees+minutes+seconds)
+// `dp` - number of decimal places to use - use -1 for defaults: 4 for d, 2 for dm, 0 for dms.
+// `opts` - options for locales, see WithDecimalComma and WithSymbols.
+//
+// Returns an empty string for invalid points.
+func FormatLatLon(ll LatLon, format, dp int, opts ...FormatOption) string {
+	if !ll.Valid() {
+		return ""
+	}
+
+	lat := Wrap90(ll.Latitude)
+	lon := Wrap180(ll.Longitude)
+
+	latDMS := FormatDMS(lat, format, dp, opts...)
+	lonDMS := FormatDMS(lon, format, dp, opts...)
+	if latDMS == "" || lonDMS == "" {
+		return ""
+	}
+
+	ns := "N"
+	if lat < 0 {
+		ns = "S"
+	}
+
+	ew := "E"
+	if lon < 0 {
+		ew = "W"
+	}
+
+	separator := ", "
+	if newFormatConfig(opts).decimalComma {
+		separator = "; "
+	}
+
+	// latitude degrees never need 3 digits
+	return latDMS[1:] + ns + separator + lonDMS + ew
+}
+
+// FormatLatLonDecimal formats the point as a signed decimal latitude/longitude pair, for example "50.3664, -4.1339".
+//
+// Arguments:
+//
+// `ll` - the point to be formatted.
+// `dp` - number of decimal places to use - use -1 for the default of 4.
+//
+// Returns an empty string for invalid points.
+func FormatLatLonDecimal(ll LatLon, dp int) string {
+	if !ll.Valid() {
+		return ""
+	}
+
+	if dp == -1 {
+		dp = 4
+	}
+
+	return strconv.FormatFloat(float64(Wrap90(ll.Latitude)), 'f', dp, 64) + ", " +
+		strconv.FormatFloat(float64(Wrap180(ll.Longitude)), 'f', dp, 64)
+}
+
+// FormatLatLonWithin formats the point as a signed decimal latitude/longitude pair using the shortest decimal
+// representation that stays within `tolerance` of the original position, for example "50.3664, -4.1339" for
+// tolerance of 10m. Trailing zeros are omitted.
+//
+// Returns an empty string for invalid points.
+func FormatLatLonWithin(ll LatLon, tolerance units.Distance) string {
+	if !ll.Valid() {
+		return ""
+	}
+
+	ll = LatLon{Latitude: Wrap90(ll.Latitude), Longitude: Wrap180(ll.Longitude)}.RoundWithin(tolerance)
+
+	return strconv.FormatFloat(float64(ll.Latitude), 'f', -1, 64) + ", " +
+		strconv.FormatFloat(float64(ll.Longitude), 'f', -1, 64)
+}
+
 // Wrap360 contrains `degrees` to range 0..360 (e.g. for bearings); -1 --> 359, 361 --> 1.
 func Wrap360(degrees Degrees) Degrees {
 	if 0.0 <= float64(degrees) && float64(degrees) < 360.0 {
@@ -7,8 +7,13 @@ package geod
  */
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestParseDMS(t *testing.T) {
@@ -166,6 +171,52 @@ func TestParseDMS(t *testing.T) {
 	}
 }
 
+func TestParseErrors(t *testing.T) {
+	_, err := ParseDMS("")
+	assert.ErrorIs(t, err, ErrEmptyInput)
+
+	_, err = ParseDMS("xxx")
+	assert.ErrorIs(t, err, ErrInvalidDMS)
+
+	_, err = ParseLatLon()
+	assert.ErrorIs(t, err, ErrEmptyInput)
+
+	_, err = ParseLatLon("51.5, 0.1, 3")
+	assert.ErrorIs(t, err, ErrTooManyTokens)
+	var pe *ParseError
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, 2, pe.Token)
+	assert.Equal(t, 10, pe.Pos)
+
+	_, err = ParseLatLon("51.5")
+	assert.ErrorIs(t, err, ErrInvalidLongitude)
+
+	_, err = ParseLatLon("51°28′40″N, 000°0x′05″W")
+	assert.ErrorIs(t, err, ErrInvalidLongitude)
+	require.True(t, errors.As(err, &pe))
+	assert.Equal(t, 1, pe.Token)
+	assert.Equal(t, 16, pe.Pos) // byte offset
+
+	_, err = ParseLatLon("xxx", 1.2)
+	assert.ErrorIs(t, err, ErrInvalidLatitude)
+
+	_, err = ParseLatLon(1.2, math.NaN())
+	assert.ErrorIs(t, err, ErrInvalidLongitude)
+
+	_, err = ParseLatLon(true)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+
+	_, err = ParseLatLonEllipsoidal("51.5, 0.1, x")
+	assert.ErrorIs(t, err, ErrInvalidHeight)
+
+	p, err := ParseLatLonEllipsoidal("51.5, 0.1, 17")
+	assert.NoError(t, err)
+	assert.Equal(t, 17.0, p.Height)
+
+	_, err = ParseLatLonSpherical("51.5", "0.1")
+	assert.NoError(t, err)
+}
+
 func TestToDMS(t *testing.T) {
 	s := FormatDMS(0, FormatDeg, -1)
 	if s != "000.0000°" {
@@ -240,6 +291,18 @@ func TestToDMS(t *testing.T) {
 	}
 }
 
+func TestFormatLatLon(t *testing.T) {
+	ll := NewLatLon(50.3664, -4.1339)
+	assert.Equal(t, "50°21′59″N, 004°08′02″W", FormatLatLon(ll, FormatDegMinSec, -1))
+	assert.Equal(t, "50°21.98′N, 004°08.03′W", FormatLatLon(ll, FormatDegMin, -1))
+	assert.Equal(t, "50.3664°N, 004.1339°W", FormatLatLon(ll, FormatDeg, -1))
+	assert.Equal(t, "05.1000°S, 179.9000°E", FormatLatLon(NewLatLon(-5.1, 179.9), FormatDeg, -1))
+	assert.Equal(t, "", FormatLatLon(NewLatLon(math.NaN(), 0), FormatDeg, -1))
+
+	assert.Equal(t, "50.3664, -4.1339", FormatLatLonDecimal(ll, -1))
+	assert.Equal(t, "50.37, -4.13", FormatLatLonDecimal(ll, 2))
+}
+
 func TestWrap360(t *testing.T) {
 	testValues := map[float64]float64{
 		-450: 270,
@@ -337,3 +400,52 @@ func TestWrap90(t *testing.T) {
 		}
 	}
 }
+
+func TestFormatLocale(t *testing.T) {
+	ll := NewLatLon(50.3664, -4.1339)
+	assert.Equal(t, "50,3664°N; 004,1339°W", FormatLatLon(ll, FormatDeg, -1, WithDecimalComma()))
+	assert.Equal(t, "50°21,98′N; 004°08,03′W", FormatLatLon(ll, FormatDegMin, -1, WithDecimalComma()))
+	assert.Equal(t, "50d21m59sN, 004d08m02sW", FormatLatLon(ll, FormatDegMinSec, -1, WithSymbols("d", "m", "s")))
+	assert.Equal(t, "009d09m09,00s", FormatDMS(9.1525, FormatDegMinSec, 2, WithSymbols("d", "m", "s"), WithDecimalComma()))
+	assert.Equal(t, "009,152500 deg", FormatDMS(9.1525, FormatDeg, 6, WithSymbols(" deg", "", ""), WithDecimalComma()))
+
+	// round trip
+	for _, format := range []int{FormatDeg, FormatDegMin, FormatDegMinSec} {
+		p, err := ParseLatLon(FormatLatLon(ll, format, 4, WithDecimalComma()))
+		assert.NoError(t, err)
+		assert.InDelta(t, 50.3664, float64(p.Latitude), 1e-6)
+		assert.InDelta(t, -4.1339, float64(p.Longitude), 1e-6)
+	}
+
+	_, err := ParseLatLon("50,3664°N; 004,1339°W; 0")
+	assert.ErrorIs(t, err, ErrTooManyTokens)
+}
+
+func TestParseDMSDecimalComma(t *testing.T) {
+	for input, expected := range map[string]Degrees{
+		"3,62":          3.62,
+		"-3,62":         -3.62,
+		"3°37,2′W":      -3.62,
+		"3 37 12,5":     3 + 37.0/60 + 12.5/3600,
+		"50,3664°N":     50.3664,
+		"004°08,03′W":   -(4 + 8.03/60),
+		"51°28′40,37″N": 51 + 28.0/60 + 40.37/3600,
+	} {
+		deg, err := ParseDMS(input)
+		assert.NoError(t, err, input)
+		assert.InDelta(t, float64(expected), float64(deg), 1e-12, input)
+	}
+
+	for _, input := range []string{"3,6,2", "3,6.2"} {
+		_, err := ParseDMS(input)
+		assert.ErrorIs(t, err, ErrInvalidDMS, input)
+	}
+
+	// round trip
+	ll := NewLatLon(50.3664, -4.1339)
+	for _, format := range []int{FormatDeg, FormatDegMin, FormatDegMinSec} {
+		deg, err := ParseDMS(FormatDMS(ll.Latitude, format, 4, WithDecimalComma()) + "N")
+		assert.NoError(t, err)
+		assert.InDelta(t, 50.3664, float64(deg), 1e-6)
+	}
+}
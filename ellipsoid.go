@@ -8,8 +8,12 @@ package geod
  * See LICENSE in the root directory of this source tree.
  */
 
+import (
+	"math"
+)
+
 // Ellipsoid parameters
-// The only ellipsoid defined is WGS84, for use in utm/mgrs, vincenty, nvector.
+// WGS84 is used in utm/mgrs, vincenty, nvector; the ellipsoids of other datums are available from Datum.Ellipsoid.
 type Ellipsoid struct {
 	a, b, f float64
 }
@@ -20,3 +24,125 @@ var wgs84 = Ellipsoid{a: 6378137, b: 6356752.314245, f: 1 / 298.257223563}
 func WGS84() Ellipsoid {
 	return wgs84
 }
+
+// SemiMajorAxis returns the equatorial radius a, in metres
+func (e Ellipsoid) SemiMajorAxis() float64 {
+	return e.a
+}
+
+// SemiMinorAxis returns the polar radius b, in metres
+func (e Ellipsoid) SemiMinorAxis() float64 {
+	return e.b
+}
+
+// Flattening returns the flattening f = (a−b)/a
+func (e Ellipsoid) Flattening() float64 {
+	return e.f
+}
+
+// EccentricitySq returns the first eccentricity squared e² = (a²−b²)/a², calculated as 2⋅f−f² (better conditioned)
+func (e Ellipsoid) EccentricitySq() float64 {
+	return e.f * (2 - e.f)
+}
+
+// SecondEccentricitySq returns the second eccentricity squared e′² = (a²−b²)/b² = e²/(1−e²)
+func (e Ellipsoid) SecondEccentricitySq() float64 {
+	e2 := e.EccentricitySq()
+
+	return e2 / (1 - e2)
+}
+
+// ThirdFlattening returns the third flattening n = (a−b)/(a+b) = f/(2−f), used in the series expansions of Krüger
+// (UTM) and Helmert (meridian arc)
+func (e Ellipsoid) ThirdFlattening() float64 {
+	return e.f / (2 - e.f)
+}
+
+// MeanRadius returns the arithmetic mean radius R₁ = (2a+b)/3, in metres
+func (e Ellipsoid) MeanRadius() float64 {
+	return (2*e.a + e.b) / 3
+}
+
+// AuthalicRadius returns the radius R₂ of the sphere with the same surface area as the ellipsoid, in metres
+func (e Ellipsoid) AuthalicRadius() float64 {
+	ecc := math.Sqrt(e.EccentricitySq())
+	if ecc == 0 {
+		return e.a
+	}
+
+	return math.Sqrt((e.a*e.a + e.b*e.b*math.Atanh(ecc)/ecc) / 2)
+}
+
+// RectifyingRadius returns the radius R₃ of the sphere with the same meridian length as the ellipsoid, in metres,
+// using Helmert's series in the third flattening
+func (e Ellipsoid) RectifyingRadius() float64 {
+	n := e.ThirdFlattening()
+	n2 := n * n
+
+	return e.a / (1 + n) * (1 + n2/4 + n2*n2/64)
+}
+
+// MeridianRadius returns the radius of curvature in the meridian (north-south) at `latitude`,
+// M = a⋅(1−e²)/(1−e²⋅sin²φ)^(3/2), in metres
+func (e Ellipsoid) MeridianRadius(latitude Degrees) float64 {
+	e2 := e.EccentricitySq()
+	sinφ := math.Sin(latitude.Radians())
+
+	return e.a * (1 - e2) / math.Pow(1-e2*sinφ*sinφ, 1.5)
+}
+
+// PrimeVerticalRadius returns the radius of curvature in the prime vertical (east-west) at `latitude`,
+// ν = a/√(1−e²⋅sin²φ), in metres
+func (e Ellipsoid) PrimeVerticalRadius(latitude Degrees) float64 {
+	e2 := e.EccentricitySq()
+	sinφ := math.Sin(latitude.Radians())
+
+	return e.a / math.Sqrt(1-e2*sinφ*sinφ)
+}
+
+// RectifyingLatitude returns the rectifying latitude μ of `latitude`, the latitude on the sphere of radius
+// RectifyingRadius at the same distance from the equator along the meridian, using Helmert's series in the third
+// flattening (accurate to better than 1mm on the Earth).
+func (e Ellipsoid) RectifyingLatitude(latitude Degrees) Degrees {
+	n := e.ThirdFlattening()
+	n2, n3, n4 := n*n, n*n*n, n*n*n*n
+	φ := latitude.Radians()
+
+	μ := φ +
+		(-3*n/2+9*n3/16)*math.Sin(2*φ) +
+		(15*n2/16-15*n4/32)*math.Sin(4*φ) +
+		(-35*n3/48)*math.Sin(6*φ) +
+		(315*n4/512)*math.Sin(8*φ)
+
+	return DegreesFromRadians(μ)
+}
+
+// LatitudeFromRectifying returns the latitude whose rectifying latitude is `μ`, the inverse of RectifyingLatitude.
+func (e Ellipsoid) LatitudeFromRectifying(μ Degrees) Degrees {
+	n := e.ThirdFlattening()
+	n2, n3, n4 := n*n, n*n*n, n*n*n*n
+	r := μ.Radians()
+
+	φ := r +
+		(3*n/2-27*n3/32)*math.Sin(2*r) +
+		(21*n2/16-55*n4/32)*math.Sin(4*r) +
+		(151*n3/96)*math.Sin(6*r) +
+		(1097*n4/512)*math.Sin(8*r)
+
+	return DegreesFromRadians(φ)
+}
+
+// MeridianArc returns the distance along the meridian from the equator to `latitude`, in metres, negative for
+// southern latitudes.
+//
+// Example:
+// m := geod.WGS84().MeridianArc(90) // 10001965.729m, a quarter of the meridian
+func (e Ellipsoid) MeridianArc(latitude Degrees) float64 {
+	return e.RectifyingRadius() * e.RectifyingLatitude(latitude).Radians()
+}
+
+// MeridianArcLatitude returns the latitude at `distance` metres along the meridian from the equator (negative for
+// southern latitudes), the inverse of MeridianArc.
+func (e Ellipsoid) MeridianArcLatitude(distance float64) Degrees {
+	return e.LatitudeFromRectifying(DegreesFromRadians(distance / e.RectifyingRadius()))
+}
@@ -0,0 +1,51 @@
+package geod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEllipsoidParameters(t *testing.T) {
+	e := WGS84()
+	assert.Equal(t, 6378137.0, e.SemiMajorAxis())
+	assert.Equal(t, 6356752.314245, e.SemiMinorAxis())
+	assert.Equal(t, 1/298.257223563, e.Flattening())
+
+	assert.InDelta(t, 0.00669437999014, e.EccentricitySq(), 1e-14)
+	assert.InDelta(t, 0.00673949674228, e.SecondEccentricitySq(), 1e-14)
+	assert.InDelta(t, 0.00167922038638, e.ThirdFlattening(), 1e-14)
+
+	assert.InDelta(t, 6371008.7714, e.MeanRadius(), 1e-4)
+	assert.InDelta(t, 6371007.1809, e.AuthalicRadius(), 1e-4)
+	assert.InDelta(t, 6367449.1458, e.RectifyingRadius(), 1e-4)
+
+	assert.InDelta(t, 6335439.327, e.MeridianRadius(0), 1e-3)
+	assert.InDelta(t, 6399593.626, e.MeridianRadius(90), 1e-3)
+	assert.InDelta(t, 6378137.0, e.PrimeVerticalRadius(0), 1e-3)
+	assert.InDelta(t, 6399593.626, e.PrimeVerticalRadius(-90), 1e-3)
+
+	sphere := Ellipsoid{a: 1000, b: 1000}
+	assert.Equal(t, 1000.0, sphere.AuthalicRadius())
+	assert.Equal(t, 1000.0, sphere.RectifyingRadius())
+	assert.Equal(t, 1000.0, sphere.MeridianRadius(45))
+}
+
+func TestMeridianArc(t *testing.T) {
+	e := WGS84()
+	assert.Equal(t, 0.0, e.MeridianArc(0))
+	assert.InDelta(t, 10001965.729, e.MeridianArc(90), 1e-3)
+	assert.InDelta(t, -10001965.729, e.MeridianArc(-90), 1e-3)
+	// from GeographicLib
+	assert.InDelta(t, 4984944.378, e.MeridianArc(45), 1e-3)
+	assert.InDelta(t, 90, float64(e.RectifyingLatitude(90)), 1e-12)
+
+	for _, lat := range []Degrees{-89.9, -41.29, 0, 10, 45, 51.47, 89} {
+		assert.InDelta(t, float64(lat), float64(e.MeridianArcLatitude(e.MeridianArc(lat))), 1e-11)
+		assert.InDelta(t, float64(lat), float64(e.LatitudeFromRectifying(e.RectifyingLatitude(lat))), 1e-11)
+	}
+
+	// the derivative is the radius of curvature in the meridian
+	dφ := Degrees(1e-4)
+	assert.InDelta(t, e.MeridianRadius(30), (e.MeridianArc(30+dφ/2)-e.MeridianArc(30-dφ/2))/dφ.Radians(), 1e-3)
+}
@@ -0,0 +1,77 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+)
+
+// ENU is a vector in the local East-North-Up frame of a point: East and North are on the plane tangent to the
+// ellipsoid at the point, and Up is along the normal to the ellipsoid, all in metres.
+type ENU struct {
+	East  float64
+	North float64
+	Up    float64
+}
+
+// enuTolerance is the length in metres below which an ENU vector, or its horizontal part, is considered to be zero,
+// to allow for rounding errors in the cartesian coordinates
+const enuTolerance = 1e-6
+
+// ENUTo returns the vector from `l` to `to` in the local East-North-Up frame at `l`. Both points should use the
+// same ellipsoid (see Datum to convert between them).
+func (l LatLonEllipsoidal) ENUTo(to LatLonEllipsoidal) ENU {
+	c1 := l.Cartesian()
+	c2 := to.Cartesian()
+	v := Vector3D(c2).Minus(Vector3D(c1)).ECEFToENU(l.LatLon)
+
+	return ENU{East: v.X, North: v.Y, Up: v.Z}
+}
+
+// Length returns the length of the vector in metres, the straight line distance between the points.
+func (v ENU) Length() float64 {
+	return math.Sqrt(v.East*v.East + v.North*v.North + v.Up*v.Up)
+}
+
+// Azimuth returns the bearing of the vector from North, in Degrees (0°..360°). Returns NaN if the vector is
+// vertical.
+func (v ENU) Azimuth() Degrees {
+	if math.Hypot(v.East, v.North) < enuTolerance {
+		return Degrees(math.NaN())
+	}
+
+	return Wrap360(DegreesFromRadians(math.Atan2(v.East, v.North)))
+}
+
+// Elevation returns the angle of the vector above the local horizon, in Degrees (-90°..90°). Returns NaN if the
+// vector has zero length.
+func (v ENU) Elevation() Degrees {
+	if v.Length() < enuTolerance {
+		return Degrees(math.NaN())
+	}
+
+	return DegreesFromRadians(math.Atan2(v.Up, math.Hypot(v.East, v.North)))
+}
+
+// ElevationAngle returns the angle of `to` above the local horizon at `from` (negative if it is below), and its
+// azimuth (the bearing of the straight line to `to` from North), for example for pointing an antenna at a satellite
+// or checking whether a target is above the horizon. The local horizon is the plane tangent to the ellipsoid at
+// `from`, and refraction is ignored.
+//
+// Both points should use the same ellipsoid (see Datum to convert between them).
+//
+// Returns NaN values if the points are the same, and a NaN azimuth if `to` is directly above or below `from`.
+//
+// Example:
+// antenna := geod.NewLatLonEllipsodial(-41.29, 174.78, 120)
+// target := geod.NewLatLonEllipsodial(-41.10, 174.95, 2000)
+// elevation, azimuth := geod.ElevationAngle(antenna, target)
+func ElevationAngle(from, to LatLonEllipsoidal) (Degrees, Degrees) {
+	v := from.ENUTo(to)
+
+	return v.Elevation(), v.Azimuth()
+}
@@ -0,0 +1,37 @@
+package geod
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestElevationAngle(t *testing.T) {
+	p := NewLatLonEllipsodial(-41.29, 174.78, 0)
+
+	el, az := ElevationAngle(p, NewLatLonEllipsodial(-41.29, 174.78, 1000))
+	assert.InDelta(t, 90, float64(el), 1e-9)
+	assert.True(t, math.IsNaN(float64(az)))
+
+	el, az = ElevationAngle(p, p)
+	assert.True(t, math.IsNaN(float64(el)))
+	assert.True(t, math.IsNaN(float64(az)))
+
+	// a point on the surface drops below the horizon by about d/2R radians
+	q := NewLatLonEllipsodial(-41.2, 174.8, 0)
+	el, az = ElevationAngle(p, q)
+	d := float64(Distance(p.LatLon, q.LatLon, VincentyModel).Metre())
+	assert.InDelta(t, float64(-DegreesFromRadians(d/2/6371e3)), float64(el), 0.001)
+	assert.InDelta(t, float64(InitialBearing(p.LatLon, q.LatLon, VincentyModel)), float64(az), 0.01)
+
+	// a geostationary satellite above the observer
+	el, _ = ElevationAngle(NewLatLonEllipsodial(0, 10, 0), NewLatLonEllipsodial(0, 10, 35786e3))
+	assert.InDelta(t, 90, float64(el), 1e-9)
+	el, az = ElevationAngle(NewLatLonEllipsodial(0, 90, 0), NewLatLonEllipsodial(0, 10, 35786e3))
+	assert.Greater(t, float64(el), 0.0)
+	assert.InDelta(t, 270, float64(az), 1e-9)
+
+	v := p.ENUTo(q)
+	assert.InDelta(t, float64(Distance3D(p, q).Metre()), v.Length(), 1e-6)
+}
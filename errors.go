@@ -0,0 +1,127 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"errors"
+	"fmt"
+)
+
+// Errors returned by the parsers (ParseDMS, ParseLatLon, ParseLatLonEllipsoidal), wrapped in a *ParseError.
+// ErrInvalidLatitude, ErrInvalidLongitude and ErrInvalidHeight are also returned by Position.Validate and
+// Position.UnmarshalJSON, and ErrInvalidArgument by CompositeGreatCircle and Coordinate.Transform, and by the panics
+// of RegisterCRS and RegisterModel, wrapped with a description of the invalid value instead. Use errors.Is() to check
+// for these.
+var (
+	ErrEmptyInput       = errors.New("empty input")
+	ErrInvalidArgument  = errors.New("invalid argument")
+	ErrInvalidDMS       = errors.New("invalid degrees/minutes/seconds value")
+	ErrInvalidLatitude  = errors.New("invalid latitude")
+	ErrInvalidLongitude = errors.New("invalid longitude")
+	ErrInvalidHeight    = errors.New("invalid height")
+	ErrTooManyTokens    = errors.New("too many items")
+	ErrUnknownDatum     = errors.New("unknown datum")
+)
+
+// ErrReducedPrecision is returned by VincentyInverseChecked if the Vincenty method failed to converge (nearly
+// antipodal points) and the result was calculated using a slower fallback method with reduced precision.
+var ErrReducedPrecision = errors.New("vincenty inverse failed to converge, result has reduced precision")
+
+// ErrInvalidTimeDelta is returned by SpeedBetween if the second fix is not later than the first.
+var ErrInvalidTimeDelta = errors.New("invalid time difference - must be positive")
+
+// ErrInvalidSpeed is returned by TravelTime and ETA if the speed is not positive.
+var ErrInvalidSpeed = errors.New("invalid speed - must be positive")
+
+// Errors returned by MeanPosition.
+var (
+	ErrInvalidWeights = errors.New("invalid weights")
+	ErrUndefinedMean  = errors.New("mean position is undefined")
+)
+
+// Errors returned by NewModel, CheckModel, the Checked variants of the package-level functions (e.g. DistanceChecked)
+// and SetEarthRadiusChecked. The model functions (e.g. VincentyModel) and SetEarthRadius panic with these errors.
+var (
+	ErrInvalidModelArgs   = errors.New("invalid model arguments")
+	ErrInvalidEarthRadius = errors.New("invalid Earth radius, must be positive")
+)
+
+// ErrInvalidAccuracy is returned by Position.Validate if the horizontal accuracy is negative or not a number. Invalid
+// positions are also reported with ErrInvalidLatitude, ErrInvalidLongitude and ErrInvalidHeight.
+var ErrInvalidAccuracy = errors.New("invalid accuracy")
+
+// ErrUnknownModel is returned by ModelByName if no model is registered with the name.
+var ErrUnknownModel = errors.New("unknown model")
+
+// Errors returned by FixFromBearings and FixFromRanges.
+var (
+	ErrTooFewObservations = errors.New("too few observations")
+	ErrNoFix              = errors.New("no position fix")
+)
+
+// Errors returned by Coordinate.Transform, if the point can't be converted to or from the grid coordinates of a
+// projected CRS, and by LookupCRS and CRSFromEPSG.
+var (
+	ErrOutsideProjection = errors.New("coordinates outside the projection")
+	ErrUnknownCRS        = errors.New("unknown coordinate reference system")
+)
+
+// ErrInvalidQuadKey is returned by ParseQuadKey, wrapped in a *ParseError, if the quadkey is not valid.
+var ErrInvalidQuadKey = errors.New("invalid quadkey")
+
+// ParseError describes why a coordinate could not be parsed.
+type ParseError struct {
+	// Err is one of the ErrXXX errors above, describing the type of failure
+	Err error
+	// Input is the string being parsed, empty if the value was not a string
+	Input string
+	// Token is the index of the offending item (argument or comma-separated token), -1 if not applicable
+	Token int
+	// Pos is the byte offset of the offending item in Input, -1 if not applicable
+	Pos int
+	// Detail is a further description of the failure, may be empty
+	Detail string
+}
+
+func (e *ParseError) Error() string {
+	msg := e.Err.Error()
+	if e.Detail != "" {
+		msg += ": " + e.Detail
+	}
+	if e.Pos >= 0 {
+		msg += fmt.Sprintf(" at position %d", e.Pos)
+	}
+	if e.Input != "" {
+		msg += fmt.Sprintf(" in %q", e.Input)
+	}
+
+	return msg
+}
+
+// Unwrap returns the underlying ErrXXX error.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parseError returns a *ParseError without position info
+func parseError(err error, input string, detail string) *ParseError {
+	return &ParseError{Err: err, Input: input, Token: -1, Pos: -1, Detail: detail}
+}
+
+// tokenError returns a *ParseError for the token at index `token` in the comma-separated `tokens`, which were split
+// from `input`. If `input` is empty, the position of the token is not known.
+func tokenError(err error, input string, tokens []string, token int, detail string) *ParseError {
+	pos := -1
+	if input != "" {
+		pos = 0
+		for i := 0; i < token && i < len(tokens); i++ {
+			pos += len(tokens[i]) + 1 // + the separator
+		}
+	}
+
+	return &ParseError{Err: err, Input: input, Token: token, Pos: pos, Detail: detail}
+}
@@ -0,0 +1,291 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/starboard-nz/units"
+)
+
+const (
+	// fixMaxIterations limits the number of Gauss-Newton steps of the position fixes
+	fixMaxIterations = 50
+	// fixTolerance is the size of a step, in degrees, below which the position fixes stop
+	fixTolerance = 1e-11
+	// fixDerivativeStep is the step, in degrees, of the finite differences used for the derivatives
+	fixDerivativeStep = 1e-7
+)
+
+// BearingObservation is the bearing of an unknown position observed from a known point, for example by radio
+// direction finding.
+type BearingObservation struct {
+	// From is where the bearing was observed from
+	From LatLon
+	// Bearing is the observed bearing of the position, in Degrees from North
+	Bearing Degrees
+}
+
+// BearingFix is a position calculated from bearing observations.
+type BearingFix struct {
+	// Position is the calculated position
+	Position LatLon
+	// Residuals are the observed bearings minus the bearings from the observation points to Position, in the same
+	// order as the observations
+	Residuals []Degrees
+}
+
+// FixFromBearings returns the position where the bearing lines of the observations intersect, or nearly intersect: the
+// least-squares fix, which minimises the sum of the squared distances from the position to the bearing lines. With 2
+// observations this is the intersection of the 2 lines, as for LatLonSpherical.Intersection, extended to any model
+// and to more observations, which in practice don't meet at a single point.
+//
+// Arguments:
+//
+// observations - at least 2 bearings observed from known points
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions. The bearing lines follow the paths of the model, for example great circles
+//	for SphericalModel and lines of constant bearing for RhumbModel.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// The fix starts from the mean of the spherical intersections of the pairs of bearing lines that meet ahead of both
+// observers, and is refined with the Gauss-Newton method. ErrTooFewObservations is returned for less than 2
+// observations, and ErrNoFix if no bearing lines meet, for example if they are all parallel, or the position is
+// undetermined. The refinement stops after 50 steps, returning the best estimate so far.
+//
+// Example:
+//
+//	fix, err := geod.FixFromBearings([]geod.BearingObservation{
+//		{From: geod.NewLatLon(-41.29, 174.78), Bearing: 45},
+//		{From: geod.NewLatLon(-41.0, 175.2), Bearing: 135},
+//		{From: geod.NewLatLon(-40.9, 174.9), Bearing: 100},
+//	}, geod.VincentyModel)
+func FixFromBearings(observations []BearingObservation, model EarthModel, modelArgs ...interface{}) (BearingFix, error) {
+	if len(observations) < 2 {
+		return BearingFix{}, fmt.Errorf("%w: %d bearings, at least 2 needed", ErrTooFewObservations,
+			len(observations))
+	}
+
+	models := make([]Model, len(observations))
+	for i, obs := range observations {
+		models[i] = model(obs.From, modelArgs...)
+	}
+
+	// the initial position, from the intersections of the bearing lines ahead of both observers
+	ahead := func(obs BearingObservation, ll LatLon) bool {
+		b := LatLonSpherical{ll: obs.From}.InitialBearingTo(ll)
+
+		return math.Abs(float64(Wrap180(b-obs.Bearing))) < 90
+	}
+
+	var sum Vector3D
+	for i := range observations {
+		for j := i + 1; j < len(observations); j++ {
+			oi, oj := observations[i], observations[j]
+			p := LatLonSpherical{ll: oi.From}.Intersection(oi.Bearing, oj.From, oj.Bearing)
+			if p.Valid() && !p.Equals(oi.From) && !p.Equals(oj.From) && ahead(oi, p) && ahead(oj, p) {
+				sum = sum.Plus(p.ToNvector())
+			}
+		}
+	}
+	if sum.Length() < 1e-9 {
+		return BearingFix{}, fmt.Errorf("%w: the bearing lines don't meet", ErrNoFix)
+	}
+
+	// the distance from the bearing line
+	offset := func(i int, ll LatLon) float64 {
+		if ll.Equals(observations[i].From) {
+			return 0
+		}
+
+		Δ := Wrap180(models[i].InitialBearingTo(ll) - observations[i].Bearing).Radians()
+
+		return float64(models[i].DistanceTo(ll).Metre()) * math.Sin(Δ)
+	}
+
+	position, ok := leastSquaresFix(NvectorToLatLon(sum), len(observations), offset)
+	if !ok {
+		return BearingFix{}, fmt.Errorf("%w: undetermined position", ErrNoFix)
+	}
+
+	fix := BearingFix{Position: position, Residuals: make([]Degrees, len(observations))}
+	for i, obs := range observations {
+		fix.Residuals[i] = Wrap180(obs.Bearing - models[i].InitialBearingTo(position))
+	}
+
+	return fix, nil
+}
+
+// RangeObservation is the distance of an unknown position measured from a known point, for example by radar or from
+// the travel time of a signal.
+type RangeObservation struct {
+	// From is where the range was measured from
+	From LatLon
+	// Range is the measured distance of the position
+	Range units.Distance
+}
+
+// RangeFix is a position calculated from range observations.
+type RangeFix struct {
+	// Position is the calculated position
+	Position LatLon
+	// Residuals are the observed ranges minus the distances from the observation points to Position, in the same
+	// order as the observations
+	Residuals []units.Distance
+}
+
+// FixFromRanges returns the position whose distances from the observation points best match the observed ranges: the
+// least-squares fix, which minimises the sum of the squared differences, using the distances of the model. See
+// FixFromBearings for the arguments.
+//
+// The fix starts from the spherical intersections of the range circles of pairs of observations (see
+// CircleIntersections), using the intersection that best fits all the observations, or the mean of the observation
+// points if no circles intersect, and is refined with the Gauss-Newton method. With 2 observations there are
+// generally 2 positions that fit, and the one on the left of the path from the first observation point to the second
+// is returned; use CircleIntersections to get both. ErrTooFewObservations is returned for less than 2 observations,
+// and ErrNoFix if the position is undetermined. The refinement stops after 50 steps, returning the best estimate so
+// far.
+//
+// Example:
+//
+//	fix, err := geod.FixFromRanges([]geod.RangeObservation{
+//		{From: geod.NewLatLon(-41.29, 174.78), Range: units.Km(22.1)},
+//		{From: geod.NewLatLon(-41.0, 175.2), Range: units.Km(19.4)},
+//		{From: geod.NewLatLon(-40.9, 174.9), Range: units.Km(24.0)},
+//	}, geod.VincentyModel)
+func FixFromRanges(observations []RangeObservation, model EarthModel, modelArgs ...interface{}) (RangeFix, error) {
+	if len(observations) < 2 {
+		return RangeFix{}, fmt.Errorf("%w: %d ranges, at least 2 needed", ErrTooFewObservations, len(observations))
+	}
+
+	models := make([]Model, len(observations))
+	for i, obs := range observations {
+		models[i] = model(obs.From, modelArgs...)
+	}
+
+	difference := func(i int, ll LatLon) float64 {
+		return float64(observations[i].Range.Metre()) - float64(models[i].DistanceTo(ll).Metre())
+	}
+	misfit := func(ll LatLon) float64 {
+		ss := 0.0
+		for i := range observations {
+			ss += difference(i, ll) * difference(i, ll)
+		}
+
+		return ss
+	}
+
+	// the initial position, the best fitting intersection of the range circles on the sphere
+	var start *LatLon
+	best := math.Inf(1)
+	for i := range observations {
+		for j := i + 1; j < len(observations); j++ {
+			oi, oj := observations[i], observations[j]
+			for _, p := range CircleIntersections(oi.From, oi.Range, oj.From, oj.Range, SphericalModel) {
+				// with 2 observations both intersections fit, and the first is used
+				if ss := misfit(p); ss < best && (start == nil || len(observations) > 2) {
+					p := p
+					start, best = &p, ss
+				}
+			}
+		}
+	}
+	if start == nil {
+		points := make([]LatLon, len(observations))
+		for i, obs := range observations {
+			points[i] = obs.From
+		}
+
+		mean, err := MeanPosition(points, nil, nil)
+		if err != nil {
+			return RangeFix{}, fmt.Errorf("%w: %v", ErrNoFix, err)
+		}
+		start = &mean
+	}
+
+	position, ok := leastSquaresFix(*start, len(observations), difference)
+	if !ok {
+		return RangeFix{}, fmt.Errorf("%w: undetermined position", ErrNoFix)
+	}
+
+	fix := RangeFix{Position: position, Residuals: make([]units.Distance, len(observations))}
+	for i := range observations {
+		fix.Residuals[i] = units.Metre(difference(i, position))
+	}
+
+	return fix, nil
+}
+
+// leastSquaresFix returns the position that minimises the sum of the squares of the `n` residuals, refined from
+// `start` with the Gauss-Newton method, using finite differences for the derivatives. Steps that don't reduce the sum
+// are halved. Returns false if the position is undetermined, when the derivatives are linearly dependent, otherwise the
+// best estimate, also if the steps don't get below fixTolerance within fixMaxIterations.
+func leastSquaresFix(start LatLon, n int, residual func(i int, ll LatLon) float64) (LatLon, bool) {
+	residuals := func(ll LatLon) ([]float64, float64) {
+		r := make([]float64, n)
+		ss := 0.0
+		for i := range r {
+			r[i] = residual(i, ll)
+			ss += r[i] * r[i]
+		}
+
+		return r, ss
+	}
+
+	p := start
+	r, ss := residuals(p)
+	for iter := 0; iter < fixMaxIterations; iter++ {
+		// the normal equations JᵀJ⋅step = Jᵀr, by latitude and longitude
+		rφ, _ := residuals(LatLon{Latitude: p.Latitude + fixDerivativeStep, Longitude: p.Longitude})
+		rλ, _ := residuals(LatLon{Latitude: p.Latitude, Longitude: p.Longitude + fixDerivativeStep})
+
+		var a11, a12, a22, g1, g2 float64
+		for i := range r {
+			jφ := (rφ[i] - r[i]) / fixDerivativeStep
+			jλ := (rλ[i] - r[i]) / fixDerivativeStep
+			a11 += jφ * jφ
+			a12 += jφ * jλ
+			a22 += jλ * jλ
+			g1 += jφ * r[i]
+			g2 += jλ * r[i]
+		}
+
+		det := a11*a22 - a12*a12
+		if det == 0 || math.IsNaN(det) {
+			return p, false
+		}
+
+		Δφ := (a22*g1 - a12*g2) / det
+		Δλ := (a11*g2 - a12*g1) / det
+
+		for halvings := 0; ; halvings++ {
+			next := LatLon{Latitude: p.Latitude - Degrees(Δφ), Longitude: Wrap180(p.Longitude - Degrees(Δλ))}
+			if nextR, nextSS := residuals(next); nextSS <= ss && next.Valid() {
+				p, r, ss = next, nextR, nextSS
+				break
+			}
+
+			if halvings == 10 {
+				// no further improvement
+				return p, true
+			}
+			Δφ, Δλ = Δφ/2, Δλ/2
+		}
+
+		if math.Hypot(Δφ, Δλ) < fixTolerance {
+			return p, true
+		}
+	}
+
+	return p, true
+}
@@ -0,0 +1,122 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"testing"
+
+	"github.com/starboard-nz/units"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFixFromBearings(t *testing.T) {
+	target := NewLatLon(-41.1, 175.0)
+	stations := []LatLon{NewLatLon(-41.29, 174.78), NewLatLon(-41.0, 175.2), NewLatLon(-40.9, 174.9)}
+
+	for _, model := range []EarthModel{SphericalModel, RhumbModel, VincentyModel} {
+		observations := make([]BearingObservation, len(stations))
+		for i, s := range stations {
+			observations[i] = BearingObservation{From: s, Bearing: model(s).InitialBearingTo(target)}
+		}
+
+		// exact bearings
+		fix, err := FixFromBearings(observations, model)
+		require.NoError(t, err)
+		assert.True(t, target.EqualsWithin(fix.Position, units.Metre(1e-3), model))
+		require.Len(t, fix.Residuals, 3)
+		for _, r := range fix.Residuals {
+			assert.InDelta(t, 0, float64(r), 1e-6)
+		}
+
+		// 2 bearings are the intersection
+		fix, err = FixFromBearings(observations[:2], model)
+		require.NoError(t, err)
+		assert.True(t, target.EqualsWithin(fix.Position, units.Metre(1e-3), model))
+
+		// with errors, the fix is close and the residuals show the errors
+		observations[0].Bearing += 1
+		observations[1].Bearing -= 0.5
+		fix, err = FixFromBearings(observations, model)
+		require.NoError(t, err)
+		assert.Less(t, float64(model(target).DistanceTo(fix.Position).Km()), 1.0)
+		assert.Greater(t, math.Abs(float64(fix.Residuals[0])), 0.1)
+	}
+
+	// the intersection of the spherical example
+	p1 := NewLatLon(51.8853, 0.2545)
+	p2 := NewLatLon(49.0034, 2.5735)
+	fix, err := FixFromBearings([]BearingObservation{{p1, 108.547}, {p2, 32.435}}, SphericalModel)
+	require.NoError(t, err)
+	expected := LatLonSpherical{ll: p1}.Intersection(108.547, p2, 32.435)
+	assert.True(t, expected.EqualsWithin(fix.Position, units.Metre(1e-3), SphericalModel))
+
+	_, err = FixFromBearings([]BearingObservation{{p1, 90}}, SphericalModel)
+	assert.ErrorIs(t, err, ErrTooFewObservations)
+
+	// diverging bearings
+	_, err = FixFromBearings([]BearingObservation{{p1, 270}, {p2, 90}}, SphericalModel)
+	assert.ErrorIs(t, err, ErrNoFix)
+}
+
+func TestFixFromRanges(t *testing.T) {
+	target := NewLatLon(-41.1, 175.0)
+	stations := []LatLon{NewLatLon(-41.29, 174.78), NewLatLon(-41.0, 175.2), NewLatLon(-40.9, 174.9)}
+
+	for _, model := range []EarthModel{SphericalModel, RhumbModel, VincentyModel} {
+		observations := make([]RangeObservation, len(stations))
+		for i, s := range stations {
+			observations[i] = RangeObservation{From: s, Range: model(s).DistanceTo(target)}
+		}
+
+		// exact ranges
+		fix, err := FixFromRanges(observations, model)
+		require.NoError(t, err)
+		assert.True(t, target.EqualsWithin(fix.Position, units.Metre(1e-3), model))
+		require.Len(t, fix.Residuals, 3)
+		for _, r := range fix.Residuals {
+			assert.InDelta(t, 0, float64(r.Metre()), 1e-3)
+		}
+
+		// 2 ranges, the intersection on the left going from the first station to the second, and the other one
+		fix, err = FixFromRanges(observations[:2], model)
+		require.NoError(t, err)
+		assert.True(t, target.EqualsWithin(fix.Position, units.Metre(1e-3), model))
+		fix, err = FixFromRanges([]RangeObservation{observations[1], observations[0]}, model)
+		require.NoError(t, err)
+		assert.Greater(t, float64(model(target).DistanceTo(fix.Position).Km()), 1.0)
+
+		// with errors, the fix is close and the residuals show the errors
+		observations[0].Range = units.Metre(observations[0].Range.Metre() + 200)
+		observations[1].Range = units.Metre(observations[1].Range.Metre() - 100)
+		fix, err = FixFromRanges(observations, model)
+		require.NoError(t, err)
+		assert.Less(t, float64(model(target).DistanceTo(fix.Position).Metre()), 300.0)
+		assert.Greater(t, math.Abs(float64(fix.Residuals[0].Metre())), 10.0)
+	}
+
+	// ranges too short for the circles to meet
+	fix, err := FixFromRanges([]RangeObservation{
+		{From: NewLatLon(0, 0), Range: units.Km(50)},
+		{From: NewLatLon(0, 1), Range: units.Km(50)},
+		{From: NewLatLon(1, 0.5), Range: units.Km(50)},
+	}, SphericalModel)
+	require.NoError(t, err)
+	assert.InDelta(t, 0.4, float64(fix.Position.Latitude), 0.1)
+	assert.InDelta(t, 0.5, float64(fix.Position.Longitude), 1e-6)
+
+	// the same range twice from the same point, any point on the circle fits
+	p := NewLatLon(-41.29, 174.78)
+	_, err = FixFromRanges([]RangeObservation{{From: p, Range: units.Km(10)}, {From: p, Range: units.Km(10)}},
+		SphericalModel)
+	assert.ErrorIs(t, err, ErrNoFix)
+	assert.Contains(t, err.Error(), "undetermined position")
+
+	_, err = FixFromRanges(nil, SphericalModel)
+	assert.ErrorIs(t, err, ErrTooFewObservations)
+}
@@ -9,6 +9,10 @@ package geod
  */
 
 import (
+	"errors"
+	"fmt"
+	"math"
+
 	"github.com/starboard-nz/units"
 )
 
@@ -18,6 +22,12 @@ import (
 //	geod.SphericalModel  - spherical Earth, along great circles
 //	geod.RhumbModel      - spherical Earth, along rhumb lines
 //	geod.VincentyModel   - ellipsoid Earth, high accuracy, slower than SphericalModel
+//
+// Model arguments passed to the package-level functions (e.g. Distance) tune the calculations of a single call: the
+// `Radius` for SphericalModel and RhumbModel, and the `Ellipsoid`, `Precision`, `Tolerance` and `MaxIterations` for
+// VincentyModel.
+//
+// Models may also offer optional features, such as the intersection of paths, see Supports.
 type Model interface {
 	DistanceTo(ll LatLon) units.Distance
 	InitialBearingTo(ll LatLon) Degrees
@@ -31,6 +41,45 @@ type Model interface {
 
 type EarthModel func(LatLon, ...interface{}) Model
 
+// modelArgsError returns the error the `model` function panics with if its arguments are invalid
+func modelArgsError(model, detail string) error {
+	return fmt.Errorf("%w in call to %s(): %s", ErrInvalidModelArgs, model, detail)
+}
+
+// NewModel returns the `Model` for `ll`, using the `model` function with the `modelArgs`, like calling
+// model(ll, modelArgs...), but returns an error wrapping ErrInvalidModelArgs instead of panicking if the arguments are
+// invalid. This allows the model and its arguments to come from configuration, for example in services that must not
+// crash on bad input. Other panics of the model function are not recovered.
+//
+// Example:
+// m, err := geod.NewModel(p1, geod.VincentyModel, args...)
+// if err != nil {
+// ... handle the error
+// }
+// dist := m.DistanceTo(p2)
+func NewModel(ll LatLon, model EarthModel, modelArgs ...interface{}) (m Model, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(error)
+			if !ok || !errors.Is(e, ErrInvalidModelArgs) {
+				panic(r)
+			}
+			m, err = nil, e
+		}
+	}()
+
+	return model(ll, modelArgs...), nil
+}
+
+// CheckModel returns an error if the `model` function can't be used with the `modelArgs`, see NewModel. The
+// package-level functions (e.g. Distance) panic if the arguments are invalid, so check them first if they come from
+// configuration, or use the variants returning errors (e.g. DistanceChecked).
+func CheckModel(model EarthModel, modelArgs ...interface{}) error {
+	_, err := NewModel(LatLon{}, model, modelArgs...)
+
+	return err
+}
+
 // MidPoint returns the point halfway between `start` and `end` using the given `model`.
 //
 // Arguments:
@@ -235,3 +284,111 @@ func IntermediatePoints(start, end LatLon, fractions []float64, model EarthModel
 	p1 := model(start, modelArgs...)
 	return p1.IntermediatePointsTo(end, fractions)
 }
+
+// SplitSegment returns the points at the given distances along the segment from `start` to `end`, for example to
+// generate kilometre posts along a route. The distance between the points is only calculated once, and the points
+// are calculated using `IntermediatePointsTo`.
+//
+// Arguments:
+//
+// start - starting point
+// end - end point (destination)
+// distances - slice of distances from `start`, these should not be greater than the length of the segment
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// Returns slice of points at the given distances.
+// Points that cannot be calculated are returned as invalid points, can be tested using `LatLon.Valid()`
+//
+// Example:
+// p1 := geod.NewLatLon(10.1, -20.0)
+// p2 := geod.NewLatLon(12.1, -23.2)
+// posts := geod.SplitSegment(p1, p2, []units.Distance{units.Km(100), units.Km(200)}, geod.VincentyModel)
+func SplitSegment(start, end LatLon, distances []units.Distance, model EarthModel,
+	modelArgs ...interface{}) []LatLon {
+
+	p1 := model(start, modelArgs...)
+
+	if start.Equals(end) {
+		points := make([]LatLon, len(distances))
+		for i := range points {
+			points[i] = start
+		}
+
+		return points
+	}
+
+	length := p1.DistanceTo(end).Metre()
+
+	fractions := make([]float64, len(distances))
+	for i, d := range distances {
+		fractions[i] = float64(d.Metre() / length)
+	}
+
+	return p1.IntermediatePointsTo(end, fractions)
+}
+
+// maxSamplePathPoints limits the number of points returned by SamplePath
+const maxSamplePathPoints = 1 << 20
+
+// SamplePath returns points along the path from `start` to `end`, including both, spaced evenly so that the angle
+// between consecutive points, seen from the centre of the Earth, is at most `maxStep`, for example for drawing the
+// path smoothly on a map. This is cheaper than densifying the path to a tolerance (see utils.DensifySegment), as the
+// number of points is calculated from the length of the path only.
+//
+// Arguments:
+//
+// start - starting point
+// end - end point (destination)
+// maxStep - the largest angle between consecutive points, in `Degrees`, must be positive, otherwise nil is returned,
+// as it is if more than 2²⁰ points would be needed
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// The angle is the length of the path divided by the radius of the model's sphere, or by the smallest radius of
+// curvature of the ellipsoid for ellipsoidal models, so that the points are never further apart than `maxStep`.
+//
+// Example:
+// p1 := geod.NewLatLon(-36.85, 174.76)
+// p2 := geod.NewLatLon(37.62, -122.38)
+// path := geod.SamplePath(p1, p2, 1, geod.SphericalModel)        // 96 points, 110.5km apart
+func SamplePath(start, end LatLon, maxStep Degrees, model EarthModel, modelArgs ...interface{}) []LatLon {
+	if !(maxStep > 0) {
+		return nil
+	}
+
+	if start.Equals(end) {
+		return []LatLon{start, end}
+	}
+
+	p1 := model(start, modelArgs...)
+	δ := float64(p1.DistanceTo(end).Metre()) / minRadius(p1)
+	steps := math.Ceil(δ / maxStep.Radians())
+	if !(steps < maxSamplePathPoints) {
+		return nil
+	}
+	n := int(math.Max(1, steps))
+
+	fractions := make([]float64, n-1)
+	for i := range fractions {
+		fractions[i] = float64(i+1) / float64(n)
+	}
+
+	points := make([]LatLon, 0, n+1)
+	points = append(points, start)
+	points = append(points, p1.IntermediatePointsTo(end, fractions)...)
+
+	return append(points, end)
+}
@@ -8,8 +8,12 @@ package geod_test
 
 import (
 	"fmt"
+	"math"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
 	geod "github.com/starboard-nz/go-geodesy"
 	"github.com/starboard-nz/units"
 )
@@ -94,6 +98,59 @@ func TestSgnBwn(t *testing.T) {
 	fmt.Printf("  Midpoint distances: rhumb to Vincenty: %v\n", geod.Distance(mpr, mpv, geod.VincentyModel).Metre())
 }
 
+func TestSplitSegment(t *testing.T) {
+	p1 := geod.LatLon{-36.8368, 174.765}  // Auckland
+	p2 := geod.LatLon{32.6616, -117.2241} // San Diego
+
+	distances := []units.Distance{units.Km(0), units.Km(1000), units.NM(2000), units.Km(5000)}
+	for _, model := range []geod.EarthModel{geod.SphericalModel, geod.RhumbModel, geod.VincentyModel} {
+		points := geod.SplitSegment(p1, p2, distances, model)
+		require.Len(t, points, len(distances))
+
+		assert.InDelta(t, float64(p1.Latitude), float64(points[0].Latitude), 1e-9)
+		assert.InDelta(t, float64(p1.Longitude), float64(points[0].Longitude), 1e-9)
+		for i := 1; i < len(distances); i++ {
+			d := geod.Distance(p1, points[i], model)
+			assert.InDelta(t, float64(distances[i].Metre()), float64(d.Metre()), 1e-3)
+
+			// on the segment
+			total := geod.Distance(p1, points[i], model).Metre() + geod.Distance(points[i], p2, model).Metre()
+			assert.InDelta(t, float64(geod.Distance(p1, p2, model).Metre()), float64(total), 1e-3)
+		}
+	}
+
+	points := geod.SplitSegment(p1, p1, distances, geod.VincentyModel)
+	assert.Equal(t, []geod.LatLon{p1, p1, p1, p1}, points)
+}
+
+func TestSamplePath(t *testing.T) {
+	p1 := geod.LatLon{-36.8368, 174.765}  // Auckland
+	p2 := geod.LatLon{32.6616, -117.2241} // San Diego
+
+	for _, model := range []geod.EarthModel{geod.SphericalModel, geod.RhumbModel, geod.VincentyModel} {
+		points := geod.SamplePath(p1, p2, 1, model)
+		require.Greater(t, len(points), 2)
+		assert.Equal(t, p1, points[0])
+		assert.Equal(t, p2, points[len(points)-1])
+
+		// evenly spaced, at most 1° (about 111km) apart
+		step := geod.Distance(points[0], points[1], model).Metre()
+		assert.LessOrEqual(t, float64(step), 2*math.Pi*6371000/360)
+		for i := 2; i < len(points); i++ {
+			assert.InDelta(t, float64(step), float64(geod.Distance(points[i-1], points[i], model).Metre()), 1)
+		}
+
+		// one less point would be too far apart
+		total := geod.Distance(p1, p2, model).Metre()
+		assert.Greater(t, float64(total)/float64(len(points)-2), 2*math.Pi*6356000/360)
+	}
+
+	assert.Len(t, geod.SamplePath(p1, p2, 180, geod.SphericalModel), 2)
+	assert.Equal(t, []geod.LatLon{p1, p1}, geod.SamplePath(p1, p1, 1, geod.VincentyModel))
+	assert.Nil(t, geod.SamplePath(p1, p2, 0, geod.SphericalModel))
+	assert.Nil(t, geod.SamplePath(p1, p2, 1e-12, geod.SphericalModel))
+}
+
 func BenchmarkMidPointSpherical(b *testing.B) {
 	p := getTestPositions()
 	N := len(p)
@@ -1199,3 +1256,67 @@ func getTestPositions() []geod.LatLon {
 		{53.6257166666667, 0.192516666666667},
 	}
 }
+
+func TestNewModel(t *testing.T) {
+	p1 := geod.NewLatLon(-41.29, 174.78)
+	p2 := geod.NewLatLon(-43.49, 172.53)
+
+	m, err := geod.NewModel(p1, geod.VincentyModel, geod.WGS84(), geod.HighPrecision)
+	require.NoError(t, err)
+	assert.Equal(t, geod.Distance(p1, p2, geod.VincentyModel, geod.HighPrecision), m.DistanceTo(p2))
+
+	for _, tc := range []struct {
+		model geod.EarthModel
+		args  []interface{}
+	}{
+		{geod.VincentyModel, []interface{}{"WGS84"}},
+		{geod.VincentyModel, []interface{}{geod.WGS84(), geod.HighPrecision, geod.Tolerance(1e-9), geod.MaxIterations(10), geod.StandardPrecision}},
+		{geod.VincentyModel, []interface{}{geod.Tolerance(0)}},
+		{geod.VincentyModel, []interface{}{geod.MaxIterations(0)}},
+		{geod.SphericalModel, []interface{}{geod.Radius(0)}},
+		{geod.SphericalModel, []interface{}{geod.Radius(6371000), geod.Radius(6371000)}},
+		{geod.SphericalModel, []interface{}{geod.WGS84()}},
+		{geod.RhumbModel, []interface{}{1}},
+		{geod.PlanarModel, []interface{}{1}},
+		{geod.PlanarModelAt(p1), []interface{}{1}},
+	} {
+		m, err := geod.NewModel(p1, tc.model, tc.args...)
+		assert.Nil(t, m)
+		assert.ErrorIs(t, err, geod.ErrInvalidModelArgs)
+		assert.ErrorIs(t, geod.CheckModel(tc.model, tc.args...), geod.ErrInvalidModelArgs)
+		assert.Panics(t, func() { tc.model(p1, tc.args...) })
+	}
+
+	assert.NoError(t, geod.CheckModel(geod.SphericalModel))
+
+	// other panics are not errors in the arguments
+	assert.PanicsWithValue(t, "boom", func() {
+		_, _ = geod.NewModel(p1, func(geod.LatLon, ...interface{}) geod.Model { panic("boom") })
+	})
+}
+
+func TestModelArgs(t *testing.T) {
+	p1 := geod.NewLatLon(52.205, 0.119)
+	p2 := geod.NewLatLon(48.857, 2.351)
+
+	// the radius overrides the global radius for the call
+	for _, model := range []geod.EarthModel{geod.SphericalModel, geod.RhumbModel} {
+		d := geod.Distance(p1, p2, model).Metre()
+		dr := geod.Distance(p1, p2, model, geod.Radius(2*geod.EarthRadius())).Metre()
+		assert.InDelta(t, float64(2*d), float64(dr), 1e-6)
+
+		dest := geod.DestinationPoint(p1, float64(dr), geod.InitialBearing(p1, p2, model), model, geod.Radius(2*geod.EarthRadius()))
+		assert.InDelta(t, float64(p2.Latitude), float64(dest.Latitude), 1e-9)
+		assert.InDelta(t, float64(p2.Longitude), float64(dest.Longitude), 1e-9)
+	}
+	assert.Equal(t, 6371000.0, geod.EarthRadius())
+
+	// a looser tolerance is less accurate, but close
+	d := geod.Distance(p1, p2, geod.VincentyModel).Metre()
+	dt := geod.Distance(p1, p2, geod.VincentyModel, geod.Tolerance(1e-6), geod.WGS84()).Metre()
+	assert.InDelta(t, float64(d), float64(dt), 1)
+
+	// too few iterations fail
+	assert.True(t, math.IsNaN(float64(geod.Distance(p1, p2, geod.VincentyModel, geod.MaxIterations(1)).Metre())))
+	assert.InDelta(t, float64(d), float64(geod.Distance(p1, p2, geod.VincentyModel, geod.MaxIterations(10)).Metre()), 1e-6)
+}
@@ -0,0 +1,234 @@
+// Package geodtest validates the accuracy of Earth models against geodesics on the WGS84 ellipsoid calculated with
+// GeographicLib, so that custom models (see geod.RegisterModel) can be checked programmatically.
+//
+// The package embeds the 20 test geodesics of GeographicLib's own test suite, in the format of its GeodTest.dat test set
+// (see GeodTest-geographiclib.dat for their source and licence). The full test set, with 500000 geodesics, can be
+// downloaded from https://sourceforge.net/projects/geographiclib/files/testdata/ and read with ParseGeodesics.
+package geodtest
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/units"
+)
+
+//go:embed GeodTest-geographiclib.dat
+var sample string
+
+var (
+	sampleOnce      sync.Once
+	sampleGeodesics []Geodesic
+)
+
+// Geodesic is a test geodesic on the WGS84 ellipsoid, a line of GeodTest.dat.
+type Geodesic struct {
+	// Start and End are the ends of the geodesic
+	Start, End geod.LatLon
+	// Azimuth1 and Azimuth2 are the forward azimuths of the geodesic at Start and End
+	Azimuth1, Azimuth2 geod.Degrees
+	// Distance is the length of the geodesic in metres
+	Distance float64
+	// Arc is the length of the geodesic on the auxiliary sphere, in degrees
+	Arc float64
+	// ReducedLength is the reduced length of the geodesic in metres
+	ReducedLength float64
+	// Area is the area between the geodesic and the equator in square metres
+	Area float64
+}
+
+// ParseGeodesics reads geodesics in the format of GeodTest.dat: one per line, with the 10 values of Geodesic
+// separated by spaces, in the order lat1 lon1 azi1 lat2 lon2 azi2 s12 a12 m12 S12. Empty lines and comment lines,
+// starting with '#', are skipped. Longitudes are wrapped to [-180, 180].
+func ParseGeodesics(r io.Reader) ([]Geodesic, error) {
+	var geodesics []Geodesic
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		if len(fields) != 10 {
+			return nil, fmt.Errorf("line %d: %d values, 10 expected", line, len(fields))
+		}
+
+		var v [10]float64
+		for i, f := range fields {
+			var err error
+			if v[i], err = strconv.ParseFloat(f, 64); err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+		}
+
+		geodesics = append(geodesics, Geodesic{
+			Start:         geod.LatLon{Latitude: geod.Degrees(v[0]), Longitude: geod.Wrap180(geod.Degrees(v[1]))},
+			Azimuth1:      geod.Degrees(v[2]),
+			End:           geod.LatLon{Latitude: geod.Degrees(v[3]), Longitude: geod.Wrap180(geod.Degrees(v[4]))},
+			Azimuth2:      geod.Degrees(v[5]),
+			Distance:      v[6],
+			Arc:           v[7],
+			ReducedLength: v[8],
+			Area:          v[9],
+		})
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return geodesics, nil
+}
+
+// Geodesics returns the embedded test geodesics.
+func Geodesics() []Geodesic {
+	sampleOnce.Do(func() {
+		var err error
+		if sampleGeodesics, err = ParseGeodesics(strings.NewReader(sample)); err != nil {
+			panic(err)
+		}
+	})
+
+	return append([]Geodesic(nil), sampleGeodesics...)
+}
+
+// Report is the accuracy of a model for a set of test geodesics, see ValidateModel.
+type Report struct {
+	// Geodesics is the number of test geodesics
+	Geodesics int
+	// MaxDistanceError and MeanDistanceError are the largest and mean absolute errors of the distances between the
+	// ends of the geodesics (the inverse problem), and MaxRelativeDistanceError the largest as a fraction of the
+	// length of the geodesic
+	MaxDistanceError         units.Distance
+	MeanDistanceError        units.Distance
+	MaxRelativeDistanceError float64
+	// WorstDistance is the index of the geodesic with the largest distance error
+	WorstDistance int
+	// MaxBearingError is the largest absolute error of the initial bearings
+	MaxBearingError geod.Degrees
+	// MaxPositionError is the largest distance between the end of a geodesic and the destination point at its
+	// length and initial azimuth from its start (the direct problem), measured on the WGS84 ellipsoid
+	MaxPositionError units.Distance
+	// InverseFailures and DirectFailures are the numbers of geodesics for which the model returned NaN, or panicked,
+	// for example because it doesn't implement DestinationPoint. They aren't included in the errors.
+	InverseFailures int
+	DirectFailures  int
+}
+
+// String returns a summary of the report.
+func (r Report) String() string {
+	return fmt.Sprintf("%d geodesics: distance error max %.6fm (%.3g relative), mean %.6fm; bearing error max %.3g°; "+
+		"position error max %.6fm; failures %d inverse, %d direct", r.Geodesics, float64(r.MaxDistanceError.Metre()),
+		r.MaxRelativeDistanceError, float64(r.MeanDistanceError.Metre()), float64(r.MaxBearingError),
+		float64(r.MaxPositionError.Metre()), r.InverseFailures, r.DirectFailures)
+}
+
+// ValidateModel returns the accuracy of the model for the embedded test geodesics, comparing the distances
+// and initial bearings between their ends, and the destination points at their lengths, with the values calculated
+// with GeographicLib.
+//
+// Example:
+//
+//	report := geodtest.ValidateModel(karney.Model)
+//	if report.MaxDistanceError.Metre() > 0.001 {
+//		log.Printf("inaccurate model: %v", report)
+//	}
+func ValidateModel(model geod.EarthModel, modelArgs ...interface{}) Report {
+	return Validate(Geodesics(), model, modelArgs...)
+}
+
+// Validate returns the accuracy of the model for the test geodesics, for example the full GeodTest.dat read with
+// ParseGeodesics. See ValidateModel.
+func Validate(geodesics []Geodesic, model geod.EarthModel, modelArgs ...interface{}) Report {
+	r := Report{
+		Geodesics:         len(geodesics),
+		MaxDistanceError:  units.Metre(0),
+		MeanDistanceError: units.Metre(0),
+		WorstDistance:     -1,
+		MaxPositionError:  units.Metre(0),
+	}
+
+	total, counted := 0.0, 0
+	for i, g := range geodesics {
+		if d, b, ok := inverse(g, model, modelArgs); ok {
+			e := math.Abs(d - g.Distance)
+			total += e
+			counted++
+
+			if r.WorstDistance < 0 || e > float64(r.MaxDistanceError.Metre()) {
+				r.MaxDistanceError, r.WorstDistance = units.Metre(e), i
+			}
+			if g.Distance > 0 {
+				r.MaxRelativeDistanceError = math.Max(r.MaxRelativeDistanceError, e/g.Distance)
+			}
+			if g.Distance > 0 && !math.IsNaN(float64(b)) {
+				r.MaxBearingError = geod.Degrees(math.Max(float64(r.MaxBearingError),
+					math.Abs(float64(geod.Wrap180(b-g.Azimuth1)))))
+			}
+		} else {
+			r.InverseFailures++
+		}
+
+		if e, ok := direct(g, model, modelArgs); ok {
+			r.MaxPositionError = units.Metre(math.Max(float64(r.MaxPositionError.Metre()), e))
+		} else {
+			r.DirectFailures++
+		}
+	}
+
+	if counted > 0 {
+		r.MeanDistanceError = units.Metre(total / float64(counted))
+	}
+
+	return r
+}
+
+// inverse returns the distance and initial bearing between the ends of the geodesic calculated with the model, or
+// false if the model failed
+func inverse(g Geodesic, model geod.EarthModel, modelArgs []interface{}) (d float64, b geod.Degrees, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	// the bearing is undefined between identical points
+	if g.Start.Equals(g.End) {
+		return 0, geod.Degrees(math.NaN()), true
+	}
+
+	m := model(g.Start, modelArgs...)
+	d = float64(m.DistanceTo(g.End).Metre())
+	b = m.InitialBearingTo(g.End)
+
+	return d, b, !math.IsNaN(d)
+}
+
+// direct returns the distance between the end of the geodesic and the destination point calculated with the model,
+// or false if the model failed
+func direct(g Geodesic, model geod.EarthModel, modelArgs []interface{}) (e float64, ok bool) {
+	defer func() {
+		if recover() != nil {
+			ok = false
+		}
+	}()
+
+	p := model(g.Start, modelArgs...).DestinationPoint(g.Distance, g.Azimuth1)
+	if !p.Valid() {
+		return 0, false
+	}
+	if p.Equals(g.End) {
+		return 0, true
+	}
+
+	e = float64(geod.VincentyModel(g.End).DistanceTo(p).Metre())
+
+	return e, !math.IsNaN(e)
+}
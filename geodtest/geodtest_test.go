@@ -0,0 +1,58 @@
+package geodtest_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/geodtest"
+)
+
+func TestGeodesics(t *testing.T) {
+	geodesics := geodtest.Geodesics()
+	require.Len(t, geodesics, 20)
+	assert.Equal(t, geod.NewLatLon(35.60777, -139.44815), geodesics[0].Start)
+	// longitudes are wrapped
+	assert.InDelta(t, -162.81766, float64(geodesics[1].End.Longitude), 1e-9)
+
+	// a copy
+	geodesics[0].Distance = 0
+	assert.NotEqual(t, 0.0, geodtest.Geodesics()[0].Distance)
+
+	g, err := geodtest.ParseGeodesics(strings.NewReader("# comment\n10 0 45 20 10 50 1000 0.01 1000 0\n\n"))
+	require.NoError(t, err)
+	require.Len(t, g, 1)
+	assert.Equal(t, geod.NewLatLon(20, 10), g[0].End)
+
+	_, err = geodtest.ParseGeodesics(strings.NewReader("10 0 45 20 10 50 1000 0.01 1000\n"))
+	assert.Error(t, err)
+	_, err = geodtest.ParseGeodesics(strings.NewReader("10 0 45 20 10 50 1000 0.01 1000 x\n"))
+	assert.Error(t, err)
+}
+
+func TestValidateModel(t *testing.T) {
+	// Vincenty is accurate to well under a millimetre
+	report := geodtest.ValidateModel(geod.VincentyModel)
+	assert.Equal(t, 20, report.Geodesics)
+	assert.Less(t, float64(report.MaxDistanceError.Metre()), 1e-3)
+	assert.Less(t, float64(report.MaxPositionError.Metre()), 1e-3)
+	assert.Zero(t, report.InverseFailures)
+	assert.Zero(t, report.DirectFailures)
+
+	// the sphere is accurate to about 0.5%
+	report = geodtest.ValidateModel(geod.SphericalModel)
+	assert.Equal(t, 20, report.Geodesics)
+	assert.Greater(t, report.MaxRelativeDistanceError, 1e-3)
+	assert.Less(t, report.MaxRelativeDistanceError, 1e-2)
+	assert.Greater(t, float64(report.MeanDistanceError.Metre()), 0.0)
+	assert.Less(t, float64(report.MeanDistanceError.Metre()), float64(report.MaxDistanceError.Metre()))
+
+	// PlanarModel doesn't implement DestinationPoint
+	report = geodtest.ValidateModel(geod.PlanarModel)
+	assert.Equal(t, 20, report.DirectFailures)
+	assert.Zero(t, report.InverseFailures)
+	assert.Contains(t, report.String(), "20 direct")
+}
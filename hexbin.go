@@ -0,0 +1,177 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/starboard-nz/orb"
+)
+
+// Hexagonal binning
+//
+// The hexagons are laid out on the Lambert cylindrical equal-area projection of the sphere (x = longitude in radians,
+// y = sin(latitude)), so all cells of a resolution have the same area on the sphere. Shapes are distorted away from
+// the equator: cells get shorter north-south and wider east-west on the ground, which doesn't matter for counting
+// or aggregating positions.
+//
+// At resolution 0 there are 12 cells around the equator, and each resolution doubles that. The width of the cells
+// is chosen so that the grid wraps around the antimeridian without a seam.
+
+// MaxHexResolution is the highest supported resolution, with cells around 20cm wide at the equator.
+const MaxHexResolution = 24
+
+// hexColumns0 is the number of cells around the equator at resolution 0
+const hexColumns0 = 12
+
+// HexCell identifies a hexagonal cell at a resolution, using axial coordinates (Q, R) of pointy-top hexagons.
+// R is the row, increasing to the north, Q increases to the east.
+type HexCell struct {
+	Resolution int
+	Q          int
+	R          int
+}
+
+// hexSize returns the distance between the centre and the vertices of the hexagons, in projected units, and the
+// number of columns around the globe
+func hexSize(resolution int) (float64, int) {
+	columns := hexColumns0 << resolution
+	width := 2 * π / float64(columns)
+
+	return width / math.Sqrt(3), columns
+}
+
+// HexCellAt returns the cell containing the point at the given resolution (0..MaxHexResolution).
+// Panics if the resolution is out of range.
+func HexCellAt(ll LatLon, resolution int) HexCell {
+	if resolution < 0 || resolution > MaxHexResolution {
+		panic("invalid hex resolution")
+	}
+
+	size, columns := hexSize(resolution)
+	x := Wrap180(ll.Longitude).Radians()
+	y := math.Sin(ll.Latitude.Radians())
+
+	// fractional axial coordinates, rounded to the nearest hexagon using cube coordinates
+	q := (math.Sqrt(3)/3*x - y/3) / size
+	r := (2.0 / 3 * y) / size
+	s := -q - r
+
+	rq, rr, rs := math.Round(q), math.Round(r), math.Round(s)
+	dq, dr, ds := math.Abs(rq-q), math.Abs(rr-r), math.Abs(rs-s)
+	if dq > dr && dq > ds {
+		rq = -rr - rs
+	} else if dr > ds {
+		rr = -rq - rs
+	}
+
+	return HexCell{Resolution: resolution, Q: int(rq), R: int(rr)}.normalise(columns)
+}
+
+// normalise wraps the cell to its column in the -columns/2..columns/2 range, so cells on either side of the
+// antimeridian have a single representation
+func (c HexCell) normalise(columns int) HexCell {
+	// offset coordinates, with odd rows shifted right
+	col := c.Q + (c.R-(c.R&1))/2
+	wrapped := ((col+columns/2)%columns+columns)%columns - columns/2
+	c.Q += wrapped - col
+
+	return c
+}
+
+// projectedCentre returns the centre of the cell in the projection
+func (c HexCell) projectedCentre() (float64, float64) {
+	size, _ := hexSize(c.Resolution)
+	x := size * math.Sqrt(3) * (float64(c.Q) + float64(c.R)/2)
+	y := size * 3 / 2 * float64(c.R)
+
+	return x, y
+}
+
+// unprojectEqualArea converts projected coordinates to a point, limiting the latitude to the poles
+func unprojectEqualArea(x, y float64) LatLon {
+	y = math.Max(-1, math.Min(1, y))
+
+	return LatLon{Latitude: DegreesFromRadians(math.Asin(y)), Longitude: DegreesFromRadians(x)}
+}
+
+// Centre returns the centre of the cell. Cells on the antimeridian may have longitudes slightly over ±180.
+func (c HexCell) Centre() LatLon {
+	return unprojectEqualArea(c.projectedCentre())
+}
+
+// Boundary returns the 6 vertices of the cell, anticlockwise starting from the east. Longitudes are continuous
+// around the cell, so cells on the antimeridian have longitudes over ±180, and vertices beyond the poles are moved
+// to the poles.
+//
+// The edges are straight lines in the projection, which are close to rhumb lines for small cells.
+func (c HexCell) Boundary() []LatLon {
+	size, _ := hexSize(c.Resolution)
+	cx, cy := c.projectedCentre()
+
+	vertices := make([]LatLon, 6)
+	for i := range vertices {
+		a := float64(i)*π/3 - π/6
+		vertices[i] = unprojectEqualArea(cx+size*math.Cos(a), cy+size*math.Sin(a))
+	}
+
+	return vertices
+}
+
+// Polygon returns the cell's boundary as a closed polygon. See Boundary.
+func (c HexCell) Polygon() orb.Polygon {
+	vertices := c.Boundary()
+	ring := make(orb.Ring, 0, len(vertices)+1)
+	for _, v := range vertices {
+		ring = append(ring, orb.Point{float64(v.Longitude), float64(v.Latitude)})
+	}
+
+	return orb.Polygon{append(ring, ring[0])}
+}
+
+// Neighbours returns the 6 cells adjacent to the cell.
+func (c HexCell) Neighbours() []HexCell {
+	_, columns := hexSize(c.Resolution)
+	dirs := [6][2]int{{1, 0}, {1, -1}, {0, -1}, {-1, 0}, {-1, 1}, {0, 1}}
+
+	cells := make([]HexCell, 6)
+	for i, d := range dirs {
+		cells[i] = HexCell{Resolution: c.Resolution, Q: c.Q + d[0], R: c.R + d[1]}.normalise(columns)
+	}
+
+	return cells
+}
+
+// hex ID bit layout: resolution (5 bits), row (29 bits, biased), column (30 bits, biased)
+const (
+	hexRowBits = 29
+	hexColBits = 30
+)
+
+// ID returns the cell as a single integer, suitable as a map or database key.
+func (c HexCell) ID() uint64 {
+	col := c.Q + (c.R-(c.R&1))/2
+	row := uint64(c.R+1<<(hexRowBits-1)) & (1<<hexRowBits - 1)
+	column := uint64(col+1<<(hexColBits-1)) & (1<<hexColBits - 1)
+
+	return uint64(c.Resolution)<<(hexRowBits+hexColBits) | row<<hexColBits | column
+}
+
+// HexCellFromID returns the cell with the given ID.
+func HexCellFromID(id uint64) HexCell {
+	res := int(id >> (hexRowBits + hexColBits))
+	row := int((id>>hexColBits)&(1<<hexRowBits-1)) - 1<<(hexRowBits-1)
+	col := int(id&(1<<hexColBits-1)) - 1<<(hexColBits-1)
+
+	return HexCell{Resolution: res, Q: col - (row-(row&1))/2, R: row}
+}
+
+// String returns the cell as "resolution/q/r".
+func (c HexCell) String() string {
+	return fmt.Sprintf("%d/%d/%d", c.Resolution, c.Q, c.R)
+}
@@ -0,0 +1,84 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// projectedDistance returns the distance from (x, y) to the centre of the cell in the projection, the short way
+// around the antimeridian
+func projectedDistance(x, y float64, c HexCell) float64 {
+	cx, cy := c.projectedCentre()
+	dx := math.Mod(math.Abs(x-cx), 2*math.Pi)
+
+	return math.Hypot(math.Min(dx, 2*math.Pi-dx), y-cy)
+}
+
+func TestHexCellAt(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < 1000; i++ {
+		ll := NewLatLon(rng.Float64()*180-90, rng.Float64()*360-180)
+		res := rng.Intn(MaxHexResolution + 1)
+		c := HexCellAt(ll, res)
+		require.Equal(t, res, c.Resolution)
+
+		// the centre of the cell is the closest in the projection
+		x := float64(ll.Longitude.Radians())
+		y := math.Sin(ll.Latitude.Radians())
+		d := projectedDistance(x, y, c)
+		for _, n := range c.Neighbours() {
+			assert.LessOrEqual(t, d, projectedDistance(x, y, n)+1e-12)
+		}
+
+		assert.Equal(t, c, HexCellFromID(c.ID()))
+	}
+
+	// same cell on both sides of the antimeridian
+	assert.Equal(t, HexCellAt(NewLatLon(10, 180), 5), HexCellAt(NewLatLon(10, -180), 5))
+	c := HexCellAt(NewLatLon(0, 180), 0)
+	assert.InDelta(t, 180, math.Abs(float64(c.Centre().Longitude)), 1e-9)
+
+	// neighbours wrap
+	c = HexCellAt(NewLatLon(0, 179.9), 3)
+	east := c.Neighbours()[0]
+	assert.Less(t, float64(east.Centre().Longitude), 0.0)
+
+	assert.Equal(t, "0/0/0", HexCellAt(NewLatLon(0, 0), 0).String())
+	assert.Panics(t, func() { HexCellAt(NewLatLon(0, 0), MaxHexResolution+1) })
+}
+
+func TestHexCellBoundary(t *testing.T) {
+	c := HexCellAt(NewLatLon(-36.85, 174.76), 10)
+	centre := c.Centre()
+	vertices := c.Boundary()
+	require.Len(t, vertices, 6)
+
+	// around 3.3km wide at the equator, less in latitude and more in longitude further from it
+	for _, v := range vertices {
+		d := SphericalModel(centre).DistanceTo(v).Km()
+		assert.Greater(t, float64(d), 1.0)
+		assert.Less(t, float64(d), 3.0)
+	}
+
+	p := c.Polygon()
+	require.Len(t, p, 1)
+	require.Len(t, p[0], 7)
+	assert.Equal(t, p[0][0], p[0][6])
+
+	// vertices beyond the poles
+	for _, v := range HexCellAt(NewLatLon(90, 0), 0).Boundary() {
+		assert.True(t, v.Valid())
+		assert.LessOrEqual(t, float64(v.Latitude), 90.0)
+	}
+}
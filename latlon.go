@@ -9,9 +9,12 @@ package geod
  */
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"strings"
+
+	"github.com/starboard-nz/units"
 )
 
 // Degrees angle
@@ -76,10 +79,70 @@ func (ll LatLon) Equals(other LatLon) bool {
 	return true
 }
 
+// EqualsWithin returns true if `ll` and `other` are no more than `tolerance` apart, measured using `model`.
+// `modelArgs` are passed to `model`, as for `Distance`. Invalid points are never equal.
+//
+// Example:
+// dup := p1.EqualsWithin(p2, units.Metre(5), geod.SphericalModel)
+func (ll LatLon) EqualsWithin(other LatLon, tolerance units.Distance, model EarthModel, modelArgs ...interface{}) bool {
+	if !ll.Valid() || !other.Valid() {
+		return false
+	}
+
+	// some models return an invalid distance for identical points
+	if ll.Equals(other) {
+		return true
+	}
+
+	d := model(ll, modelArgs...).DistanceTo(other)
+	if !d.Valid() {
+		return false
+	}
+
+	return d.Metre() <= tolerance.Metre()
+}
+
+// Round returns the point with Latitude and Longitude rounded to `decimals` decimal places.
+func (ll LatLon) Round(decimals int) LatLon {
+	return LatLon{
+		Latitude:  Degrees(ll.Latitude.RoundTo(decimals)),
+		Longitude: Degrees(ll.Longitude.RoundTo(decimals)),
+	}
+}
+
+// maxRoundDecimals is the number of decimal places beyond which rounding a coordinate in degrees has no effect
+// on a float64.
+const maxRoundDecimals = 15
+
+// DecimalsWithin returns the smallest number of decimal places the point can be rounded to while staying within
+// `tolerance` of the original position. Distances are measured on a sphere.
+func (ll LatLon) DecimalsWithin(tolerance units.Distance) int {
+	tol := float64(tolerance.Metre())
+	for n := 0; n < maxRoundDecimals; n++ {
+		d := LatLonSpherical{ll: ll}.DistanceTo(ll.Round(n))
+		if float64(d.Metre()) <= tol {
+			return n
+		}
+	}
+
+	return maxRoundDecimals
+}
+
+// RoundWithin returns the point rounded to the fewest decimal places that keep it within `tolerance` of the
+// original position, e.g. RoundWithin(units.Metre(0.01)) for 1cm. Useful to keep serialised output compact.
+func (ll LatLon) RoundWithin(tolerance units.Distance) LatLon {
+	if !ll.Valid() {
+		return ll
+	}
+
+	return ll.Round(ll.DecimalsWithin(tolerance))
+}
+
 // ParseLatLon parses a latitude/longitude point from a variety of formats.
 //
 // Latitude & longitude (in degrees) can be supplied as two separate string parameters or
-// as a single comma-separated lat/lon string
+// as a single comma-separated lat/lon string. If the string contains a semicolon, it separates the latitude and
+// longitude instead, as formatted by FormatLatLon with WithDecimalComma, and the values may use decimal commas.
 //
 // The latitude/longitude values may be signed decimal or deg-min-sec (hexagesimal) suffixed by compass direction (NSEW)
 // a variety of separators are accepted. Examples: -3.62, '3 37 12W', '3°37′12″W'.
@@ -87,94 +150,103 @@ func (ll LatLon) Equals(other LatLon) bool {
 // Thousands/decimal separators must be comma/dot
 //
 // Arguments:
-// lat|latlon - Latitude (in degrees), or comma-separated lat/lon
+// lat|latlon - Latitude (in degrees), or comma- or semicolon-separated lat/lon
 // [lon]      - Longitude (in degrees).
 //
-// Returns Latitude/longitude point on WGS84 (LatLon)
+// Returns Latitude/longitude point on WGS84 (LatLon). Errors are returned as *ParseError.
 //
 // Example:
 // p1 := ParseLatLon(51.47788, -0.00147)         // numeric pair
 // p2 := ParseLatLon("51.47788", "-0.00147")     // string pair
 // p3 := ParseLatLon("51°28′40″N, 000°00′05″W")   // single dms string
 // p4 := ParseLatLon("51°28′40″N", "000°00′05″W") // dms lat string, dms lon string
+// p5 := ParseLatLon("51,4779°N; 000,0015°W")     // decimal commas
 func ParseLatLon(args ...interface{}) (LatLon, error) {
 	if len(args) == 0 {
-		return LatLon{}, fmt.Errorf("Invalid (empty) point")
+		return LatLon{}, parseError(ErrEmptyInput, "", "")
 	}
 
 	// split the arguments into lat, lon
-	var args2 []interface{}
+	var (
+		args2  []interface{}
+		input  string
+		tokens []string
+	)
 	if len(args) == 1 {
 		// single string of "lat, lon"
 		s, ok := args[0].(string)
 		if !ok {
-			return LatLon{}, fmt.Errorf("Invalid argument type: %T", args[0])
+			return LatLon{}, &ParseError{Err: ErrInvalidArgument, Token: 0, Pos: -1, Detail: fmt.Sprintf("type %T", args[0])}
 		}
-		tokens := strings.Split(s, ",")
+		if strings.TrimSpace(s) == "" {
+			return LatLon{}, parseError(ErrEmptyInput, s, "")
+		}
+		input = s
+		separator := ","
+		if strings.Contains(s, ";") {
+			separator = ";"
+		}
+		tokens = strings.Split(s, separator)
 		if len(tokens) > 2 {
-			return LatLon{}, fmt.Errorf("Failed to parse argument: too many items")
+			return LatLon{}, tokenError(ErrTooManyTokens, input, tokens, 2, "")
 		}
 		if len(tokens) == 1 {
-			return LatLon{}, fmt.Errorf("Failed to parse argument: latitude and longitude are required")
+			return LatLon{}, parseError(ErrInvalidLongitude, input, "latitude and longitude are required")
 		}
 		args2 = []interface{}{tokens[0], tokens[1]}
 	} else if len(args) == 2 {
 		args2 = args
 	} else {
-		return LatLon{}, fmt.Errorf("Too many arguments")
+		return LatLon{}, &ParseError{Err: ErrTooManyTokens, Token: 2, Pos: -1, Detail: fmt.Sprintf("%d arguments", len(args))}
 	}
-	var lat, lon Degrees
 
-	// we now have 2 values in args2: lat, lon
-	switch v := args2[0].(type) {
-	case string:
-		lat0, err := ParseDMS(v)
-		if err != nil {
-			return LatLon{}, fmt.Errorf("Failed to parse latitude: %v", err)
-		}
-		lat = lat0
-	case float64:
-		if math.IsNaN(v) {
-			return LatLon{}, fmt.Errorf("Latitude cannot be NaN")
-		}
-		lat = Degrees(v)
-	case float32:
-		if math.IsNaN(float64(v)) {
-			return LatLon{}, fmt.Errorf("Latitude cannot be NaN")
-		}
-		lat = Degrees(v)
-	case Degrees:
-		lat = v
-	default:
-		return LatLon{}, fmt.Errorf("Invalid type for latitude: %T", v)
+	lat, err := parseCoordinate(args2[0], ErrInvalidLatitude, input, tokens, 0)
+	if err != nil {
+		return LatLon{}, err
 	}
 
-	lat = Wrap90(lat)
+	lon, err := parseCoordinate(args2[1], ErrInvalidLongitude, input, tokens, 1)
+	if err != nil {
+		return LatLon{}, err
+	}
+
+	return LatLon{Latitude: Wrap90(lat), Longitude: Wrap180(lon)}, nil
+}
 
-	switch v := args2[1].(type) {
+// parseCoordinate parses a latitude or longitude, which is the `token`-th item of the input. `input` and `tokens`
+// are only set if the value was split from a single string. Errors are reported as `kind`.
+func parseCoordinate(arg interface{}, kind error, input string, tokens []string, token int) (Degrees, error) {
+	switch v := arg.(type) {
 	case string:
-		lon0, err := ParseDMS(v)
+		deg, err := ParseDMS(v)
 		if err != nil {
-			return LatLon{}, fmt.Errorf("Failed to parse longitude: %v", err)
+			detail := err.Error()
+			var pe *ParseError
+			if errors.As(err, &pe) {
+				// the input string is reported by the outer error
+				pe.Input = ""
+				pe.Pos = -1
+				detail = pe.Error()
+			}
+			if input == "" {
+				return 0, &ParseError{Err: kind, Input: v, Token: token, Pos: -1, Detail: detail}
+			}
+			return 0, tokenError(kind, input, tokens, token, detail)
 		}
-		lon = lon0
+		return deg, nil
 	case float64:
 		if math.IsNaN(v) {
-			return LatLon{}, fmt.Errorf("Longitude cannot be NaN")
+			return 0, &ParseError{Err: kind, Token: token, Pos: -1, Detail: "NaN"}
 		}
-		lon = Degrees(v)
+		return Degrees(v), nil
 	case float32:
 		if math.IsNaN(float64(v)) {
-			return LatLon{}, fmt.Errorf("Longitude cannot be NaN")
+			return 0, &ParseError{Err: kind, Token: token, Pos: -1, Detail: "NaN"}
 		}
-		lon = Degrees(v)
+		return Degrees(v), nil
 	case Degrees:
-		lon = v
+		return v, nil
 	default:
-		return LatLon{}, fmt.Errorf("Invalid type for longitude: %T", v)
+		return 0, &ParseError{Err: kind, Token: token, Pos: -1, Detail: fmt.Sprintf("invalid type %T", v)}
 	}
-
-	lon = Wrap180(lon)
-
-	return LatLon{Latitude: lat, Longitude: lon}, nil
 }
@@ -9,6 +9,7 @@ package geod
  */
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"strconv"
@@ -69,32 +70,85 @@ func NewLatLonEllipsodial(latitude, longitude Degrees, height float64) LatLonEll
 // [lon]      - Longitude (in degrees).
 // [height]   - Height above ellipsoid in metres.
 //
-// Returns Latitude/longitude point on WGS84 ellipsoidal model earth (LatLonEllipsoidal)
+// The first string may start with the datum of the coordinates, as a name or EPSG code followed by a colon or space
+// (see LookupDatum), e.g. "OSGB36: 51°28′N, 000°00′W" or "EPSG:4277 51.4774, 0.0001", in which case the point is
+// converted to WGS84. ErrUnknownDatum is returned for datums that aren't supported.
+//
+// Returns Latitude/longitude point on WGS84 ellipsoidal model earth (LatLonEllipsoidal).
+// Errors are returned as *ParseError.
 //
 // Example:
 // p1 := ParseLatLon(51.47788, -0.00147)         // numeric pair
 // p2 := ParseLatLon("51.47788", "-0.00147")         // string pair
 // p3 := ParseLatLon("51°28′40″N, 000°00′05″W", 17)   // dms string + height
 // p4 := ParseLatLon("51°28′40″N", "000°00′05″W", 17) // dms lat, dms lon, height
+// p5 := ParseLatLon("OSGB36: 51.47737, 0.00014")     // converted to WGS84: 51.4779°N, 000.0015°W
 func ParseLatLonEllipsoidal(args ...interface{}) (LatLonEllipsoidal, error) {
 	if len(args) == 0 {
-		return LatLonEllipsoidal{}, fmt.Errorf("Invalid (empty) point")
+		return LatLonEllipsoidal{}, parseError(ErrEmptyInput, "", "")
+	}
+
+	s, ok := args[0].(string)
+	if !ok {
+		return parseLatLonEllipsoidal(args...)
+	}
+
+	prefix, rest := splitDatumPrefix(s)
+	if prefix == "" {
+		return parseLatLonEllipsoidal(args...)
+	}
+
+	datum, ok := LookupDatum(prefix)
+	if !ok {
+		return LatLonEllipsoidal{}, &ParseError{Err: ErrUnknownDatum, Input: s, Token: 0, Pos: strings.Index(s, prefix), Detail: prefix}
+	}
+
+	args = append([]interface{}{rest}, args[1:]...)
+	ll, err := parseLatLonEllipsoidal(args...)
+	if err != nil {
+		// report the error in the whole input
+		var pe *ParseError
+		if errors.As(err, &pe) && pe.Input == rest {
+			pe.Input = s
+			if pe.Pos >= 0 {
+				pe.Pos += len(s) - len(rest)
+			}
+		}
+
+		return LatLonEllipsoidal{}, err
+	}
+
+	return datum.ToWGS84(ll), nil
+}
+
+// parseLatLonEllipsoidal implements ParseLatLonEllipsoidal for coordinates without a datum
+func parseLatLonEllipsoidal(args ...interface{}) (LatLonEllipsoidal, error) {
+	if len(args) == 0 {
+		return LatLonEllipsoidal{}, parseError(ErrEmptyInput, "", "")
 	}
 
 	// split the arguments into lat, lon, height
-	var args3 []interface{}
+	var (
+		args3  []interface{}
+		input  string
+		tokens []string
+	)
 	if len(args) == 1 {
 		// single string of "lat, lon[, height]"
 		s, ok := args[0].(string)
 		if !ok {
-			return LatLonEllipsoidal{}, fmt.Errorf("Invalid argument type: %T", args[0])
+			return LatLonEllipsoidal{}, &ParseError{Err: ErrInvalidArgument, Token: 0, Pos: -1, Detail: fmt.Sprintf("type %T", args[0])}
+		}
+		if strings.TrimSpace(s) == "" {
+			return LatLonEllipsoidal{}, parseError(ErrEmptyInput, s, "")
 		}
-		tokens := strings.Split(s, ",")
+		input = s
+		tokens = strings.Split(s, ",")
 		if len(tokens) > 3 {
-			return LatLonEllipsoidal{}, fmt.Errorf("Failed to parse argument: too many items")
+			return LatLonEllipsoidal{}, tokenError(ErrTooManyTokens, input, tokens, 3, "")
 		}
 		if len(tokens) == 1 {
-			return LatLonEllipsoidal{}, fmt.Errorf("Failed to parse argument: at least latitude and longitude are required")
+			return LatLonEllipsoidal{}, parseError(ErrInvalidLongitude, input, "at least latitude and longitude are required")
 		}
 		if len(tokens) == 3 {
 			args3 = []interface{}{tokens[0], tokens[1], tokens[2]}
@@ -108,99 +162,63 @@ func ParseLatLonEllipsoidal(args ...interface{}) (LatLonEllipsoidal, error) {
 			// not a string, so must be lat + lon
 			args3 = []interface{}{args[0], args[1], 0.0}
 		} else {
-			tokens := strings.Split(s, ",")
-			if len(tokens) > 2 {
-				return LatLonEllipsoidal{}, fmt.Errorf("Failed to parse argument: too many items")
+			splitTokens := strings.Split(s, ",")
+			if len(splitTokens) > 2 {
+				return LatLonEllipsoidal{}, tokenError(ErrTooManyTokens, s, splitTokens, 2, "")
 			}
-			if len(tokens) == 1 {
+			if len(splitTokens) == 1 {
 				// lat + lon
 				args3 = append(args, 0.0)
-			} else if len(tokens) == 2 {
+			} else if len(splitTokens) == 2 {
 				// lat/lon + height
+				input = s
+				tokens = splitTokens
 				args3 = []interface{}{tokens[0], tokens[1], args[1]}
 			}
 		}
 	} else if len(args) == 3 {
 		args3 = args
 	} else {
-		return LatLonEllipsoidal{}, fmt.Errorf("Too many arguments")
+		return LatLonEllipsoidal{}, &ParseError{Err: ErrTooManyTokens, Token: 3, Pos: -1, Detail: fmt.Sprintf("%d arguments", len(args))}
 	}
-	var lat, lon Degrees
-	var height float64
 
 	// we now have 3 values in args3: lat, lon, height
-	switch v := args3[0].(type) {
-	case string:
-		lat0, err := ParseDMS(v)
-		if err != nil {
-			return LatLonEllipsoidal{}, fmt.Errorf("Failed to parse latitude: %v", err)
-		}
-		lat = lat0
-	case float64:
-		if math.IsNaN(v) {
-			return LatLonEllipsoidal{}, fmt.Errorf("Latitude cannot be NaN")
-		}
-		lat = Degrees(v)
-	case float32:
-		if math.IsNaN(float64(v)) {
-			return LatLonEllipsoidal{}, fmt.Errorf("Latitude cannot be NaN")
-		}
-		lat = Degrees(v)
-	case Degrees:
-		lat = v
-	default:
-		return LatLonEllipsoidal{}, fmt.Errorf("Invalid type for latitude: %T", v)
+	lat, err := parseCoordinate(args3[0], ErrInvalidLatitude, input, tokens, 0)
+	if err != nil {
+		return LatLonEllipsoidal{}, err
 	}
 
-	lat = Wrap90(lat)
-
-	switch v := args3[1].(type) {
-	case string:
-		lon0, err := ParseDMS(v)
-		if err != nil {
-			return LatLonEllipsoidal{}, fmt.Errorf("Failed to parse longitude: %v", err)
-		}
-		lon = lon0
-	case float64:
-		if math.IsNaN(v) {
-			return LatLonEllipsoidal{}, fmt.Errorf("Longitude cannot be NaN")
-		}
-		lon = Degrees(v)
-	case float32:
-		if math.IsNaN(float64(v)) {
-			return LatLonEllipsoidal{}, fmt.Errorf("Longitude cannot be NaN")
-		}
-		lon = Degrees(v)
-	case Degrees:
-		lon = v
-	default:
-		return LatLonEllipsoidal{}, fmt.Errorf("Invalid type for longitude: %T", v)
+	lon, err := parseCoordinate(args3[1], ErrInvalidLongitude, input, tokens, 1)
+	if err != nil {
+		return LatLonEllipsoidal{}, err
 	}
 
-	lon = Wrap180(lon)
-
+	var height float64
 	switch v := args3[2].(type) {
 	case string:
 		var err error
-		if height, err = strconv.ParseFloat(v, 64); err != nil {
-			return LatLonEllipsoidal{}, fmt.Errorf("Failed to parse height: %v", err)
+		if height, err = strconv.ParseFloat(strings.TrimSpace(v), 64); err != nil {
+			if len(tokens) == 3 {
+				return LatLonEllipsoidal{}, tokenError(ErrInvalidHeight, input, tokens, 2, err.Error())
+			}
+			return LatLonEllipsoidal{}, &ParseError{Err: ErrInvalidHeight, Input: v, Token: 2, Pos: -1, Detail: err.Error()}
 		}
 	case float64:
 		if math.IsNaN(v) {
-			return LatLonEllipsoidal{}, fmt.Errorf("Height cannot be NaN")
+			return LatLonEllipsoidal{}, &ParseError{Err: ErrInvalidHeight, Token: 2, Pos: -1, Detail: "NaN"}
 		}
 		height = v
 	case float32:
 		if math.IsNaN(float64(v)) {
-			return LatLonEllipsoidal{}, fmt.Errorf("Height cannot be NaN")
+			return LatLonEllipsoidal{}, &ParseError{Err: ErrInvalidHeight, Token: 2, Pos: -1, Detail: "NaN"}
 		}
 		height = float64(v)
 	}
 
 	return LatLonEllipsoidal{
 		LatLon: LatLon{
-			Latitude:  lat,
-			Longitude: lon,
+			Latitude:  Wrap90(lat),
+			Longitude: Wrap180(lon),
 		},
 		Height:    height,
 		ellipsoid: WGS84(),
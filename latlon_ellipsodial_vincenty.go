@@ -22,34 +22,73 @@ import (
  */
 
 import (
+	"fmt"
 	"math"
-	"sync"
 )
 
 // LatLonEllipsoidalVincenty represents a point used for calculations using a the Vincenty method, on an
 // ellipsoidal Earth model.
 type LatLonEllipsoidalVincenty struct {
-	ll        LatLon
-	ellipsoid Ellipsoid
+	ll            LatLon
+	ellipsoid     Ellipsoid
+	precision     Precision
+	tolerance     Tolerance
+	maxIterations MaxIterations
 }
 
+// Precision selects the precision of iterative calculations, it can be passed to VincentyModel as a model argument.
+type Precision int
+
+const (
+	// StandardPrecision is the default, accurate to within a fraction of a millimetre.
+	StandardPrecision Precision = iota
+	// HighPrecision iterates to the limit of float64 precision and uses the more accurate form of the series
+	// coefficients (Vincenty, 1976), so that round trips between the direct and inverse solutions are stable to
+	// a few nanometres (within 20nm for distances up to 15000km). It is somewhat slower than StandardPrecision.
+	HighPrecision
+)
+
+// Tolerance is the convergence limit of iterative calculations, in radians, it can be passed to VincentyModel as a
+// model argument to override the tolerance of the `Precision`, for example to trade accuracy for speed.
+type Tolerance float64
+
+// MaxIterations is the maximum number of iterations of iterative calculations, it can be passed to VincentyModel as
+// a model argument. Calculations that don't converge within the limit fail, as for nearly antipodal points.
+type MaxIterations int
+
 // VincentyModel returns a `Model` that wraps geodesy calculations using the Vincenty method on an ellipsoidal Earth model
+//
+// Accepted model arguments are the `Ellipsoid` (default: WGS84), the `Precision` (default: StandardPrecision), the
+// `Tolerance` (default: set by the precision) and the `MaxIterations` (default: 100 for direct and 1000 for inverse
+// calculations), in any order.
 func VincentyModel(ll LatLon, modelArgs ...interface{}) Model {
-	ellipsoid := WGS84()
-	if len(modelArgs) != 0 {
-		if len(modelArgs) > 1 {
-			panic("Invalid number of arguments in call to VincentyModel()")
-		}
-		switch v := modelArgs[0].(type) {
+	llv := LatLonEllipsoidalVincenty{ll: ll, ellipsoid: WGS84()}
+	if len(modelArgs) > 4 {
+		panic(modelArgsError("VincentyModel", "too many arguments"))
+	}
+	for _, arg := range modelArgs {
+		switch v := arg.(type) {
 		case Ellipsoid:
-			ellipsoid = v
+			llv.ellipsoid = v
 		case func() Ellipsoid:
-			ellipsoid = v()
+			llv.ellipsoid = v()
+		case Precision:
+			llv.precision = v
+		case Tolerance:
+			if !(v > 0) {
+				panic(modelArgsError("VincentyModel", fmt.Sprintf("invalid tolerance %v", float64(v))))
+			}
+			llv.tolerance = v
+		case MaxIterations:
+			if v < 1 {
+				panic(modelArgsError("VincentyModel", fmt.Sprintf("invalid maximum iterations %d", int(v))))
+			}
+			llv.maxIterations = v
 		default:
-			panic("Invalid argument type in call to VincentyModel()")
+			panic(modelArgsError("VincentyModel", fmt.Sprintf("unexpected argument type %T", arg)))
 		}
 	}
-	return LatLonEllipsoidalVincenty{ll: ll, ellipsoid: ellipsoid}
+	return llv
 }
 
 // LatLon converts LatLonEllipsoidalVincenty to LatLon
@@ -68,6 +107,49 @@ func NewLatLonEllipsodialVincenty(latitude, longitude float64, ellipsoid Ellipso
 	}
 }
 
+// WithPrecision returns a copy of `llv` that uses the given precision for calculations.
+func (llv LatLonEllipsoidalVincenty) WithPrecision(precision Precision) LatLonEllipsoidalVincenty {
+	llv.precision = precision
+	return llv
+}
+
+// convergenceTolerance returns the convergence limit of the iterations, in radians
+func (llv LatLonEllipsoidalVincenty) convergenceTolerance() float64 {
+	if llv.tolerance > 0 {
+		return float64(llv.tolerance)
+	}
+	if llv.precision == HighPrecision {
+		return 1e-15
+	}
+
+	return 1e-12
+}
+
+// iterationLimit returns the maximum number of iterations, `defaultLimit` unless set with MaxIterations
+func (llv LatLonEllipsoidalVincenty) iterationLimit(defaultLimit int) int {
+	if llv.maxIterations > 0 {
+		return int(llv.maxIterations)
+	}
+
+	return defaultLimit
+}
+
+// seriesAB returns Vincenty's A and B coefficients for the given u²
+func (llv LatLonEllipsoidalVincenty) seriesAB(uSq float64) (float64, float64) {
+	if llv.precision == HighPrecision {
+		// Vincenty's 1976 modification, using k₁ = (√(1+u²)−1)/(√(1+u²)+1)
+		sq := math.Sqrt(1 + uSq)
+		k1 := (sq - 1) / (sq + 1)
+		A := (1 + k1*k1/4) / (1 - k1)
+		B := k1 * (1 - 3*k1*k1/8)
+		return A, B
+	}
+
+	A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
+	B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	return A, B
+}
+
 // VincentyDirect - Vincenty direct calculation - calculates the destination point and final bearing given the
 // starting point, distance and initial bearing.
 //
@@ -98,8 +180,7 @@ func (llv LatLonEllipsoidalVincenty) VincentyDirect(distance float64, initialBea
 	sinα := cosU1 * sinα1          // α = azimuth of the geodesic at the equator
 	cosSqα := 1 - sinα*sinα
 	uSq := cosSqα * (a*a - b*b) / (b * b)
-	A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
-	B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	A, B := llv.seriesAB(uSq)
 
 	σ := s / (b * A)
 
@@ -108,6 +189,8 @@ func (llv LatLonEllipsoidalVincenty) VincentyDirect(distance float64, initialBea
 	var cos2σₘ float64 // σₘ = angular distance on the sphere from the equator to the midpoint of the line
 
 	var σʹ float64
+	tolerance := llv.convergenceTolerance()
+	converged := false
 	iterations := 0
 	for {
 		cos2σₘ = math.Cos(2*σ1 + σ)
@@ -118,11 +201,17 @@ func (llv LatLonEllipsoidalVincenty) VincentyDirect(distance float64, initialBea
 		σʹ = σ
 		σ = s/(b*A) + Δσ
 		iterations++
-		if math.Abs(σ-σʹ) <= 1e-12 || iterations >= 100 {
+		if math.Abs(σ-σʹ) <= tolerance {
+			converged = true
+			break
+		}
+		if iterations >= llv.iterationLimit(100) {
+			// the high precision tolerance may not be reachable due to rounding
+			converged = math.Abs(σ-σʹ) <= 1e-12
 			break
 		}
 	}
-	if iterations >= 100 {
+	if !converged {
 		// algorithm failed to converge
 		return LatLon{Latitude: Degrees(math.NaN()), Longitude: Degrees(math.NaN())}, Degrees(math.NaN())
 	}
@@ -145,14 +234,39 @@ func (llv LatLonEllipsoidalVincenty) VincentyDirect(distance float64, initialBea
 // VincentyInverse - Vincenty inverse calculation.  Calculates the distance, initial and final bearing going
 // from point `llv` to `dest`, using the Vincenty method.
 //
+// For nearly antipodal points, where the Vincenty method fails to converge, the result is calculated using
+// a slower fallback method with reduced precision. Use VincentyInverseChecked to find out whether this happened.
+//
 // Arguments:
 //
 // dest - destination point
 //
 // Returns (distance from `llv` to `dest`, initial bearing in degrees from North, final bearing in degrees from North)
 func (llv LatLonEllipsoidalVincenty) VincentyInverse(dest LatLon) (units.Distance, Degrees, Degrees) {
+	distance, initialBearing, finalBearing, _ := llv.VincentyInverseChecked(dest)
+	return distance, initialBearing, finalBearing
+}
+
+// VincentyInverseChecked is the same as VincentyInverse, but it also returns ErrReducedPrecision if the Vincenty
+// method failed to converge and the result was calculated using the fallback method.
+//
+// Returns (distance, initial bearing, final bearing, error)
+func (llv LatLonEllipsoidalVincenty) VincentyInverseChecked(dest LatLon) (units.Distance, Degrees, Degrees, error) {
+	distance, initialBearing, finalBearing, ok := llv.vincentyInverse(dest)
+	if ok {
+		return distance, initialBearing, finalBearing, nil
+	}
+
+	distance, initialBearing, finalBearing = llv.vincentyInverseFallback(dest)
+
+	return distance, initialBearing, finalBearing, ErrReducedPrecision
+}
+
+// vincentyInverse returns false if the iteration failed to converge
+func (llv LatLonEllipsoidalVincenty) vincentyInverse(dest LatLon) (units.Distance, Degrees, Degrees, bool) {
+	// the distance between identical points is 0, but the bearings are undefined
 	if llv.ll.Equals(dest) {
-		return units.Metre(math.NaN()), Degrees(math.NaN()), Degrees(math.NaN())
+		return units.Metre(0), Degrees(math.NaN()), Degrees(math.NaN()), true
 	}
 
 	const π = math.Pi
@@ -193,6 +307,8 @@ func (llv LatLonEllipsoidalVincenty) VincentyInverse(dest LatLon) (units.Distanc
 	cosSqα := 1.0
 
 	var C, λʹ, iterationCheck float64
+	tolerance := llv.convergenceTolerance()
+	converged := false
 	iterations := 0
 	for {
 		sinλ = math.Sin(λ)
@@ -200,6 +316,7 @@ func (llv LatLonEllipsoidalVincenty) VincentyInverse(dest LatLon) (units.Distanc
 		sinSqσ = (cosU2*sinλ)*(cosU2*sinλ) + (cosU1*sinU2-sinU1*cosU2*cosλ)*
 			(cosU1*sinU2-sinU1*cosU2*cosλ)
 		if math.Abs(sinSqσ) < ε {
+			converged = true
 			break // co-incident/antipodal points (falls back on λ/σ = L)
 		}
 		sinσ = math.Sqrt(sinSqσ)
@@ -221,21 +338,26 @@ func (llv LatLonEllipsoidalVincenty) VincentyInverse(dest LatLon) (units.Distanc
 			iterationCheck = math.Abs(λ)
 		}
 		if iterationCheck > π {
-			return units.Metre(math.NaN()), Degrees(math.NaN()), Degrees(math.NaN())
+			return units.Metre(math.NaN()), Degrees(math.NaN()), Degrees(math.NaN()), false
 		}
 		iterations++
-		if math.Abs(λ-λʹ) <= 1e-12 || iterations >= 1000 {
+		if math.Abs(λ-λʹ) <= tolerance {
+			converged = true
+			break
+		}
+		if iterations >= llv.iterationLimit(1000) {
+			// the high precision tolerance may not be reachable due to rounding
+			converged = math.Abs(λ-λʹ) <= 1e-12
 			break
 		}
 	}
 
-	if iterations >= 1000 {
-		return units.Metre(math.NaN()), Degrees(math.NaN()), Degrees(math.NaN())
+	if !converged {
+		return units.Metre(math.NaN()), Degrees(math.NaN()), Degrees(math.NaN()), false
 	}
 
 	uSq := cosSqα * (a*a - b*b) / (b * b)
-	A := 1 + uSq/16384*(4096+uSq*(-768+uSq*(320-175*uSq)))
-	B := uSq / 1024 * (256 + uSq*(-128+uSq*(74-47*uSq)))
+	A, B := llv.seriesAB(uSq)
 	Δσ := B * sinσ * (cos2σₘ + B/4*(cosσ*(-1+2*cos2σₘ*cos2σₘ)-
 		B/6*cos2σₘ*(-3+4*sinσ*sinσ)*(-3+4*cos2σₘ*cos2σₘ)))
 
@@ -261,7 +383,85 @@ func (llv LatLonEllipsoidalVincenty) VincentyInverse(dest LatLon) (units.Distanc
 	if math.Abs(s) >= ε {
 		finalBearing = Wrap360(DegreesFromRadians(α2))
 	}
-	return units.Metre(s), initialBearing, finalBearing
+	return units.Metre(s), initialBearing, finalBearing, true
+}
+
+// vincentyInverseFallback solves the inverse problem for nearly antipodal points, where the Vincenty iteration fails
+// to converge.
+//
+// The geodesic is split at a waypoint roughly half way along it: for a trial initial bearing the waypoint is found
+// using the direct solution, and the remaining distance to `dest` using the inverse solution, which converges as the
+// waypoint is nowhere near antipodal to `dest`. The total distance is never shorter than the geodesic and it's equal
+// to it when the waypoint is on the geodesic, so the initial bearing is found by minimising the total distance:
+// a coarse scan of all bearings followed by golden-section search around the best one.
+func (llv LatLonEllipsoidalVincenty) vincentyInverseFallback(dest LatLon) (units.Distance, Degrees, Degrees) {
+	nan := units.Metre(math.NaN())
+
+	// half of the distance on a sphere of mean radius, good enough for placing the waypoint
+	a := llv.ellipsoid.a
+	b := llv.ellipsoid.b
+	δ := LatLonSpherical{ll: llv.ll}.DistanceTo(dest).Metre() / units.Metre(earthRadius)
+	d1 := float64(δ) * (2*a + b) / 3 / 2
+
+	total := func(bearing Degrees) (float64, Degrees) {
+		waypoint, _ := llv.VincentyDirect(d1, bearing)
+		if !waypoint.Valid() {
+			return math.NaN(), Degrees(math.NaN())
+		}
+
+		llw := llv
+		llw.ll = waypoint
+		d2, _, finalBearing, ok := llw.vincentyInverse(dest)
+		if !ok {
+			return math.NaN(), Degrees(math.NaN())
+		}
+
+		return d1 + float64(d2.Metre()), finalBearing
+	}
+
+	bestBearing := Degrees(math.NaN())
+	bestDist := math.Inf(1)
+	for bearing := Degrees(0); bearing < 360; bearing++ {
+		d, _ := total(bearing)
+		if d < bestDist {
+			bestDist = d
+			bestBearing = bearing
+		}
+	}
+
+	if !bestBearing.Valid() {
+		return nan, Degrees(math.NaN()), Degrees(math.NaN())
+	}
+
+	// golden-section search for the minimum within ±1° of the best bearing
+	invφ := (math.Sqrt(5) - 1) / 2
+	lo := bestBearing - 1
+	hi := bestBearing + 1
+	x1 := hi - Degrees(invφ)*(hi-lo)
+	x2 := lo + Degrees(invφ)*(hi-lo)
+	f1, _ := total(x1)
+	f2, _ := total(x2)
+	for hi-lo > 1e-10 {
+		// treat failed evaluations as very long distances
+		if f1 < f2 || math.IsNaN(f2) {
+			hi, x2, f2 = x2, x1, f1
+			x1 = hi - Degrees(invφ)*(hi-lo)
+			f1, _ = total(x1)
+		} else {
+			lo, x1, f1 = x1, x2, f2
+			x2 = lo + Degrees(invφ)*(hi-lo)
+			f2, _ = total(x2)
+		}
+	}
+
+	initialBearing := (lo + hi) / 2
+	distance, finalBearing := total(initialBearing)
+	if math.IsNaN(distance) || distance > bestDist {
+		initialBearing = bestBearing
+		distance, finalBearing = total(bestBearing)
+	}
+
+	return units.Metre(distance), Wrap360(initialBearing), finalBearing
 }
 
 // DistanceTo returns the distance along the surface of the earth from `llv` to `dest` using Vincenty Inverse calculation
@@ -350,21 +550,12 @@ func (llv LatLonEllipsoidalVincenty) MidPointTo(dest LatLon) LatLon {
 // p2 := geod.LatLon{48.857, 2.351}
 // pInt := p1.IntermediatePointsTo(p2, []float64{0.25, 0.5, 0.75})
 func (llv LatLonEllipsoidalVincenty) IntermediatePointsTo(dest LatLon, fractions []float64) []LatLon {
-	waitGroup := &sync.WaitGroup{}
-
 	distance, initialBearing, _ := llv.VincentyInverse(dest)
 
 	points := make([]LatLon, len(fractions))
-	for i, fraction := range fractions {
-		waitGroup.Add(1)
-		go func(i int, fraction float64) {
-			points[i], _ = llv.VincentyDirect(float64(distance.Metre())*fraction, initialBearing)
-			waitGroup.Done()
-		}(i, fraction)
-	}
-
-	// wait for all goroutines to finish
-	waitGroup.Wait()
+	ParallelFor(len(fractions), func(i int) {
+		points[i], _ = llv.VincentyDirect(float64(distance.Metre())*fractions[i], initialBearing)
+	})
 
 	return points
 }
@@ -0,0 +1,75 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVincentyInverseAntipodal(t *testing.T) {
+	p1 := NewLatLonEllipsodialVincenty(0, 0, WGS84())
+
+	// converges
+	d, b1, b2, err := p1.VincentyInverseChecked(NewLatLon(0.5, 179.5))
+	assert.NoError(t, err)
+	assert.InDelta(t, 19936288.579, float64(d.Metre()), 0.001)
+	assert.InDelta(t, 25.671873, float64(b1), 0.000001)
+	assert.InDelta(t, 154.327085, float64(b2), 0.000001)
+
+	// expected values calculated using GeographicLib
+	testData := []struct {
+		dest   LatLon
+		dist   float64
+		b1, b2 Degrees
+	}{
+		{NewLatLon(0.5, 179.7), 19944127.421, 15.556883, 164.442514},
+		{NewLatLon(0, 179.9), 20003008.422, 9.545673, 170.454327},
+		{NewLatLon(0.2, 179.8), 19979050.315, 14.329785, 165.670127},
+	}
+
+	for _, td := range testData {
+		d, b1, b2, err := p1.VincentyInverseChecked(td.dest)
+		assert.True(t, errors.Is(err, ErrReducedPrecision))
+		assert.InDelta(t, td.dist, float64(d.Metre()), 0.01)
+		assert.InDelta(t, float64(td.b1), float64(b1), 0.0001)
+		assert.InDelta(t, float64(td.b2), float64(b2), 0.0001)
+
+		assert.Equal(t, d, p1.DistanceTo(td.dest))
+	}
+}
+
+func TestVincentyHighPrecision(t *testing.T) {
+	r := rand.New(rand.NewSource(42)) // nolint:gosec
+	for i := 0; i < 1000; i++ {
+		p1 := VincentyModel(NewLatLon(r.Float64()*170-85, r.Float64()*360-180), WGS84(), HighPrecision)
+		dist := r.Float64() * 15e6
+		bearing := Degrees(r.Float64() * 360)
+
+		p2 := p1.DestinationPoint(dist, bearing)
+		d := p1.DistanceTo(p2)
+		if !d.Valid() {
+			continue
+		}
+
+		assert.InDelta(t, dist, float64(d.Metre()), 20e-9)
+	}
+}
+
+func TestVincentyInverseIdenticalPoints(t *testing.T) {
+	p := NewLatLon(-41.29, 174.78)
+
+	d, initial, final := NewLatLonEllipsodialVincenty(-41.29, 174.78, WGS84()).VincentyInverse(p)
+	assert.Equal(t, 0.0, float64(d.Metre()))
+	assert.True(t, math.IsNaN(float64(initial)))
+	assert.True(t, math.IsNaN(float64(final)))
+	assert.Equal(t, 0.0, float64(Distance(p, p, VincentyModel).Metre()))
+}
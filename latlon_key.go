@@ -0,0 +1,80 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"strconv"
+)
+
+// LatLonKey identifies a cell of a regular latitude/longitude grid. It is comparable and can be used as a map key,
+// for example to deduplicate or count positions. Keys are only comparable if they were made with the same resolution.
+type LatLonKey struct {
+	Lat int64
+	Lon int64
+}
+
+// invalidKeyIndex is used for both fields of the key of an invalid point
+const invalidKeyIndex = math.MinInt64
+
+// Key returns the key of the grid cell, `resolution` degrees in size, whose centre is closest to the point.
+//
+// The key is normalised so that points that are the same position on Earth get the same key:
+//   - longitudes are wrapped to -180..+180, and cells on +180 are merged with those on -180
+//   - -0 and +0 give the same key
+//   - all longitudes collapse to 0 in the cells at the poles.
+//
+// `resolution` should divide 360 evenly, otherwise the cells next to the antimeridian are narrower or wider than
+// the others. Key panics if `resolution` is not positive.
+// Invalid points all return the same key, for which `Valid()` returns false.
+func (ll LatLon) Key(resolution Degrees) LatLonKey {
+	if !(resolution > 0) {
+		panic("resolution must be positive")
+	}
+
+	if !ll.Valid() {
+		return LatLonKey{Lat: invalidKeyIndex, Lon: invalidKeyIndex}
+	}
+
+	r := float64(resolution)
+	lat := int64(math.Round(float64(Wrap90(ll.Latitude)) / r))
+	lon := int64(math.Round(float64(Wrap180(ll.Longitude)) / r))
+
+	if float64(lat)*r >= 90 || float64(lat)*r <= -90 {
+		return LatLonKey{Lat: lat, Lon: 0}
+	}
+
+	n := int64(math.Round(360 / r))
+	if lon >= n-n/2 {
+		lon -= n
+	}
+
+	return LatLonKey{Lat: lat, Lon: lon}
+}
+
+// Valid returns false if the key was made from an invalid point.
+func (k LatLonKey) Valid() bool {
+	return k.Lat != invalidKeyIndex
+}
+
+// LatLon returns the centre of the cell identified by the key. `resolution` must be the value used to make the key.
+func (k LatLonKey) LatLon(resolution Degrees) LatLon {
+	if !k.Valid() {
+		return LatLon{Latitude: Degrees(math.NaN()), Longitude: Degrees(math.NaN())}
+	}
+
+	return LatLon{Latitude: Degrees(k.Lat) * resolution, Longitude: Degrees(k.Lon) * resolution}
+}
+
+// String returns the key as a string, for example "-3685:17476".
+func (k LatLonKey) String() string {
+	if !k.Valid() {
+		return "invalid"
+	}
+
+	return strconv.FormatInt(k.Lat, 10) + ":" + strconv.FormatInt(k.Lon, 10)
+}
@@ -0,0 +1,49 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKey(t *testing.T) {
+	k := NewLatLon(-36.8484, 174.7633).Key(0.01)
+	assert.Equal(t, LatLonKey{Lat: -3685, Lon: 17476}, k)
+	assert.Equal(t, "-3685:17476", k.String())
+	assert.InDelta(t, -36.85, float64(k.LatLon(0.01).Latitude), 1e-9)
+	assert.InDelta(t, 174.76, float64(k.LatLon(0.01).Longitude), 1e-9)
+
+	// nearby points share a key
+	assert.Equal(t, k, NewLatLon(-36.8451, 174.7649).Key(0.01))
+
+	// -0
+	assert.Equal(t, NewLatLon(0, 0).Key(1), NewLatLon(math.Copysign(0, -1), math.Copysign(0, -1)).Key(1))
+	assert.Equal(t, NewLatLon(0.1, 0.1).Key(1), NewLatLon(-0.1, -0.1).Key(1))
+
+	// antimeridian
+	assert.Equal(t, NewLatLon(10, 180).Key(1), NewLatLon(10, -180).Key(1))
+	assert.Equal(t, NewLatLon(10, 179.8).Key(1), NewLatLon(10, -179.9).Key(1))
+	assert.Equal(t, NewLatLon(10, 541).Key(1), NewLatLon(10, -179).Key(1))
+	assert.Equal(t, int64(-180), NewLatLon(10, 180).Key(1).Lon)
+
+	// poles
+	assert.Equal(t, NewLatLon(90, 12).Key(1), NewLatLon(89.9, -100).Key(1))
+	assert.Equal(t, LatLonKey{Lat: -90, Lon: 0}, NewLatLon(-90, 45).Key(1))
+	assert.NotEqual(t, NewLatLon(89.4, 12).Key(1), NewLatLon(89.4, -100).Key(1))
+
+	// invalid
+	k = NewLatLon(math.NaN(), 0).Key(1)
+	assert.False(t, k.Valid())
+	assert.Equal(t, "invalid", k.String())
+	assert.False(t, k.LatLon(1).Valid())
+	assert.Equal(t, k, NewLatLon(0, math.NaN()).Key(1))
+
+	assert.Panics(t, func() { NewLatLon(0, 0).Key(0) })
+}
@@ -23,7 +23,7 @@ type LatLonPlanar struct {
 // Only suitable for short distances.
 func PlanarModel(ll LatLon, modelArgs ...interface{}) Model {
 	if len(modelArgs) != 0 {
-		panic("Invalid number of arguments in call to PlanarModel()")
+		panic(modelArgsError("PlanarModel", "no arguments expected"))
 	}
 	return LatLonPlanar{ll: ll}
 }
@@ -46,47 +46,25 @@ func NewLatLonPlanar(latitude, longitude float64) LatLonPlanar {
 // ParseLatLonPlanar parses a latitude/longitude point from a variety of formats
 // See ParseLatLon for details.
 func ParseLatLonPlanar(args ...interface{}) (LatLonPlanar, error) {
-	ll, err := ParseLatLon(args)
+	ll, err := ParseLatLon(args...)
 	if err != nil {
 		return LatLonPlanar{}, err
 	}
 	return LatLonPlanar{ll: ll}, nil
 }
 
-// approximate distances of 1 degree longitude at each latitude in metres
-var lngDistances = map[int]float64{
-	90: 20.0, 89: 1941, 88: 3881, 87: 5819, 86: 7756,
-	85: 9691, 84: 11623, 83: 13551, 82: 15475, 81: 17395,
-	80: 19309, 79: 21217, 78: 23118, 77: 25013, 76: 26900,
-	75: 28779, 74: 30649, 73: 32510, 72: 34361, 71: 36201,
-	70: 38030, 69: 39848, 68: 41654, 67: 43447, 66: 45227,
-	65: 46993, 64: 48744, 63: 50481, 62: 52202, 61: 53908,
-	60: 55597, 59: 57269, 58: 58924, 57: 60561, 56: 62179,
-	55: 63778, 54: 65358, 53: 66918, 52: 68458, 51: 69977,
-	50: 71474, 49: 72950, 48: 74403, 47: 75834, 46: 77242,
-	45: 78626, 44: 79986, 43: 81322, 42: 82633, 41: 83919,
-	40: 85180, 39: 86414, 38: 87622, 37: 88804, 36: 89958,
-	35: 91085, 34: 92184, 33: 93256, 32: 94298, 31: 95312,
-	30: 96297, 29: 97253, 28: 98179, 27: 99075, 26: 99941,
-	25: 100777, 24: 101581, 23: 102355, 22: 103098, 21: 103809,
-	20: 104489, 19: 105137, 18: 105753, 17: 106336, 16: 106887,
-	15: 107406, 14: 107892, 13: 108345, 12: 108765, 11: 109152,
-	10: 109506, 9: 109826, 8: 110113, 7: 110366, 6: 110586,
-	5: 110772, 4: 110924, 3: 111043, 2: 111127, 1: 111178, 0: 111195,
-}
+// planarDegreeDistance is the length of 1 degree of latitude in metres, and of 1 degree of longitude at the equator
+const planarDegreeDistance = 111195
 
+// DistanceTo returns the distance to `dest`, treating degrees of latitude and longitude as a plane scaled to the
+// length of a degree of longitude at the average latitude of the 2 points.
 func (lls LatLonPlanar) DistanceTo(dest LatLon) units.Distance {
-	const latDist = 111195 // distance of latitudes in metres
-
 	y0 := float64(Wrap90(lls.ll.Latitude))
 	y1 := float64(Wrap90(dest.Latitude))
-	dy := math.Abs(y0-y1) * latDist
+	dy := math.Abs(y0-y1) * planarDegreeDistance
 
-	avgLat := int(math.Round(math.Abs(y0+y1) / 2))
-	lngDist, ok := lngDistances[avgLat]
-	if !ok {
-		lngDist = latDist
-	}
+	avgLat := Degrees((y0 + y1) / 2)
+	lngDist := planarDegreeDistance * math.Cos(avgLat.Radians())
 
 	x0 := float64(Wrap180(lls.ll.Longitude))
 	x1 := float64(Wrap180(dest.Longitude))
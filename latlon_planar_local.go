@@ -0,0 +1,125 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+
+	"github.com/starboard-nz/units"
+)
+
+// LatLonLocalPlanar represents a point used for calculations on a plane centred on an origin point, using the
+// azimuthal equidistant projection of a spherical Earth. Distances and bearings from the origin are exact (on the
+// sphere), and the errors grow with the square of the distance from the origin, to about 0.1% at 500km.
+type LatLonLocalPlanar struct {
+	ll     LatLon
+	origin LatLon
+	x, y   float64
+}
+
+// PlanarModelAt returns an `EarthModel` for calculations on a plane centred on `origin`, using the azimuthal
+// equidistant projection. Straight lines on the plane are close to great circles near the origin.
+//
+// It is much more accurate than PlanarModel for regional work, as long as all points are within a few hundred
+// kilometres of the origin. The spherical Earth radius is used (see SetEarthRadius).
+//
+// Example:
+// model := geod.PlanarModelAt(geod.NewLatLon(-41.29, 174.78))
+// d := geod.Distance(p1, p2, model)
+func PlanarModelAt(origin LatLon) EarthModel {
+	return func(ll LatLon, modelArgs ...interface{}) Model {
+		if len(modelArgs) != 0 {
+			panic(modelArgsError("PlanarModelAt", "no arguments expected"))
+		}
+
+		x, y := azimuthalEquidistant(origin, ll)
+
+		return LatLonLocalPlanar{ll: ll, origin: origin, x: x, y: y}
+	}
+}
+
+// azimuthalEquidistant returns the projected coordinates of `ll` in metres, east and north of `origin`
+func azimuthalEquidistant(origin, ll LatLon) (float64, float64) {
+	if origin.Equals(ll) {
+		return 0, 0
+	}
+
+	o := LatLonSpherical{ll: origin}
+	d := float64(o.DistanceTo(ll).Metre())
+	θ := o.InitialBearingTo(ll).Radians()
+
+	return d * math.Sin(θ), d * math.Cos(θ)
+}
+
+// point returns the point at projected coordinates x, y
+func (lp LatLonLocalPlanar) point(x, y float64) LatLon {
+	d := math.Hypot(x, y)
+	if d == 0 {
+		return lp.origin
+	}
+
+	return LatLonSpherical{ll: lp.origin}.DestinationPoint(d, DegreesFromRadians(math.Atan2(x, y)))
+}
+
+// LatLon converts LatLonLocalPlanar to LatLon
+func (lp LatLonLocalPlanar) LatLon() LatLon {
+	return lp.ll
+}
+
+// DistanceTo returns the straight line distance to `dest` on the plane.
+func (lp LatLonLocalPlanar) DistanceTo(dest LatLon) units.Distance {
+	x, y := azimuthalEquidistant(lp.origin, dest)
+
+	return units.Metre(math.Hypot(x-lp.x, y-lp.y))
+}
+
+// InitialBearingTo returns the bearing to `dest` on the plane, relative to the direction of North at the origin,
+// in Degrees (0°..360°). Returns NaN if the points are the same.
+func (lp LatLonLocalPlanar) InitialBearingTo(dest LatLon) Degrees {
+	x, y := azimuthalEquidistant(lp.origin, dest)
+	if x == lp.x && y == lp.y {
+		return Degrees(math.NaN())
+	}
+
+	return Wrap360(DegreesFromRadians(math.Atan2(x-lp.x, y-lp.y)))
+}
+
+// FinalBearingOn returns the same as InitialBearingTo, as bearings don't change along straight lines on the plane.
+func (lp LatLonLocalPlanar) FinalBearingOn(dest LatLon) Degrees {
+	return lp.InitialBearingTo(dest)
+}
+
+// DestinationPoint returns the point `distance` metres away in the direction of `bearing` on the plane.
+func (lp LatLonLocalPlanar) DestinationPoint(distance float64, bearing Degrees) LatLon {
+	θ := bearing.Radians()
+
+	return lp.point(lp.x+distance*math.Sin(θ), lp.y+distance*math.Cos(θ))
+}
+
+// MidPointTo returns the point half way to `dest` on the plane.
+func (lp LatLonLocalPlanar) MidPointTo(dest LatLon) LatLon {
+	return lp.IntermediatePointTo(dest, 0.5)
+}
+
+// IntermediatePointTo returns the point at `fraction` of the straight line to `dest` on the plane.
+func (lp LatLonLocalPlanar) IntermediatePointTo(dest LatLon, fraction float64) LatLon {
+	x, y := azimuthalEquidistant(lp.origin, dest)
+
+	return lp.point(lp.x+(x-lp.x)*fraction, lp.y+(y-lp.y)*fraction)
+}
+
+// IntermediatePointsTo returns the points at each of the `fractions` of the straight line to `dest` on the plane.
+func (lp LatLonLocalPlanar) IntermediatePointsTo(dest LatLon, fractions []float64) []LatLon {
+	x, y := azimuthalEquidistant(lp.origin, dest)
+
+	res := make([]LatLon, 0, len(fractions))
+	for _, fr := range fractions {
+		res = append(res, lp.point(lp.x+(x-lp.x)*fr, lp.y+(y-lp.y)*fr))
+	}
+
+	return res
+}
@@ -0,0 +1,62 @@
+package geod_test
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	geod "github.com/starboard-nz/go-geodesy"
+)
+
+func TestPlanarModelAt(t *testing.T) {
+	origin := geod.NewLatLon(-41.29, 174.78)
+	model := geod.PlanarModelAt(origin)
+
+	// exact from the origin
+	p := geod.NewLatLon(-43.53, 172.64)
+	exp := geod.SphericalModel(origin).DistanceTo(p).Metre()
+	assert.InDelta(t, float64(exp), float64(model(origin).DistanceTo(p).Metre()), 1e-6)
+	assert.InDelta(t, float64(geod.SphericalModel(origin).InitialBearingTo(p)),
+		float64(model(origin).InitialBearingTo(p)), 1e-9)
+
+	// regional distances are much closer to the sphere than PlanarModel
+	p1 := geod.NewLatLon(-39.49, 176.91)
+	p2 := geod.NewLatLon(-45.87, 170.50)
+	exp = geod.SphericalModel(p1).DistanceTo(p2).Metre()
+	local := model(p1).DistanceTo(p2).Metre()
+	global := geod.PlanarModel(p1).DistanceTo(p2).Metre()
+	assert.InEpsilon(t, float64(exp), float64(local), 1e-3)
+	assert.Less(t, math.Abs(float64(local-exp)), math.Abs(float64(global-exp))/5)
+
+	// destination and back
+	d := model(p1).DestinationPoint(250000, 200)
+	assert.InDelta(t, 250000, float64(model(p1).DistanceTo(d).Metre()), 1e-6)
+	assert.InDelta(t, 200, float64(model(p1).InitialBearingTo(d)), 1e-9)
+	assert.InDelta(t, 20, float64(model(d).FinalBearingOn(p1)), 1e-9)
+
+	// intermediate points are on the straight line
+	mid := model(p1).MidPointTo(p2)
+	assert.InDelta(t, float64(model(p1).DistanceTo(p2).Metre())/2, float64(model(p1).DistanceTo(mid).Metre()), 1e-6)
+	pts := model(p1).IntermediatePointsTo(p2, []float64{0, 0.5, 1})
+	assert.True(t, pts[0].Equals(p1) || float64(model(p1).DistanceTo(pts[0]).Metre()) < 1e-6)
+	assert.InDelta(t, float64(mid.Latitude), float64(pts[1].Latitude), 1e-12)
+	assert.InDelta(t, 0, float64(model(p2).DistanceTo(pts[2]).Metre()), 1e-6)
+
+	assert.True(t, math.IsNaN(float64(model(p1).InitialBearingTo(p1))))
+	assert.Panics(t, func() { model(p1, geod.WGS84()) })
+
+	// across the antimeridian
+	model = geod.PlanarModelAt(geod.NewLatLon(-17, 180))
+	a := geod.NewLatLon(-17, 179.5)
+	b := geod.NewLatLon(-17, -179.5)
+	exp = geod.SphericalModel(a).DistanceTo(b).Metre()
+	assert.InEpsilon(t, float64(exp), float64(model(a).DistanceTo(b).Metre()), 1e-5)
+	assert.InDelta(t, 90, float64(model(a).InitialBearingTo(b)), 0.01)
+}
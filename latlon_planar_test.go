@@ -101,24 +101,35 @@ func TestPlanar(t *testing.T) {
 		OctagonSth := geod.NewLatLonPlanar(-45.8745, 170.5033)
 		OctagonNth := geod.NewLatLon(-45.8736, 170.5038)
 		dist := OctagonSth.DistanceTo(OctagonNth)
-		assert.InDeltaf(t, float64(107.2692), float64(dist.Metre()), δ, "distance: %v", dist)
+		assert.InDeltaf(t, float64(107.3010), float64(dist.Metre()), δ, "distance: %v", dist)
 
 		p1 := geod.NewLatLonPlanar(20, 165)
 		p2 := geod.NewLatLon(20, 175)
 		dist = p1.DistanceTo(p2)
-		assert.InDeltaf(t, float64(104489*10), float64(dist.Metre()), δ, "distance: %v", dist)
+		assert.InDeltaf(t, 111195*math.Cos(20*math.Pi/180)*10, float64(dist.Metre()), δ, "distance: %v", dist)
 	})
 
 	t.Run("Distance (crossing the antimeridian)", func(t *testing.T) {
 		p1 := geod.NewLatLonPlanar(20, 175)
 		p2 := geod.NewLatLon(20, -175)
 		dist := p1.DistanceTo(p2)
-		assert.InDeltaf(t, float64(104489*10), float64(dist.Metre()), δ, "distance: %v", dist)
+		assert.InDeltaf(t, 111195*math.Cos(20*math.Pi/180)*10, float64(dist.Metre()), δ, "distance: %v", dist)
 
 		p1 = geod.NewLatLonPlanar(20, -175)
 		p2 = geod.NewLatLon(20, 175)
 		dist = p1.DistanceTo(p2)
-		assert.InDeltaf(t, float64(104489*10), float64(dist.Metre()), δ, "distance: %v", dist)
+		assert.InDeltaf(t, 111195*math.Cos(20*math.Pi/180)*10, float64(dist.Metre()), δ, "distance: %v", dist)
+	})
+
+	t.Run("Distance (fractional latitudes)", func(t *testing.T) {
+		// within 0.01% of the spherical distance at any latitude
+		for _, lat := range []float64{0.3, 45.5, 61.49, 75.7, 89.2} {
+			p1 := geod.NewLatLon(lat, 10)
+			p2 := geod.NewLatLon(lat+0.01, 10.01)
+			exp := geod.SphericalModel(p1).DistanceTo(p2).Metre()
+			dist := geod.PlanarModel(p1).DistanceTo(p2).Metre()
+			assert.InEpsilonf(t, float64(exp), float64(dist), 1e-4, "latitude %f", lat)
+		}
 	})
 
 	t.Run("IntermediatePointTo", func(t *testing.T) {
@@ -22,21 +22,21 @@ import (
  */
 
 import (
+	"fmt"
 	"math"
-	"sync"
 )
 
 // LatLonSpherical represents a point used for calculations using a spherical Earth model, along great circles
 type LatLonSpherical struct {
-	ll LatLon
+	ll     LatLon
+	radius Radius
 }
 
 // SphericalModel returns a `Model` that wraps geodesy calculations using spherical Earth model along great circles
+//
+// The only accepted model argument is the `Radius` of the Earth (default: see SetEarthRadius).
 func SphericalModel(ll LatLon, modelArgs ...interface{}) Model {
-	if len(modelArgs) != 0 {
-		panic("Invalid number of arguments in call to SphericalModel()")
-	}
-	return LatLonSpherical{ll: ll}
+	return LatLonSpherical{ll: ll, radius: radiusArg("SphericalModel", modelArgs)}
 }
 
 // LatLon converts LatLonSpherical to LatLon
@@ -46,16 +46,67 @@ func (lls LatLonSpherical) LatLon() LatLon {
 
 var earthRadius float64 = 6371000 // metres
 
+// Radius is the radius of the Earth in metres, it can be passed to SphericalModel and RhumbModel as a model argument
+// to override the radius set with SetEarthRadius for a single calculation.
+//
+// Example:
+// d := geod.Distance(p1, p2, geod.SphericalModel, geod.Radius(6378137))
+type Radius float64
+
+// radiusArg returns the `Radius` in the arguments of the `model` function, or 0 for the global radius. Panics if the
+// arguments are invalid.
+func radiusArg(model string, modelArgs []interface{}) Radius {
+	if len(modelArgs) > 1 {
+		panic(modelArgsError(model, "too many arguments"))
+	}
+	if len(modelArgs) == 0 {
+		return 0
+	}
+
+	r, ok := modelArgs[0].(Radius)
+	if !ok {
+		panic(modelArgsError(model, fmt.Sprintf("unexpected argument type %T", modelArgs[0])))
+	}
+	if !(r > 0) || math.IsInf(float64(r), 1) {
+		panic(modelArgsError(model, fmt.Sprintf("invalid radius %v", float64(r))))
+	}
+
+	return r
+}
+
 // SetEarthRadius can be used to [globally] change the value of Earth's radius (in metres) used
 // for spherical Earth calculations (includes rhumb). Default is 6371000m
+//
+// Panics if the radius is not positive, see SetEarthRadiusChecked.
 func SetEarthRadius(r float64) {
-	if math.IsNaN(r) {
-		panic("Invalid Earth radius specified: NaN")
+	if err := SetEarthRadiusChecked(r); err != nil {
+		panic(err)
 	}
-	if r <= 0 {
-		panic("Invalid Earth radius specified, must be positive")
+}
+
+// SetEarthRadiusChecked is the same as SetEarthRadius, but returns ErrInvalidEarthRadius instead of panicking if the
+// radius is not positive (or is NaN or infinite), for radii from configuration.
+func SetEarthRadiusChecked(r float64) error {
+	if !(r > 0) || math.IsInf(r, 1) {
+		return fmt.Errorf("%w: %v", ErrInvalidEarthRadius, r)
 	}
 	earthRadius = r
+
+	return nil
+}
+
+// EarthRadius returns the radius of the Earth (in metres) used for spherical Earth calculations, see SetEarthRadius.
+func EarthRadius() float64 {
+	return earthRadius
+}
+
+// sphereRadius returns the radius of the Earth used by `lls`, in metres
+func (lls LatLonSpherical) sphereRadius() float64 {
+	if lls.radius > 0 {
+		return float64(lls.radius)
+	}
+
+	return earthRadius
 }
 
 // NewLatLonSpherical creates a new LatLonSpherical struct
@@ -71,7 +122,7 @@ func NewLatLonSpherical(latitude, longitude float64) LatLonSpherical {
 // ParseLatLonSpherical parses a latitude/longitude point from a variety of formats
 // See ParseLatLon for details.
 func ParseLatLonSpherical(args ...interface{}) (LatLonSpherical, error) {
-	ll, err := ParseLatLon(args)
+	ll, err := ParseLatLon(args...)
 	if err != nil {
 		return LatLonSpherical{}, err
 	}
@@ -99,7 +150,7 @@ func (lls LatLonSpherical) DistanceTo(dest LatLon) units.Distance {
 	// δ = 2·atan2(√(a), √(1−a))
 	// see mathforum.org/library/drmath/view/51879.html for derivation
 
-	R := earthRadius
+	R := lls.sphereRadius()
 	φ1 := lls.ll.Latitude.Radians()
 	λ1 := lls.ll.Longitude.Radians()
 	φ2 := dest.Latitude.Radians()
@@ -264,19 +315,10 @@ func (lls LatLonSpherical) IntermediatePointTo(dest LatLon, fraction float64) La
 // p2 := geod.LatLon{48.857, 2.351}
 // pInt := p1.IntermediatePointsTo(p2, []float64{0.25, 0.5, 0.75})
 func (lls LatLonSpherical) IntermediatePointsTo(dest LatLon, fractions []float64) []LatLon {
-	waitGroup := &sync.WaitGroup{}
-
 	points := make([]LatLon, len(fractions))
-	for i, fraction := range fractions {
-		waitGroup.Add(1)
-		go func(i int, fraction float64) {
-			points[i] = lls.IntermediatePointTo(dest, fraction)
-			waitGroup.Done()
-		}(i, fraction)
-	}
-
-	// wait for all goroutines to finish
-	waitGroup.Wait()
+	ParallelFor(len(fractions), func(i int) {
+		points[i] = lls.IntermediatePointTo(dest, fractions[i])
+	})
 
 	return points
 }
@@ -299,7 +341,7 @@ func (lls LatLonSpherical) DestinationPoint(distance float64, bearing Degrees) L
 	// tanΔλ = sinθ⋅sinδ⋅cosφ1 / cosδ−sinφ1⋅sinφ2
 	// see mathforum.org/library/drmath/view/52049.html for derivation
 
-	δ := distance / earthRadius // angular distance in radians
+	δ := distance / lls.sphereRadius() // angular distance in radians
 	θ := bearing.Radians()
 
 	φ1 := lls.ll.Latitude.Radians()
@@ -396,3 +438,90 @@ func (lls LatLonSpherical) Intersection(bearing1 Degrees, ll2 LatLon, bearing2 D
 
 	return LatLon{Latitude: Wrap90(lat), Longitude: Wrap180(lon)}
 }
+
+// CrossTrackDistanceTo returns the (signed) distance from `lls` to the great circle defined by `pathStart` and
+// `pathEnd`, negative if `lls` is to the left of the path.
+//
+// Arguments:
+//
+// pathStart - start point of the great circle path
+// pathEnd - end point of the great circle path
+//
+// Returns the distance to the great circle.
+//
+// Example:
+// p := geod.NewLatLonSpherical(53.2611, -0.7972)
+// p1 := geod.LatLon{53.3206, -1.7297}
+// p2 := geod.LatLon{53.1887, 0.1334}
+// d := p.CrossTrackDistanceTo(p1, p2).Metre()  // -307.5 m
+func (lls LatLonSpherical) CrossTrackDistanceTo(pathStart, pathEnd LatLon) units.Distance {
+	if lls.ll.Equals(pathStart) {
+		return units.Metre(0)
+	}
+
+	R := lls.sphereRadius()
+	start := LatLonSpherical{ll: pathStart, radius: lls.radius}
+
+	δ13 := float64(start.DistanceTo(lls.ll).Metre()) / R
+	θ13 := start.InitialBearingTo(lls.ll).Radians()
+	θ12 := start.InitialBearingTo(pathEnd).Radians()
+
+	δxt := math.Asin(math.Sin(δ13) * math.Sin(θ13-θ12))
+
+	return units.Metre(δxt * R)
+}
+
+// AlongTrackDistanceTo returns how far `lls` is along the great circle path from `pathStart` to `pathEnd`, measured
+// from `pathStart` to the closest point of the path, negative if the closest point is behind `pathStart`.
+//
+// Arguments:
+//
+// pathStart - start point of the great circle path
+// pathEnd - end point of the great circle path
+//
+// Returns the distance along the great circle to the closest point.
+//
+// Example:
+// p := geod.NewLatLonSpherical(53.2611, -0.7972)
+// p1 := geod.LatLon{53.3206, -1.7297}
+// p2 := geod.LatLon{53.1887, 0.1334}
+// d := p.AlongTrackDistanceTo(p1, p2).Km()  // 62.331 km
+func (lls LatLonSpherical) AlongTrackDistanceTo(pathStart, pathEnd LatLon) units.Distance {
+	if lls.ll.Equals(pathStart) {
+		return units.Metre(0)
+	}
+
+	R := lls.sphereRadius()
+	start := LatLonSpherical{ll: pathStart, radius: lls.radius}
+
+	δ13 := float64(start.DistanceTo(lls.ll).Metre()) / R
+	θ13 := start.InitialBearingTo(lls.ll).Radians()
+	θ12 := start.InitialBearingTo(pathEnd).Radians()
+
+	δxt := math.Asin(math.Sin(δ13) * math.Sin(θ13-θ12))
+	δat := math.Acos(math.Min(math.Max(math.Cos(δ13)/math.Abs(math.Cos(δxt)), -1), 1))
+
+	if math.Cos(θ12-θ13) < 0 {
+		δat = -δat
+	}
+
+	return units.Metre(δat * R)
+}
+
+// AreaOf returns the area of the polygon with the given vertices, whose edges are great circle arcs, in square metres.
+// The polygon may be closed (with the last point the same as the first) or not, and it must be smaller than a
+// hemisphere. The receiver is only used for the radius of the Earth. See SphericalExcess.
+//
+// Example:
+// polygon := []geod.LatLon{{0, 0}, {1, 0}, {0, 1}}
+// area := geod.NewLatLonSpherical(0, 0).AreaOf(polygon)  // 6.18e9 m²
+func (lls LatLonSpherical) AreaOf(polygon []LatLon) float64 {
+	vertices := make([]Vector3D, len(polygon))
+	for i, ll := range polygon {
+		vertices[i] = ll.ToNvector()
+	}
+
+	R := lls.sphereRadius()
+
+	return SphericalExcess(vertices) * R * R
+}
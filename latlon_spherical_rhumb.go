@@ -18,22 +18,21 @@ package geod
 
 import (
 	"math"
-	"sync"
 
 	"github.com/starboard-nz/units"
 )
 
 // LatLonRhumb represents a point used for calculations using a spherical Earth model, along rhumb lines
 type LatLonRhumb struct {
-	ll LatLon
+	ll     LatLon
+	radius Radius
 }
 
 // RhumbModel returns a `Model` that wraps geodesy calculations using spherical Earth model along rhumb lines
+//
+// The only accepted model argument is the `Radius` of the Earth (default: see SetEarthRadius).
 func RhumbModel(ll LatLon, modelArgs ...interface{}) Model {
-	if len(modelArgs) != 0 {
-		panic("Invalid number of arguments in call to VincentyModel()")
-	}
-	return LatLonRhumb{ll: ll}
+	return LatLonRhumb{ll: ll, radius: radiusArg("RhumbModel", modelArgs)}
 }
 
 // LatLon converts LatLonRhumb to LatLon
@@ -41,6 +40,15 @@ func (llr LatLonRhumb) LatLon() LatLon {
 	return llr.ll
 }
 
+// sphereRadius returns the radius of the Earth used by `llr`, in metres
+func (llr LatLonRhumb) sphereRadius() float64 {
+	if llr.radius > 0 {
+		return float64(llr.radius)
+	}
+
+	return earthRadius
+}
+
 // NewLatLonRhumb creates a new LatLonRhumb struct
 func NewLatLonRhumb(latitude, longitude Degrees) LatLonRhumb {
 	return LatLonRhumb{
@@ -67,7 +75,7 @@ func (llr LatLonRhumb) DistanceTo(dest LatLon) units.Distance {
 	// see www.edwilliams.org/avform.htm#Rhumb
 
 	const π = math.Pi
-	R := earthRadius
+	R := llr.sphereRadius()
 	φ1 := llr.ll.Latitude.Radians()
 	φ2 := dest.Latitude.Radians()
 	Δφ := φ2 - φ1
@@ -175,7 +183,7 @@ func (llr LatLonRhumb) DestinationPoint(distance float64, bearing Degrees) LatLo
 	λ1 := llr.ll.Longitude.Radians()
 	θ := bearing.Radians()
 
-	δ := distance / earthRadius // angular distance in radians
+	δ := distance / llr.sphereRadius() // angular distance in radians
 
 	Δφ := δ * math.Cos(θ)
 	φ2 := φ1 + Δφ
@@ -285,23 +293,14 @@ func (llr LatLonRhumb) IntermediatePointTo(dest LatLon, fraction float64) LatLon
 // p2 := geod.LatLon{48.857, 2.351}
 // pInt := p1.IntermediatePointsTo(p2, []float64{0.25, 0.5, 0.75})
 func (llr LatLonRhumb) IntermediatePointsTo(dest LatLon, fractions []float64) []LatLon {
-	waitGroup := &sync.WaitGroup{}
-
 	dist := llr.DistanceTo(dest)
 	bearing := llr.InitialBearingTo(dest)
 
 	points := make([]LatLon, len(fractions))
-	for i, fraction := range fractions {
-		waitGroup.Add(1)
-		go func(i int, fraction float64) {
-			frDist := float64(dist.Metre()) * fraction
-			points[i] = llr.DestinationPoint(frDist, bearing)
-			waitGroup.Done()
-		}(i, fraction)
-	}
-
-	// wait for all goroutines to finish
-	waitGroup.Wait()
+	ParallelFor(len(fractions), func(i int) {
+		frDist := float64(dist.Metre()) * fractions[i]
+		points[i] = llr.DestinationPoint(frDist, bearing)
+	})
 
 	return points
 }
@@ -7,24 +7,10 @@ package geod
  */
 
 import (
-	"fmt"
 	"math"
 	"testing"
-	"time"
 )
 
-func TestTest(t *testing.T) {
-	p1 := NewLatLonRhumb(29.56600166666667, -93.65966499999999)
-	p2 := NewLatLon(-45.83760666666667, -94.61307166666667)
-	dist := p1.DistanceTo(p2)
-	fmt.Printf("dist = %v\n", dist)
-	fmt.Printf("dist = %v\n", dist)
-
-	dt := time.Duration(1680719495-1680404293) * time.Second
-	fmt.Printf("duration: %v\n", dt)
-	fmt.Printf("Speed: %f\n", float64(dist.NM())/((1680719495-1680404293)/3600))
-}
-
 func TestRhumb(t *testing.T) {
 	p1 := NewLatLonRhumb(51.127, 1.338)
 	p2 := NewLatLon(50.964, 1.853)
@@ -7,6 +7,7 @@ package geod
  */
 
 import (
+	"errors"
 	"math"
 	"testing"
 )
@@ -57,3 +58,29 @@ func TestSpherical(t *testing.T) {
 		t.Errorf("Incorrect result")
 	}
 }
+
+func TestEarthRadius(t *testing.T) {
+	SetEarthRadius(3959.0)
+	if EarthRadius() != 3959.0 {
+		t.Errorf("Incorrect result")
+	}
+	SetEarthRadius(6371000.0)
+	if EarthRadius() != 6371000.0 {
+		t.Errorf("Incorrect result")
+	}
+}
+
+func TestSetEarthRadiusChecked(t *testing.T) {
+	for _, r := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		if err := SetEarthRadiusChecked(r); !errors.Is(err, ErrInvalidEarthRadius) {
+			t.Errorf("Expected ErrInvalidEarthRadius for %v, got %v", r, err)
+		}
+	}
+	if EarthRadius() != 6371000.0 {
+		t.Errorf("Earth radius changed by invalid value")
+	}
+
+	if err := SetEarthRadiusChecked(6371000.0); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,52 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"testing"
+
+	"github.com/starboard-nz/units"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRound(t *testing.T) {
+	ll := NewLatLon(-36.848461123, 174.763336987)
+	assert.Equal(t, NewLatLon(-36.85, 174.76), ll.Round(2))
+	assert.Equal(t, NewLatLon(-37, 175), ll.Round(0))
+
+	// ~1cm needs 7 decimals at this latitude
+	assert.Equal(t, 7, ll.DecimalsWithin(units.Metre(0.01)))
+	assert.Equal(t, NewLatLon(-36.8484611, 174.763337), ll.RoundWithin(units.Metre(0.01)))
+	assert.Equal(t, 3, ll.DecimalsWithin(units.Km(0.1)))
+
+	// already short values are not padded
+	assert.Equal(t, 1, NewLatLon(10.5, 20).DecimalsWithin(units.Metre(0.01)))
+
+	p := NewLatLon(math.NaN(), 0).RoundWithin(units.Metre(1))
+	assert.False(t, p.Valid())
+}
+
+func TestFormatLatLonWithin(t *testing.T) {
+	ll := NewLatLon(-36.848461123, 174.763336987)
+	assert.Equal(t, "-36.8484611, 174.763337", FormatLatLonWithin(ll, units.Metre(0.01)))
+	assert.Equal(t, "-36.848, 174.763", FormatLatLonWithin(ll, units.Metre(100)))
+	assert.Equal(t, "10.5, 20", FormatLatLonWithin(NewLatLon(10.5, 20), units.Metre(0.01)))
+	assert.Equal(t, "", FormatLatLonWithin(NewLatLon(0, math.NaN()), units.Metre(1)))
+}
+
+func TestEqualsWithin(t *testing.T) {
+	p1 := NewLatLon(-36.8484, 174.7633)
+	p2 := NewLatLon(-36.84845, 174.7633) // ~5.6m south
+
+	assert.True(t, p1.EqualsWithin(p1, units.Metre(0), VincentyModel, WGS84()))
+	assert.True(t, p1.EqualsWithin(p2, units.Metre(6), SphericalModel))
+	assert.False(t, p1.EqualsWithin(p2, units.Metre(5), SphericalModel))
+	assert.True(t, p1.EqualsWithin(p2, units.Metre(6), VincentyModel, WGS84()))
+	assert.True(t, p1.EqualsWithin(p2, units.NM(0.01), RhumbModel))
+	assert.False(t, p1.EqualsWithin(NewLatLon(math.NaN(), 0), units.Km(1e6), SphericalModel))
+}
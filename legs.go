@@ -0,0 +1,284 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/starboard-nz/units"
+)
+
+// legTolerance is the fraction of the great circle at which the search for the end of a leg stops
+const legTolerance = 1e-9
+
+// RhumbLeg is a leg of a route sailed on a constant bearing.
+type RhumbLeg struct {
+	// From and To are the start and end of the leg
+	From, To LatLon
+	// Bearing is the constant (rhumb) bearing from From to To, in `Degrees` from North
+	Bearing Degrees
+	// Distance is the length of the leg along the rhumb line
+	Distance units.Distance
+}
+
+// GreatCircleAsRhumbLegs returns the rhumb line legs approximating the great circle route from `start` to `end`, so
+// that no point of the legs is further than `maxCrossTrack` from the great circle. This is how great circle routes
+// are sailed: the course is kept constant on each leg and changed at the waypoints between the legs, which are on
+// the great circle.
+//
+// The legs are found greedily, each leg as long as the tolerance allows, which gives the fewest legs with
+// waypoints on the great circle. The calculations use the spherical Earth model (see SetEarthRadius). Returns nil
+// if `start` and `end` are the same or antipodal (the great circle is undefined), or if the tolerance is not positive.
+//
+// Example:
+// legs := geod.GreatCircleAsRhumbLegs(geod.NewLatLon(-33.86, 151.21), geod.NewLatLon(37.77, -122.42), units.NM(50))
+// for _, leg := range legs {
+// fmt.Printf("%s %.0f° %.0fNM\n", leg.From, leg.Bearing, leg.Distance.NM())
+// }
+func GreatCircleAsRhumbLegs(start, end LatLon, maxCrossTrack units.Distance) []RhumbLeg {
+	tolerance := float64(maxCrossTrack.Metre())
+	if !(tolerance > 0) || start.Equals(end) {
+		return nil
+	}
+
+	// the normal of the great circle, (nearly) zero if the points are (nearly) antipodal
+	n := start.ToNvector().Cross(end.ToNvector())
+	if n.Length() < 1e-12 {
+		return nil
+	}
+	n = n.Unit()
+
+	gc := LatLonSpherical{ll: start}
+	pointAt := func(f float64) LatLon {
+		switch f {
+		case 0:
+			return start
+		case 1:
+			return end
+		}
+
+		return gc.IntermediatePointTo(end, f)
+	}
+
+	// the largest distance of the rhumb line from `from` to `to` from the great circle
+	deviation := func(from, to LatLon) float64 {
+		rl := LatLonRhumb{ll: from}
+		offset := func(f float64) float64 {
+			return math.Abs(math.Asin(rl.IntermediatePointTo(to, f).ToNvector().Dot(n)))
+		}
+		_, d := maximiseAlong(offset, offset(0), offset(1))
+
+		return d * earthRadius
+	}
+
+	var legs []RhumbLeg
+
+	f0, from := 0.0, start
+	for f0 < 1 {
+		f1, to := 1.0, end
+		if deviation(from, to) > tolerance {
+			// bisect for the furthest point on the great circle reachable within the tolerance
+			lo, hi := f0, 1.0
+			for hi-lo > legTolerance {
+				mid := (lo + hi) / 2
+				if deviation(from, pointAt(mid)) > tolerance {
+					hi = mid
+				} else {
+					lo = mid
+				}
+			}
+			if lo == f0 {
+				lo = hi
+			}
+			f1, to = lo, pointAt(lo)
+		}
+
+		rl := LatLonRhumb{ll: from}
+		legs = append(legs, RhumbLeg{From: from, To: to, Bearing: rl.InitialBearingTo(to), Distance: rl.DistanceTo(to)})
+		f0, from = f1, to
+	}
+
+	return legs
+}
+
+// BearingPoint is a point along a path, with the bearing of the path at the point.
+type BearingPoint struct {
+	LatLon
+	// Distance is the distance along the path from its start
+	Distance units.Distance
+	// Bearing is the direction of the path at the point, in `Degrees` from North
+	Bearing Degrees
+}
+
+// BearingProfile returns `n` points spaced evenly along the path from `start` to `end`, including both, with the
+// bearing of the path at each point, for example for producing the schedule of course changes of a long ocean
+// passage along a great circle. The bearing is the initial bearing at `start`, the final bearing at `end`, and the
+// initial bearing to `end` from the points in between.
+//
+// Arguments:
+//
+// start - starting point
+// end - end point (destination)
+// n - the number of points, at least 2, otherwise nil is returned
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// Returns nil if `start` and `end` are the same, as the bearing is undefined.
+//
+// Example:
+// p1 := geod.NewLatLon(-36.85, 174.76)
+// p2 := geod.NewLatLon(37.62, -122.38)
+// for _, p := range geod.BearingProfile(p1, p2, 11, geod.SphericalModel) {
+// fmt.Printf("%.0fNM %.1f° %.0f°\n", p.Distance.NM(), p.Latitude, p.Bearing)
+// }
+func BearingProfile(start, end LatLon, n int, model EarthModel, modelArgs ...interface{}) []BearingPoint {
+	if n < 2 || start.Equals(end) {
+		return nil
+	}
+
+	p1 := model(start, modelArgs...)
+	total := float64(p1.DistanceTo(end).Metre())
+
+	fractions := make([]float64, n-2)
+	for i := range fractions {
+		fractions[i] = float64(i+1) / float64(n-1)
+	}
+
+	profile := make([]BearingPoint, 0, n)
+	profile = append(profile, BearingPoint{LatLon: start, Distance: units.Metre(0), Bearing: p1.InitialBearingTo(end)})
+
+	for i, p := range p1.IntermediatePointsTo(end, fractions) {
+		profile = append(profile, BearingPoint{
+			LatLon:   p,
+			Distance: units.Metre(fractions[i] * total),
+			Bearing:  model(p, modelArgs...).InitialBearingTo(end),
+		})
+	}
+
+	return append(profile, BearingPoint{LatLon: end, Distance: units.Metre(total), Bearing: p1.FinalBearingOn(end)})
+}
+
+// CompositeLeg is a leg of a composite great circle route, see CompositeGreatCircle.
+type CompositeLeg struct {
+	// From and To are the start and end of the leg
+	From, To LatLon
+	// Bearing is the initial bearing of the leg, in `Degrees` from North
+	Bearing Degrees
+	// Distance is the length of the leg
+	Distance units.Distance
+	// Parallel is true for the leg along the limiting parallel, sailed on a constant course of 090° or 270°, and
+	// false for the great circle legs
+	Parallel bool
+}
+
+// CompositeGreatCircle returns the legs of the composite great circle route from `start` to `end` that doesn't go
+// further from the equator than `maxLatitude`, for example to keep a passage clear of ice or heavy weather, and the
+// total distance. This is the classic composite sailing: the great circle from `start` that touches the limiting
+// parallel, along the parallel, and the great circle from the parallel that reaches `end`. It is the shortest route
+// within the limit.
+//
+// If the great circle from `start` to `end` doesn't go beyond `maxLatitude` it is the only leg. The legs along the
+// great circles may be sailed as rhumb line legs with GreatCircleAsRhumbLegs. The calculations use the spherical
+// Earth model (see SetEarthRadius).
+//
+// Returns an error wrapping ErrInvalidArgument if `maxLatitude` is not between 0° and 90°, if `start` or `end` is
+// beyond it, or if the points are antipodal (the great circle is undefined). Returns no legs if `start` and `end` are
+// the same.
+//
+// Example:
+// // Cape Town to Hobart, north of 50°S
+// legs, dist, err := geod.CompositeGreatCircle(geod.NewLatLon(-33.92, 18.42), geod.NewLatLon(-42.88, 147.33), 50)
+// if err != nil {
+// ... handle the error
+// }
+// for _, leg := range legs {
+// fmt.Printf("%s %.0f° %.0fNM\n", leg.From, leg.Bearing, leg.Distance.NM())
+// }
+// fmt.Printf("%.0fNM\n", dist.NM())
+func CompositeGreatCircle(start, end LatLon, maxLatitude Degrees) ([]CompositeLeg, units.Distance, error) {
+	if !(maxLatitude > 0 && maxLatitude < 90) {
+		return nil, units.Metre(0), fmt.Errorf("%w: limiting latitude %v", ErrInvalidArgument, maxLatitude)
+	}
+
+	for _, p := range []LatLon{start, end} {
+		if math.Abs(float64(p.Latitude)) > float64(maxLatitude) {
+			return nil, units.Metre(0), fmt.Errorf("%w: %v beyond the limiting latitude %v", ErrInvalidArgument, p,
+				maxLatitude)
+		}
+	}
+
+	if start.Equals(end) {
+		return nil, units.Metre(0), nil
+	}
+
+	if start.ToNvector().Cross(end.ToNvector()).Length() < 1e-12 {
+		return nil, units.Metre(0), fmt.Errorf("%w: antipodal points %v and %v", ErrInvalidArgument, start, end)
+	}
+
+	greatCircle := func(from, to LatLon) CompositeLeg {
+		gc := LatLonSpherical{ll: from}
+
+		return CompositeLeg{From: from, To: to, Bearing: gc.InitialBearingTo(to), Distance: gc.DistanceTo(to)}
+	}
+
+	// the parallel limiting the route, in the hemisphere the great circle goes beyond it, if it does
+	north, south := LatitudeExtremes(start, end, SphericalModel)
+	limit := maxLatitude
+	if north.Latitude <= maxLatitude {
+		if south.Latitude >= -maxLatitude {
+			leg := greatCircle(start, end)
+
+			return []CompositeLeg{leg}, leg.Distance, nil
+		}
+		limit = -maxLatitude
+	}
+
+	// the great circles touching the parallel have their vertices on it, where tan φ = tan φv ⋅ cos(λ - λv)
+	tanφv := math.Tan(limit.Radians())
+	Δλ := Wrap180(end.Longitude - start.Longitude).Radians()
+	east := 1.0
+	if Δλ < 0 {
+		east = -1
+	}
+	Δλ1 := math.Acos(math.Tan(start.Latitude.Radians()) / tanφv)
+	Δλ2 := math.Acos(math.Tan(end.Latitude.Radians()) / tanφv)
+	if Δλ1+Δλ2 > math.Abs(Δλ) {
+		// not reached unless rounding errors, as the great circle goes beyond the parallel
+		Δλ1 = math.Abs(Δλ) * Δλ1 / (Δλ1 + Δλ2)
+		Δλ2 = math.Abs(Δλ) - Δλ1
+	}
+
+	v1 := LatLon{Latitude: limit, Longitude: Wrap180(start.Longitude + DegreesFromRadians(east*Δλ1))}
+	v2 := LatLon{Latitude: limit, Longitude: Wrap180(end.Longitude - DegreesFromRadians(east*Δλ2))}
+
+	var legs []CompositeLeg
+	if !start.Equals(v1) {
+		legs = append(legs, greatCircle(start, v1))
+	}
+	if !v1.Equals(v2) {
+		rl := LatLonRhumb{ll: v1}
+		legs = append(legs, CompositeLeg{From: v1, To: v2, Bearing: rl.InitialBearingTo(v2), Distance: rl.DistanceTo(v2),
+			Parallel: true})
+	}
+	if !v2.Equals(end) {
+		legs = append(legs, greatCircle(v2, end))
+	}
+
+	var total float64
+	for _, leg := range legs {
+		total += float64(leg.Distance.Metre())
+	}
+
+	return legs, units.Metre(total), nil
+}
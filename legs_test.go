@@ -0,0 +1,168 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starboard-nz/units"
+)
+
+func TestGreatCircleAsRhumbLegs(t *testing.T) {
+	sydney := NewLatLon(-33.86, 151.21)
+	sanFrancisco := NewLatLon(37.77, -122.42)
+	n := GreatCircleNormal(sydney, sanFrancisco).Unit()
+
+	legs := GreatCircleAsRhumbLegs(sydney, sanFrancisco, units.NM(50))
+	require.Greater(t, len(legs), 1)
+	assert.Equal(t, sydney, legs[0].From)
+	assert.Equal(t, sanFrancisco, legs[len(legs)-1].To)
+
+	total := 0.0
+	for i, leg := range legs {
+		if i > 0 {
+			assert.Equal(t, legs[i-1].To, leg.From)
+		}
+		assert.InDelta(t, float64(InitialBearing(leg.From, leg.To, RhumbModel)), float64(leg.Bearing), 1e-9)
+		total += float64(leg.Distance.Metre())
+
+		// the legs stay within the tolerance
+		for f := 0.0; f <= 1; f += 0.05 {
+			p := RhumbModel(leg.From).IntermediatePointTo(leg.To, f)
+			d := math.Abs(math.Asin(p.ToNvector().Dot(n))) * EarthRadius()
+			assert.LessOrEqual(t, d, float64(units.NM(50).Metre())+1e-3)
+		}
+	}
+	gc := float64(Distance(sydney, sanFrancisco, SphericalModel).Metre())
+	assert.Greater(t, total, gc)
+	assert.Less(t, total, float64(Distance(sydney, sanFrancisco, RhumbModel).Metre()))
+
+	// a tighter tolerance needs more legs
+	assert.Greater(t, len(GreatCircleAsRhumbLegs(sydney, sanFrancisco, units.NM(5))), len(legs))
+
+	// along the equator and meridians the great circle is a rhumb line
+	assert.Len(t, GreatCircleAsRhumbLegs(NewLatLon(0, 10), NewLatLon(0, 100), units.Metre(1)), 1)
+	assert.Len(t, GreatCircleAsRhumbLegs(NewLatLon(-40, 10), NewLatLon(50, 10), units.Metre(1)), 1)
+
+	assert.Nil(t, GreatCircleAsRhumbLegs(sydney, sydney, units.NM(50)))
+	assert.Nil(t, GreatCircleAsRhumbLegs(NewLatLon(0, 0), NewLatLon(0, 180), units.NM(50)))
+	assert.Nil(t, GreatCircleAsRhumbLegs(sydney, sanFrancisco, units.Metre(0)))
+}
+
+func TestBearingProfile(t *testing.T) {
+	auckland := NewLatLon(-36.85, 174.76)
+	sanFrancisco := NewLatLon(37.62, -122.38)
+
+	for _, model := range []EarthModel{SphericalModel, VincentyModel, RhumbModel} {
+		profile := BearingProfile(auckland, sanFrancisco, 11, model)
+		require.Len(t, profile, 11)
+
+		total := Distance(auckland, sanFrancisco, model).Metre()
+		assert.Equal(t, auckland, profile[0].LatLon)
+		assert.Equal(t, sanFrancisco, profile[10].LatLon)
+		assert.InDelta(t, float64(InitialBearing(auckland, sanFrancisco, model)), float64(profile[0].Bearing), 1e-9)
+		assert.InDelta(t, float64(FinalBearing(auckland, sanFrancisco, model)), float64(profile[10].Bearing), 1e-9)
+		assert.InDelta(t, float64(total), float64(profile[10].Distance.Metre()), 1e-6)
+
+		for i := 1; i < 10; i++ {
+			p := profile[i]
+			assert.InDelta(t, float64(total)*float64(i)/10, float64(p.Distance.Metre()), 1e-6)
+			assert.InDelta(t, float64(p.Distance.Metre()), float64(Distance(auckland, p.LatLon, model).Metre()), 1)
+
+			// the bearing is the direction of the path, towards the next point
+			next := InitialBearing(p.LatLon, profile[i+1].LatLon, model)
+			assert.InDelta(t, 0, float64(Wrap180(p.Bearing-next)), 1e-6)
+		}
+	}
+
+	// the course of the great circle changes, the rhumb line's doesn't
+	gc := BearingProfile(auckland, sanFrancisco, 3, SphericalModel)
+	assert.Greater(t, float64(gc[0].Bearing-gc[1].Bearing), 10.0)
+	rl := BearingProfile(auckland, sanFrancisco, 3, RhumbModel)
+	assert.InDelta(t, float64(rl[0].Bearing), float64(rl[2].Bearing), 1e-9)
+
+	assert.Nil(t, BearingProfile(auckland, sanFrancisco, 1, SphericalModel))
+	assert.Nil(t, BearingProfile(auckland, auckland, 5, SphericalModel))
+}
+
+func TestCompositeGreatCircle(t *testing.T) {
+	capeTown := NewLatLon(-33.92, 18.42)
+	hobart := NewLatLon(-42.88, 147.33)
+
+	direct := Distance(capeTown, hobart, SphericalModel)
+	_, south := LatitudeExtremes(capeTown, hobart, SphericalModel)
+	require.Less(t, float64(south.Latitude), -50.0)
+
+	legs, dist, err := CompositeGreatCircle(capeTown, hobart, 50)
+	require.NoError(t, err)
+	require.Len(t, legs, 3)
+
+	assert.Equal(t, capeTown, legs[0].From)
+	assert.Equal(t, hobart, legs[2].To)
+	assert.Equal(t, legs[0].To, legs[1].From)
+	assert.Equal(t, legs[1].To, legs[2].From)
+	assert.False(t, legs[0].Parallel)
+	assert.True(t, legs[1].Parallel)
+	assert.False(t, legs[2].Parallel)
+
+	// along the parallel, which the great circles touch
+	assert.InDelta(t, -50, float64(legs[1].From.Latitude), 1e-9)
+	assert.InDelta(t, -50, float64(legs[1].To.Latitude), 1e-9)
+	assert.InDelta(t, 90, float64(legs[1].Bearing), 1e-9)
+	assert.InDelta(t, 90, float64(FinalBearing(capeTown, legs[0].To, SphericalModel)), 1e-6)
+	assert.InDelta(t, 90, float64(legs[2].Bearing), 1e-6)
+	for _, leg := range []CompositeLeg{legs[0], legs[2]} {
+		_, south := LatitudeExtremes(leg.From, leg.To, SphericalModel)
+		assert.GreaterOrEqual(t, float64(south.Latitude), -50-1e-6)
+	}
+
+	var total float64
+	for _, leg := range legs {
+		total += float64(leg.Distance.Metre())
+	}
+	assert.InDelta(t, total, float64(dist.Metre()), 1e-6)
+	assert.Greater(t, float64(dist.Metre()), float64(direct.Metre()))
+
+	// westbound in the northern hemisphere
+	sanFrancisco := NewLatLon(37.77, -122.42)
+	yokohama := NewLatLon(35.44, 139.64)
+	legs, _, err = CompositeGreatCircle(sanFrancisco, yokohama, 45)
+	require.NoError(t, err)
+	require.Len(t, legs, 3)
+	assert.InDelta(t, 45, float64(legs[1].From.Latitude), 1e-9)
+	assert.InDelta(t, 270, float64(legs[1].Bearing), 1e-9)
+	assert.InDelta(t, 270, float64(legs[2].Bearing), 1e-6)
+
+	// the great circle doesn't reach the limit
+	legs, dist, err = CompositeGreatCircle(capeTown, hobart, 70)
+	require.NoError(t, err)
+	require.Len(t, legs, 1)
+	assert.False(t, legs[0].Parallel)
+	assert.InDelta(t, float64(direct.Metre()), float64(dist.Metre()), 1e-6)
+
+	// starting on the parallel
+	legs, _, err = CompositeGreatCircle(NewLatLon(-50, 18.42), hobart, 50)
+	require.NoError(t, err)
+	require.Len(t, legs, 2)
+	assert.True(t, legs[0].Parallel)
+
+	legs, dist, err = CompositeGreatCircle(capeTown, capeTown, 50)
+	require.NoError(t, err)
+	assert.Empty(t, legs)
+	assert.Equal(t, 0.0, float64(dist.Metre()))
+
+	_, _, err = CompositeGreatCircle(capeTown, hobart, 40)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, _, err = CompositeGreatCircle(capeTown, hobart, 90)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+	_, _, err = CompositeGreatCircle(NewLatLon(10, 20), NewLatLon(-10, -160), 50)
+	assert.ErrorIs(t, err, ErrInvalidArgument)
+}
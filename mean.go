@@ -0,0 +1,105 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	// meanMaxIterations limits the number of refinement steps of MeanPosition
+	meanMaxIterations = 100
+	// meanTolerance is the size of a refinement step, in metres, below which MeanPosition stops
+	meanTolerance = 1e-4
+)
+
+// MeanPosition returns the weighted mean position of the points.
+//
+// Arguments:
+//
+// points - the positions to average
+// weights - the weight of each point, or nil for equal weights. Weights must not be negative.
+// model - if nil, the spherical (vector) mean is returned: the direction of the weighted sum of the unit vectors of
+//
+//	the points. Otherwise the vector mean is refined iteratively to the point that minimises the weighted sum of the
+//	squared distances to the points using the `model` (the geodesic or Karcher mean). The model must implement
+//	DestinationPoint.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// Both means work across the antimeridian and near the poles. They are only defined if the points are not spread
+// around the globe; ErrUndefinedMean is returned if the vector sum is (close to) zero, for example for 2 antipodal
+// points, or if there are no points with a positive weight. ErrInvalidWeights is returned if the number of weights
+// doesn't match the number of points, or any weight is negative or NaN.
+//
+// Example:
+// mean, err := geod.MeanPosition(points, nil, geod.SphericalModel)
+func MeanPosition(points []LatLon, weights []float64, model EarthModel, modelArgs ...interface{}) (LatLon, error) {
+	invalid := LatLon{Latitude: Degrees(math.NaN()), Longitude: Degrees(math.NaN())}
+
+	if weights != nil && len(weights) != len(points) {
+		return invalid, fmt.Errorf("%w: %d weights for %d points", ErrInvalidWeights, len(weights), len(points))
+	}
+
+	weight := func(i int) float64 {
+		if weights == nil {
+			return 1
+		}
+
+		return weights[i]
+	}
+
+	var sum Vector3D
+	total := 0.0
+	for i, p := range points {
+		w := weight(i)
+		if !(w >= 0) {
+			return invalid, fmt.Errorf("%w: weight %d is %v", ErrInvalidWeights, i, w)
+		}
+
+		sum = sum.Plus(p.ToNvector().Times(w))
+		total += w
+	}
+
+	if total == 0 || sum.Length() < 1e-12*total {
+		return invalid, ErrUndefinedMean
+	}
+
+	mean := NvectorToLatLon(sum)
+	if model == nil {
+		return mean, nil
+	}
+
+	// fixed point iteration: move by the weighted mean of the vectors (distance and bearing) to the points
+	for it := 0; it < meanMaxIterations; it++ {
+		m := model(mean, modelArgs...)
+		east, north := 0.0, 0.0
+		for i, p := range points {
+			w := weight(i)
+			if w == 0 || mean.Equals(p) {
+				continue
+			}
+
+			d := float64(m.DistanceTo(p).Metre())
+			θ := m.InitialBearingTo(p).Radians()
+			east += w * d * math.Sin(θ)
+			north += w * d * math.Cos(θ)
+		}
+
+		step := math.Hypot(east, north) / total
+		if !(step >= meanTolerance) {
+			break
+		}
+
+		mean = m.DestinationPoint(step, DegreesFromRadians(math.Atan2(east, north)))
+	}
+
+	return mean, nil
+}
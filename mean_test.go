@@ -0,0 +1,62 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMeanPosition(t *testing.T) {
+	// straddling the antimeridian
+	points := []LatLon{NewLatLon(-17, 179), NewLatLon(-17, -179), NewLatLon(-18, 179.5), NewLatLon(-18, -179.5)}
+	mean, err := MeanPosition(points, nil, nil)
+	require.NoError(t, err)
+	assert.InDelta(t, 180, float64(Wrap360(mean.Longitude)), 1e-9)
+	assert.InDelta(t, -17.5, float64(mean.Latitude), 0.01)
+
+	// weights pull the mean towards the heavier point
+	mean, err = MeanPosition(points[:2], []float64{3, 1}, nil)
+	require.NoError(t, err)
+	assert.InDelta(t, 179.5, float64(mean.Longitude), 0.01)
+
+	// the refined mean minimises the sum of squared distances
+	points = []LatLon{NewLatLon(0, 0), NewLatLon(0, 10), NewLatLon(60, 5)}
+	vector, err := MeanPosition(points, nil, nil)
+	require.NoError(t, err)
+	geodesic, err := MeanPosition(points, nil, SphericalModel)
+	require.NoError(t, err)
+
+	sumSq := func(m LatLon) float64 {
+		s := 0.0
+		for _, p := range points {
+			d := float64(SphericalModel(m).DistanceTo(p).Metre())
+			s += d * d
+		}
+
+		return s
+	}
+	assert.Less(t, sumSq(geodesic), sumSq(vector))
+	for _, b := range []Degrees{0, 90, 180, 270} {
+		assert.Less(t, sumSq(geodesic), sumSq(SphericalModel(geodesic).DestinationPoint(100, b)))
+	}
+
+	// with an ellipsoid model
+	_, err = MeanPosition(points, nil, VincentyModel, WGS84())
+	require.NoError(t, err)
+
+	_, err = MeanPosition([]LatLon{NewLatLon(0, 0), NewLatLon(0, 180)}, nil, nil)
+	assert.ErrorIs(t, err, ErrUndefinedMean)
+	_, err = MeanPosition(nil, nil, nil)
+	assert.ErrorIs(t, err, ErrUndefinedMean)
+	_, err = MeanPosition(points, []float64{1, 2}, nil)
+	assert.ErrorIs(t, err, ErrInvalidWeights)
+	_, err = MeanPosition(points, []float64{1, -2, 1}, nil)
+	assert.ErrorIs(t, err, ErrInvalidWeights)
+}
@@ -43,6 +43,35 @@ func (mp MercatorPoint) LatLon() LatLon {
 	return LatLon{Latitude: lat, Longitude: lon}
 }
 
+// ToPixel converts the point to pixel coordinates of the Web Mercator tile pyramid at the given zoom level, with
+// `tileSize` pixels per tile (usually 256). The origin is the top left (north-west) corner of the world, and pixel
+// Y coordinates increase to the south, as for image rows. The coordinates are not rounded, so that fractional pixel
+// positions can be used for anti-aliased rendering. The tile containing the pixel is (⌊x / tileSize⌋, ⌊y / tileSize⌋).
+func (mp MercatorPoint) ToPixel(zoom, tileSize int) (float64, float64) {
+	mapSize := float64(tileSize) * math.Exp2(float64(zoom))
+
+	return mp.X * mapSize, (1 - mp.Y) * mapSize
+}
+
+// MercatorPointFromPixel converts pixel coordinates at the given zoom level to a MercatorPoint.
+// This is the inverse of ToPixel.
+func MercatorPointFromPixel(x, y float64, zoom, tileSize int) MercatorPoint {
+	mapSize := float64(tileSize) * math.Exp2(float64(zoom))
+
+	return MercatorPoint{X: x / mapSize, Y: 1 - y/mapSize}
+}
+
+// Tile returns the X and Y coordinates of the Web Mercator tile containing the point at the given zoom level.
+// Points on the east or south edge of the world are in the last tile.
+func (mp MercatorPoint) Tile(zoom int) (int, int) {
+	n := 1 << zoom
+	clamp := func(v float64) int {
+		return int(math.Max(0, math.Min(float64(n-1), math.Floor(v))))
+	}
+
+	return clamp(mp.X * float64(n)), clamp((1 - mp.Y) * float64(n))
+}
+
 func MultiPolygonToMercator(mp orb.MultiPolygon) orb.MultiPolygon {
 	return nil
 }
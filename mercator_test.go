@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	geod "github.com/starboard-nz/go-geodesy"
 )
@@ -80,3 +81,49 @@ func BenchmarkInverseMercator(b *testing.B) {
 		_ = testPoints[n%N].LatLon()
 	}
 }
+
+func TestMercatorPixel(t *testing.T) {
+	mp := geod.LatLon{Latitude: 0, Longitude: 0}.MercatorPoint()
+	x, y := mp.ToPixel(0, 256)
+	assert.InDelta(t, 128, x, 1e-9)
+	assert.InDelta(t, 128, y, 1e-9)
+
+	// Auckland at zoom 10
+	mp = geod.LatLon{Latitude: -36.8485, Longitude: 174.7633}.MercatorPoint()
+	x, y = mp.ToPixel(10, 256)
+	assert.InDelta(t, 258330.75, x, 0.01)
+	assert.InDelta(t, 159971.68, y, 0.01)
+
+	tx, ty := mp.Tile(10)
+	assert.Equal(t, 1009, tx)
+	assert.Equal(t, 624, ty)
+
+	back := geod.MercatorPointFromPixel(x, y, 10, 256)
+	assert.InDelta(t, mp.X, back.X, 1e-12)
+	assert.InDelta(t, mp.Y, back.Y, 1e-12)
+
+	// corners of the world
+	tx, ty = geod.LatLon{Latitude: -geod.MercatorMaxLat, Longitude: 180}.MercatorPoint().Tile(3)
+	assert.Equal(t, 7, tx)
+	assert.Equal(t, 7, ty)
+}
+
+func TestQuadKey(t *testing.T) {
+	assert.Equal(t, "213", geod.QuadKey(3, 5, 3))
+	assert.Equal(t, "", geod.QuadKey(0, 0, 0))
+	assert.Equal(t, "", geod.QuadKey(8, 0, 3))
+
+	x, y, zoom, err := geod.ParseQuadKey("213")
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 5, 3}, []int{x, y, zoom})
+
+	_, _, _, err = geod.ParseQuadKey("2143")
+	assert.ErrorIs(t, err, geod.ErrInvalidQuadKey)
+	var pe *geod.ParseError
+	require.ErrorAs(t, err, &pe)
+	assert.Equal(t, 2, pe.Pos)
+
+	mp := geod.LatLon{Latitude: -36.8485, Longitude: 174.7633}.MercatorPoint()
+	assert.Equal(t, "3113", mp.QuadKey(4))
+	assert.Equal(t, geod.QuadKey(1009, 624, 10), mp.QuadKey(10))
+}
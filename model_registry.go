@@ -0,0 +1,87 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+var (
+	modelsMutex sync.RWMutex
+	models      = map[string]EarthModel{
+		"spherical": SphericalModel,
+		"rhumb":     RhumbModel,
+		"vincenty":  VincentyModel,
+		"planar":    PlanarModel,
+	}
+)
+
+// normalizeModelName returns the key of the model `name` in the registry, names are not case sensitive
+func normalizeModelName(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// RegisterModel registers the model function `m` with the given `name`, so that it can be selected with ModelByName,
+// for example from configuration. Names are not case sensitive. Registering a name again replaces the model, so the
+// built-in models ("spherical", "rhumb", "vincenty" and "planar") can be replaced too.
+//
+// Panics with an error wrapping ErrInvalidArgument if the name is empty or the model is nil.
+//
+// Example:
+// geod.RegisterModel("karney", karney.Model)
+func RegisterModel(name string, m EarthModel) {
+	key := normalizeModelName(name)
+	if key == "" {
+		panic(fmt.Errorf("%w: empty model name", ErrInvalidArgument))
+	}
+	if m == nil {
+		panic(fmt.Errorf("%w: nil model %q", ErrInvalidArgument, name))
+	}
+
+	modelsMutex.Lock()
+	defer modelsMutex.Unlock()
+
+	models[key] = m
+}
+
+// ModelByName returns the model registered with the given `name`, see RegisterModel. Returns an error wrapping
+// ErrUnknownModel if there is no such model.
+//
+// Example:
+// model, err := geod.ModelByName(config.Model)
+// if err != nil {
+// ... handle the error
+// }
+// dist := geod.Distance(p1, p2, model)
+func ModelByName(name string) (EarthModel, error) {
+	modelsMutex.RLock()
+	defer modelsMutex.RUnlock()
+
+	m, ok := models[normalizeModelName(name)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownModel, name)
+	}
+
+	return m, nil
+}
+
+// ModelNames returns the names of the registered models, sorted.
+func ModelNames() []string {
+	modelsMutex.RLock()
+	defer modelsMutex.RUnlock()
+
+	names := make([]string, 0, len(models))
+	for name := range models {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
@@ -0,0 +1,38 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestModelRegistry(t *testing.T) {
+	p1 := NewLatLon(52.205, 0.119)
+	p2 := NewLatLon(48.857, 2.351)
+
+	m, err := ModelByName("Vincenty")
+	require.NoError(t, err)
+	assert.Equal(t, Distance(p1, p2, VincentyModel), Distance(p1, p2, m))
+
+	_, err = ModelByName("karney")
+	assert.ErrorIs(t, err, ErrUnknownModel)
+
+	// a custom model, on a larger sphere
+	RegisterModel(" Big-Sphere ", func(ll LatLon, modelArgs ...interface{}) Model {
+		return SphericalModel(ll, Radius(2*EarthRadius()))
+	})
+	m, err = ModelByName("big-sphere")
+	require.NoError(t, err)
+	assert.InDelta(t, 2*float64(Distance(p1, p2, SphericalModel).Metre()), float64(Distance(p1, p2, m).Metre()), 1e-6)
+	assert.Equal(t, []string{"big-sphere", "planar", "rhumb", "spherical", "vincenty"}, ModelNames())
+
+	assert.PanicsWithError(t, "invalid argument: empty model name", func() { RegisterModel(" ", SphericalModel) })
+	assert.PanicsWithError(t, `invalid argument: nil model "nil"`, func() { RegisterModel("nil", nil) })
+}
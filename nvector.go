@@ -0,0 +1,56 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+)
+
+// ToNvector returns the n-vector of the point: the unit vector normal to the surface of a spherical Earth at the
+// point, in the same x/y/z axes as Cartesian (x towards 0°N 0°E, z towards the North Pole).
+//
+// See www.movable-type.co.uk/scripts/latlong-vectors.html for calculations with n-vectors.
+func (ll LatLon) ToNvector() Vector3D {
+	sinφ, cosφ := math.Sincos(ll.Latitude.Radians())
+	sinλ, cosλ := math.Sincos(ll.Longitude.Radians())
+
+	return Vector3D{X: cosφ * cosλ, Y: cosφ * sinλ, Z: sinφ}
+}
+
+// NvectorToLatLon returns the point with the n-vector `v`. The vector doesn't need to be a unit vector, so it can be
+// used for example with a sum of n-vectors. Returns an invalid point if the vector has zero length.
+func NvectorToLatLon(v Vector3D) LatLon {
+	if v.Length() == 0 {
+		return LatLon{Latitude: Degrees(math.NaN()), Longitude: Degrees(math.NaN())}
+	}
+
+	return LatLon{
+		Latitude:  DegreesFromRadians(math.Atan2(v.Z, math.Hypot(v.X, v.Y))),
+		Longitude: DegreesFromRadians(math.Atan2(v.Y, v.X)),
+	}
+}
+
+// GreatCircleNormal returns the unit normal of the great circle through `p1` and `p2`, pointing to the left of the
+// direction of travel from `p1` to `p2`. Returns a zero vector if the points are the same or antipodal, as the great
+// circle is not defined.
+func GreatCircleNormal(p1, p2 LatLon) Vector3D {
+	return p1.ToNvector().Cross(p2.ToNvector()).Unit()
+}
+
+// GreatCircleFromBearing returns the unit normal of the great circle through `ll` heading on `bearing`, pointing to
+// the left of the direction of travel.
+func GreatCircleFromBearing(ll LatLon, bearing Degrees) Vector3D {
+	sinφ, cosφ := math.Sincos(ll.Latitude.Radians())
+	sinλ, cosλ := math.Sincos(ll.Longitude.Radians())
+	sinθ, cosθ := math.Sincos(bearing.Radians())
+
+	return Vector3D{
+		X: sinλ*cosθ - sinφ*cosλ*sinθ,
+		Y: -cosλ*cosθ - sinφ*sinλ*sinθ,
+		Z: cosφ * sinθ,
+	}
+}
@@ -0,0 +1,36 @@
+package geod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNvector(t *testing.T) {
+	assertVectorInDelta(t, Vector3D{1, 0, 0}, NewLatLon(0, 0).ToNvector(), 1e-15)
+	assertVectorInDelta(t, Vector3D{0, 1, 0}, NewLatLon(0, 90).ToNvector(), 1e-15)
+	assertVectorInDelta(t, Vector3D{0, 0, 1}, NewLatLon(90, 0).ToNvector(), 1e-15)
+
+	for _, ll := range []LatLon{NewLatLon(-41.29, 174.78), NewLatLon(51.47, -0.0015), NewLatLon(-89, -179)} {
+		assert.InDelta(t, 1, ll.ToNvector().Length(), 1e-15)
+		back := NvectorToLatLon(ll.ToNvector().Times(3))
+		assert.InDelta(t, float64(ll.Latitude), float64(back.Latitude), 1e-12)
+		assert.InDelta(t, float64(ll.Longitude), float64(back.Longitude), 1e-12)
+	}
+
+	assert.False(t, NvectorToLatLon(Vector3D{}).Valid())
+}
+
+func TestGreatCircleNormal(t *testing.T) {
+	// heading East along the equator, the normal points North
+	assertVectorInDelta(t, Vector3D{0, 0, 1}, GreatCircleNormal(NewLatLon(0, 0), NewLatLon(0, 10)), 1e-15)
+	assertVectorInDelta(t, Vector3D{0, 0, 1}, GreatCircleFromBearing(NewLatLon(0, 0), 90), 1e-15)
+	assertVectorInDelta(t, Vector3D{0, -1, 0}, GreatCircleFromBearing(NewLatLon(0, 0), 0), 1e-15)
+
+	p1 := NewLatLon(-41.29, 174.78)
+	p2 := NewLatLon(-33.87, 151.21)
+	bearing := InitialBearing(p1, p2, SphericalModel)
+	assertVectorInDelta(t, GreatCircleNormal(p1, p2), GreatCircleFromBearing(p1, bearing), 1e-12)
+
+	assert.Equal(t, Vector3D{}, GreatCircleNormal(p1, p1))
+}
@@ -0,0 +1,121 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+)
+
+// Position is a reported position, for example a GNSS fix or a position report of a vessel, with the attributes that
+// usually come with it.
+type Position struct {
+	LatLon
+	// Height is the height in metres, relative to whatever surface the source uses, 0 if unknown
+	Height float64
+	// Time is when the position was recorded, the zero time if unknown
+	Time time.Time
+	// Accuracy is the horizontal accuracy in metres (the radius of the uncertainty), 0 if unknown
+	Accuracy float64
+}
+
+// NewPosition returns a `Position` structure with the given latitude, longitude and time
+func NewPosition(latitude, longitude float64, t time.Time) Position {
+	return Position{LatLon: NewLatLon(latitude, longitude), Time: t}
+}
+
+// Position3D returns the position and height of `p` as a `Position3D`
+func (p Position) Position3D() Position3D {
+	return Position3D{LatLon: p.LatLon, Height: p.Height}
+}
+
+// Validate returns an error if the position is not valid: the latitude must be within -90°..90°, the longitude within
+// -180°..180°, the height finite and the accuracy not negative. The errors wrap ErrInvalidLatitude,
+// ErrInvalidLongitude, ErrInvalidHeight or ErrInvalidAccuracy.
+func (p Position) Validate() error {
+	switch {
+	case !(p.Latitude >= -90 && p.Latitude <= 90):
+		return fmt.Errorf("%w: %v", ErrInvalidLatitude, float64(p.Latitude))
+	case !(p.Longitude >= -180 && p.Longitude <= 180):
+		return fmt.Errorf("%w: %v", ErrInvalidLongitude, float64(p.Longitude))
+	case math.IsNaN(p.Height) || math.IsInf(p.Height, 0):
+		return fmt.Errorf("%w: %v", ErrInvalidHeight, p.Height)
+	case !(p.Accuracy >= 0) || math.IsInf(p.Accuracy, 1):
+		return fmt.Errorf("%w: %v", ErrInvalidAccuracy, p.Accuracy)
+	}
+
+	return nil
+}
+
+// Valid returns true if the position is valid, see Validate.
+func (p Position) Valid() bool {
+	return p.Validate() == nil
+}
+
+// positionJSON is the JSON representation of a Position
+type positionJSON struct {
+	Latitude  *float64   `json:"lat"`
+	Longitude *float64   `json:"lon"`
+	Height    float64    `json:"height,omitempty"`
+	Time      *time.Time `json:"time,omitempty"`
+	Accuracy  float64    `json:"accuracy,omitempty"`
+}
+
+// MarshalJSON implements json.Marshaler. The position is encoded as an object with "lat" and "lon" in degrees,
+// and "height" (metres), "time" (RFC 3339) and "accuracy" (metres) if known:
+//
+//	{"lat":-41.29,"lon":174.78,"time":"2026-03-01T12:00:00Z","accuracy":5}
+//
+// Invalid positions can't be encoded, see Validate.
+func (p Position) MarshalJSON() ([]byte, error) {
+	if err := p.Validate(); err != nil {
+		return nil, err
+	}
+
+	lat, lon := float64(p.Latitude), float64(p.Longitude)
+	pj := positionJSON{Latitude: &lat, Longitude: &lon, Height: p.Height, Accuracy: p.Accuracy}
+	if !p.Time.IsZero() {
+		pj.Time = &p.Time
+	}
+
+	return json.Marshal(pj)
+}
+
+// UnmarshalJSON implements json.Unmarshaler, see MarshalJSON for the format. "lat" and "lon" are required, and the
+// position must be valid.
+func (p *Position) UnmarshalJSON(data []byte) error {
+	var pj positionJSON
+	if err := json.Unmarshal(data, &pj); err != nil {
+		return err
+	}
+
+	if pj.Latitude == nil {
+		return fmt.Errorf("%w: missing", ErrInvalidLatitude)
+	}
+	if pj.Longitude == nil {
+		return fmt.Errorf("%w: missing", ErrInvalidLongitude)
+	}
+
+	pos := Position{
+		LatLon:   NewLatLon(*pj.Latitude, *pj.Longitude),
+		Height:   pj.Height,
+		Accuracy: pj.Accuracy,
+	}
+	if pj.Time != nil {
+		pos.Time = *pj.Time
+	}
+
+	if err := pos.Validate(); err != nil {
+		return err
+	}
+
+	*p = pos
+
+	return nil
+}
@@ -0,0 +1,109 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+)
+
+// Position3D represents a point above (or below) the surface of the Earth, for example of an aircraft or a drone,
+// with its Height in metres. Unlike LatLonEllipsoidal it is not tied to an ellipsoid, the height is relative to
+// whatever surface the model uses.
+type Position3D struct {
+	LatLon
+	Height float64
+}
+
+// NewPosition3D returns a `Position3D` structure with the given latitude, longitude and height
+func NewPosition3D(latitude, longitude, height float64) Position3D {
+	return Position3D{LatLon: NewLatLon(latitude, longitude), Height: height}
+}
+
+// International Standard Atmosphere, up to the top of the lower stratosphere
+const (
+	isaSeaLevelTemperature = 288.15                                              // K
+	isaLapseRate           = 0.0065                                              // K/m, in the troposphere
+	isaTropopause          = 11000.0                                             // m
+	isaTropopauseTemp      = isaSeaLevelTemperature - isaLapseRate*isaTropopause // K, constant above the tropopause
+	isaExponent            = 9.80665 * 0.0289644 / (8.3144598 * isaLapseRate)    // g⋅M / (R⋅L)
+)
+
+// isaPressureRatio returns the ratio of the air pressure at `height` to the pressure at sea level in the ISA
+func isaPressureRatio(height float64) float64 {
+	if height <= isaTropopause {
+		return math.Pow(1-isaLapseRate*height/isaSeaLevelTemperature, isaExponent)
+	}
+
+	ratio := math.Pow(isaTropopauseTemp/isaSeaLevelTemperature, isaExponent)
+
+	return ratio * math.Exp(-isaExponent*isaLapseRate*(height-isaTropopause)/isaTropopauseTemp)
+}
+
+// isaHeight returns the height where the ratio of the air pressure to the pressure at sea level is `ratio` in the ISA
+func isaHeight(ratio float64) float64 {
+	tropopauseRatio := math.Pow(isaTropopauseTemp/isaSeaLevelTemperature, isaExponent)
+	if ratio >= tropopauseRatio {
+		return (1 - math.Pow(ratio, 1/isaExponent)) * isaSeaLevelTemperature / isaLapseRate
+	}
+
+	return isaTropopause - math.Log(ratio/tropopauseRatio)*isaTropopauseTemp/(isaExponent*isaLapseRate)
+}
+
+// IntermediatePointTo returns the position at `fraction` of the path from `p` to `dest`. The horizontal position
+// follows the surface path of the given `model` (see IntermediatePoint), and the height changes linearly with the
+// fraction, i.e. the path climbs or descends at a constant rate over the distance.
+//
+// Arguments:
+//
+// dest - destination position
+// fraction - fraction of the path to take, 0..1
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// Example:
+// p1 := geod.NewPosition3D(-41.33, 174.81, 0)
+// p2 := geod.NewPosition3D(-43.49, 172.53, 10000)
+// p := p1.IntermediatePointTo(p2, 0.25, geod.SphericalModel) // 2500m high
+func (p Position3D) IntermediatePointTo(dest Position3D, fraction float64, model EarthModel,
+	modelArgs ...interface{}) Position3D {
+	return Position3D{
+		LatLon: p.intermediateLatLon(dest, fraction, model, modelArgs...),
+		Height: p.Height + (dest.Height-p.Height)*fraction,
+	}
+}
+
+// IntermediatePointByPressureTo returns the same as IntermediatePointTo, except that the air pressure changes
+// linearly with the fraction rather than the height, using the International Standard Atmosphere (up to 20km).
+// Heights are treated as pressure altitudes, so the height changes more slowly at lower altitudes, where the pressure
+// changes faster.
+func (p Position3D) IntermediatePointByPressureTo(dest Position3D, fraction float64, model EarthModel,
+	modelArgs ...interface{}) Position3D {
+	r1 := isaPressureRatio(p.Height)
+	r2 := isaPressureRatio(dest.Height)
+
+	return Position3D{
+		LatLon: p.intermediateLatLon(dest, fraction, model, modelArgs...),
+		Height: isaHeight(r1 + (r2-r1)*fraction),
+	}
+}
+
+// intermediateLatLon returns the point at `fraction` of the surface path to `dest`
+func (p Position3D) intermediateLatLon(dest Position3D, fraction float64, model EarthModel,
+	modelArgs ...interface{}) LatLon {
+	// the bearing between identical points is undefined, so VincentyModel would return NaN
+	if p.LatLon.Equals(dest.LatLon) {
+		return p.LatLon
+	}
+
+	return IntermediatePoint(p.LatLon, dest.LatLon, fraction, model, modelArgs...)
+}
@@ -0,0 +1,41 @@
+package geod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestISAPressure(t *testing.T) {
+	assert.Equal(t, 1.0, isaPressureRatio(0))
+	// standard values, from 1013.25 hPa at sea level
+	assert.InDelta(t, 795.0/1013.25, isaPressureRatio(2000), 0.001)
+	assert.InDelta(t, 226.32/1013.25, isaPressureRatio(11000), 0.001)
+	assert.InDelta(t, 54.75/1013.25, isaPressureRatio(20000), 0.001)
+
+	for _, h := range []float64{-100, 0, 1500, 10999, 11000, 15000, 20000} {
+		assert.InDelta(t, h, isaHeight(isaPressureRatio(h)), 1e-6)
+	}
+}
+
+func TestPosition3DIntermediatePointTo(t *testing.T) {
+	p1 := NewPosition3D(-41.33, 174.81, 0)
+	p2 := NewPosition3D(-43.49, 172.53, 10000)
+
+	p := p1.IntermediatePointTo(p2, 0.25, SphericalModel)
+	assert.Equal(t, 2500.0, p.Height)
+	assert.Equal(t, IntermediatePoint(p1.LatLon, p2.LatLon, 0.25, SphericalModel), p.LatLon)
+
+	assert.Equal(t, p1, p1.IntermediatePointTo(p2, 0, VincentyModel))
+
+	// vertical paths
+	up := NewPosition3D(-41.33, 174.81, 1000)
+	assert.Equal(t, NewPosition3D(-41.33, 174.81, 500), p1.IntermediatePointTo(up, 0.5, VincentyModel))
+
+	pp := p1.IntermediatePointByPressureTo(p2, 0.25, SphericalModel)
+	assert.Equal(t, p.LatLon, pp.LatLon)
+	assert.Less(t, pp.Height, p.Height)
+	assert.Greater(t, pp.Height, 0.0)
+	assert.InDelta(t, 0, p1.IntermediatePointByPressureTo(p2, 0, SphericalModel).Height, 1e-6)
+	assert.InDelta(t, 10000, p1.IntermediatePointByPressureTo(p2, 1, SphericalModel).Height, 1e-6)
+}
@@ -0,0 +1,74 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPositionValidate(t *testing.T) {
+	p := NewPosition(-41.29, 174.78, time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC))
+	assert.NoError(t, p.Validate())
+	assert.True(t, p.Valid())
+	assert.Equal(t, Position3D{LatLon: p.LatLon}, p.Position3D())
+
+	for _, tc := range []struct {
+		modify func(p *Position)
+		err    error
+	}{
+		{func(p *Position) { p.Latitude = 91 }, ErrInvalidLatitude},
+		{func(p *Position) { p.Latitude = Degrees(math.NaN()) }, ErrInvalidLatitude},
+		{func(p *Position) { p.Longitude = -180.5 }, ErrInvalidLongitude},
+		{func(p *Position) { p.Height = math.Inf(1) }, ErrInvalidHeight},
+		{func(p *Position) { p.Accuracy = -1 }, ErrInvalidAccuracy},
+		{func(p *Position) { p.Accuracy = math.NaN() }, ErrInvalidAccuracy},
+	} {
+		invalid := p
+		tc.modify(&invalid)
+		assert.ErrorIs(t, invalid.Validate(), tc.err)
+		assert.False(t, invalid.Valid())
+	}
+}
+
+func TestPositionJSON(t *testing.T) {
+	p := Position{
+		LatLon:   NewLatLon(-41.29, 174.78),
+		Height:   12.5,
+		Time:     time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Accuracy: 5,
+	}
+
+	data, err := json.Marshal(p)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"lat":-41.29,"lon":174.78,"height":12.5,"time":"2026-03-01T12:00:00Z","accuracy":5}`, string(data))
+
+	var decoded Position
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.True(t, p.Time.Equal(decoded.Time))
+	decoded.Time = p.Time
+	assert.Equal(t, p, decoded)
+
+	// unknown attributes are omitted
+	data, err = json.Marshal(Position{LatLon: NewLatLon(0, 0)})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"lat":0,"lon":0}`, string(data))
+	require.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, Position{LatLon: NewLatLon(0, 0)}, decoded)
+
+	_, err = json.Marshal(Position{LatLon: NewLatLon(100, 0)})
+	assert.ErrorIs(t, err, ErrInvalidLatitude)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"lon":1}`), &decoded), ErrInvalidLatitude)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"lat":1}`), &decoded), ErrInvalidLongitude)
+	assert.ErrorIs(t, json.Unmarshal([]byte(`{"lat":1,"lon":2,"accuracy":-3}`), &decoded), ErrInvalidAccuracy)
+	assert.Error(t, json.Unmarshal([]byte(`{"lat":"1"}`), &decoded))
+}
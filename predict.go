@@ -0,0 +1,90 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"time"
+
+	"github.com/starboard-nz/units"
+)
+
+// predictionStep is the maximum change of course, in degrees, of the steps of PredictPosition
+const predictionStep = 1
+
+// DegreesPerMinute is a rate of turn, for example as reported by AIS, positive turning right (clockwise).
+type DegreesPerMinute float64
+
+// PredictPosition returns the position and the course over ground after `dt`, for a vessel or aircraft at `p`
+// moving at the constant speed `sog`, with the course `cog` changing at the constant rate of turn `rot`, for example
+// to predict the position of a manoeuvring target from AIS reports. With a rate of turn of 0 this is dead reckoning
+// along the path of the model.
+//
+// The motion is integrated in steps along the paths of the model, turning at most 1° each step, so the target turns
+// at the constant rate relative to the paths of the model (great circles for SphericalModel, geodesics for
+// VincentyModel, rhumb lines for RhumbModel), and follows a circle of radius sog/rot.
+//
+// Arguments:
+//
+// p - the current position
+// sog - speed over ground
+// cog - course over ground, in `Degrees` from North
+// rot - rate of turn, positive turning right
+// dt - the time to predict the position after, negative for the position before
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions. The model must implement DestinationPoint, so PlanarModel can't be used.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// Example:
+// // 12 knots, turning right at 10°/min, in 3 minutes
+// p, cog := geod.PredictPosition(p, units.Knot(12), 45, 10, 3*time.Minute, geod.SphericalModel) // cog 75°
+func PredictPosition(p LatLon, sog units.Speed, cog Degrees, rot DegreesPerMinute, dt time.Duration,
+	model EarthModel, modelArgs ...interface{}) (LatLon, Degrees) {
+	if dt < 0 {
+		// backwards is forwards on the reverse course, turning the other way
+		q, course := PredictPosition(p, sog, Wrap360(cog+180), -rot, -dt, model, modelArgs...)
+
+		return q, Wrap360(course + 180)
+	}
+
+	s := float64(sog.Mps()) * dt.Seconds()
+	turn := float64(rot) * dt.Minutes()
+
+	n := int(math.Ceil(math.Abs(turn) / predictionStep))
+	if n < 1 {
+		n = 1
+	}
+
+	// each step is the chord of the arc turning by `Δθ`, in the direction of the middle of the arc
+	Δθ := turn / float64(n)
+	chord := s / float64(n)
+	if Δθ != 0 {
+		half := Degrees(Δθ / 2).Radians()
+		chord *= math.Sin(half) / half
+	}
+
+	course := cog
+	for i := 0; i < n; i++ {
+		if chord == 0 {
+			course += Degrees(Δθ)
+
+			continue
+		}
+
+		m := model(p, modelArgs...)
+		q := m.DestinationPoint(chord, Wrap360(course+Degrees(Δθ/2)))
+		course = m.FinalBearingOn(q) + Degrees(Δθ/2)
+		p = q
+	}
+
+	return p, Wrap360(course)
+}
@@ -0,0 +1,60 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/starboard-nz/units"
+)
+
+func TestPredictPosition(t *testing.T) {
+	p := NewLatLon(-41.29, 174.78)
+	sog := units.Knot(12)
+
+	// dead reckoning
+	for _, model := range []EarthModel{SphericalModel, RhumbModel, VincentyModel} {
+		q, cog := PredictPosition(p, sog, 45, 0, time.Hour, model)
+		expected := model(p).DestinationPoint(float64(units.NM(12).Metre()), 45)
+		assert.InDelta(t, 0, float64(q.DistanceTo(expected, model).Metre()), 1e-6)
+		assert.InDelta(t, float64(model(p).FinalBearingOn(expected)), float64(cog), 1e-9)
+	}
+
+	// turning right at 10°/min for 3 minutes, the course also changes with the convergence of the meridians
+	q, cog := PredictPosition(p, sog, 45, 10, 3*time.Minute, SphericalModel)
+	assert.InDelta(t, 75, float64(cog), 0.01)
+
+	// a full circle of radius v/ω comes back to the start
+	ω := Degrees(10).Radians() / 60
+	r := float64(sog.Mps()) / ω
+	q, cog = PredictPosition(p, sog, 45, 10, 36*time.Minute, VincentyModel)
+	assert.InDelta(t, 0, float64(Distance(p, q, VincentyModel).Metre()), 0.01)
+	assert.InDelta(t, 45, float64(cog), 1e-3)
+
+	// half a circle is the diameter away, on the right
+	q, cog = PredictPosition(p, sog, 45, 10, 18*time.Minute, SphericalModel)
+	assert.InDelta(t, 2*r, float64(Distance(p, q, SphericalModel).Metre()), 0.01)
+	assert.InDelta(t, 135, float64(InitialBearing(p, q, SphericalModel)), 1e-3)
+	assert.InDelta(t, 225, float64(cog), 0.05)
+
+	// turning left, and backwards
+	q, cog = PredictPosition(p, sog, 45, -10, 9*time.Minute, SphericalModel)
+	assert.InDelta(t, math.Sqrt2*r, float64(Distance(p, q, SphericalModel).Metre()), 0.01)
+	assert.InDelta(t, 315, float64(cog), 1e-3)
+	back, backCOG := PredictPosition(q, sog, cog, -10, -9*time.Minute, SphericalModel)
+	assert.InDelta(t, 0, float64(Distance(p, back, SphericalModel).Metre()), 1e-5)
+	assert.InDelta(t, 45, float64(backCOG), 1e-6)
+
+	// turning on the spot
+	q, cog = PredictPosition(p, units.Knot(0), 350, 20, time.Minute, SphericalModel)
+	assert.Equal(t, p, q)
+	assert.InDelta(t, 10, float64(cog), 1e-9)
+}
@@ -0,0 +1,243 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"sort"
+
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/orb/project"
+)
+
+// Projection is a map projection, returning the grid coordinates of a point, with x towards grid East and y towards
+// grid North. For example, for the Mercator projection:
+//
+//	mercator := func(ll geod.LatLon) (float64, float64) {
+//		mp := ll.MercatorPoint()
+//		return mp.X, mp.Y
+//	}
+type Projection func(ll LatLon) (float64, float64)
+
+// projectionStep is the step along the meridian, in degrees, used to differentiate projections (about 1m)
+const projectionStep = Degrees(1e-5)
+
+// meridianStep returns the points a small step south and north of `ll` along the meridian, staying within -90°..90°
+func meridianStep(ll LatLon) (LatLon, LatLon) {
+	south := LatLon{Latitude: ll.Latitude - projectionStep/2, Longitude: ll.Longitude}
+	north := LatLon{Latitude: ll.Latitude + projectionStep/2, Longitude: ll.Longitude}
+
+	if north.Latitude > 90 {
+		south.Latitude, north.Latitude = 90-projectionStep, 90
+	}
+	if south.Latitude < -90 {
+		south.Latitude, north.Latitude = -90, -90+projectionStep
+	}
+
+	return south, north
+}
+
+// Convergence returns the grid (or meridian) convergence of the `projection` at `ll`: the bearing of grid North,
+// clockwise from true North. True bearings are grid bearings plus the convergence, and grid bearings are true bearings
+// minus the convergence.
+//
+// The convergence is calculated numerically, from the direction of the meridian on the grid, so it works with any
+// projection, but it is undefined at the poles.
+//
+// Example:
+// γ := geod.Convergence(ll, projection)
+// trueBearing := geod.Wrap360(gridBearing + γ)
+func Convergence(ll LatLon, projection Projection) Degrees {
+	south, north := meridianStep(ll)
+	x1, y1 := projection(south)
+	x2, y2 := projection(north)
+
+	return -DegreesFromRadians(math.Atan2(x2-x1, y2-y1))
+}
+
+// ScaleFactor returns the point scale factor of the `projection` at `ll`: the ratio of a small distance on the grid
+// to the same distance on the Earth, measured using `model`, along the meridian. For conformal projections (such as
+// Mercator or Transverse Mercator) the scale factor is the same in all directions. The grid coordinates of the
+// projection must be in the same units as the distances of the model, normally metres.
+//
+// Arguments:
+//
+// ll - the point
+// projection - the projection, see Projection
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+func ScaleFactor(ll LatLon, projection Projection, model EarthModel, modelArgs ...interface{}) float64 {
+	south, north := meridianStep(ll)
+	x1, y1 := projection(south)
+	x2, y2 := projection(north)
+
+	return math.Hypot(x2-x1, y2-y1) / float64(Distance(south, north, model, modelArgs...).Metre())
+}
+
+// ProjectGeometry returns the geometry with all its points projected with `projection`, for example with Sinusoidal
+// or Robinson for plotting. The coordinates of the points of the geometry are longitude and latitude, in degrees.
+// The geometry is not modified.
+func ProjectGeometry(g orb.Geometry, projection Projection) orb.Geometry {
+	return project.Geometry(orb.Clone(g), func(p orb.Point) orb.Point {
+		x, y := projection(LatLon{Latitude: Degrees(p[1]), Longitude: Degrees(p[0])})
+
+		return orb.Point{x, y}
+	})
+}
+
+// UnprojectGeometry returns the geometry with all its points converted from grid coordinates to longitude and
+// latitude with the `inverse` of a projection, for example SinusoidalInverse. The geometry is not modified.
+func UnprojectGeometry(g orb.Geometry, inverse func(x, y float64) LatLon) orb.Geometry {
+	return project.Geometry(orb.Clone(g), func(p orb.Point) orb.Point {
+		ll := inverse(p[0], p[1])
+
+		return orb.Point{float64(ll.Longitude), float64(ll.Latitude)}
+	})
+}
+
+// invalidLatLon is returned by the inverse projections for coordinates outside the map
+var invalidLatLon = LatLon{Latitude: Degrees(math.NaN()), Longitude: Degrees(math.NaN())}
+
+// Sinusoidal is the sinusoidal (Sanson-Flamsteed) equal-area projection of the spherical Earth, centred on the prime
+// meridian, in metres (see SetEarthRadius). It can be used as a Projection.
+func Sinusoidal(ll LatLon) (float64, float64) {
+	φ := ll.Latitude.Radians()
+	λ := Wrap180(ll.Longitude).Radians()
+
+	return earthRadius * λ * math.Cos(φ), earthRadius * φ
+}
+
+// SinusoidalInverse returns the point at the grid coordinates of the Sinusoidal projection. Returns an invalid point
+// if the coordinates are outside the map.
+func SinusoidalInverse(x, y float64) LatLon {
+	φ := y / earthRadius
+	if math.Abs(φ) > math.Pi/2 {
+		return invalidLatLon
+	}
+
+	cosφ := math.Cos(φ)
+	if cosφ < 1e-15 {
+		// the poles
+		return LatLon{Latitude: DegreesFromRadians(φ)}
+	}
+
+	λ := x / (earthRadius * cosφ)
+	if math.Abs(λ) > math.Pi*(1+1e-12) {
+		return invalidLatLon
+	}
+
+	return LatLon{Latitude: DegreesFromRadians(φ), Longitude: DegreesFromRadians(λ)}
+}
+
+// robinsonTable is Robinson's table of the length of the parallels (X) and their distance from the equator (Y) for
+// every 5° of latitude
+var robinsonTable = [19][2]float64{
+	{1.0000, 0.0000}, {0.9986, 0.0620}, {0.9954, 0.1240}, {0.9900, 0.1860}, {0.9822, 0.2480},
+	{0.9730, 0.3100}, {0.9600, 0.3720}, {0.9427, 0.4340}, {0.9216, 0.4958}, {0.8962, 0.5571},
+	{0.8679, 0.6176}, {0.8350, 0.6769}, {0.7986, 0.7346}, {0.7597, 0.7903}, {0.7186, 0.8435},
+	{0.6732, 0.8936}, {0.6213, 0.9394}, {0.5722, 0.9761}, {0.5322, 1.0000},
+}
+
+// Robinson projection constants, the scales of X and Y in the table
+const (
+	robinsonScaleX = 0.8487
+	robinsonScaleY = 1.3523
+)
+
+// robinsonInterpolate returns X and Y from robinsonTable at `lat` degrees (0..90), interpolated linearly
+func robinsonInterpolate(lat float64) (float64, float64) {
+	i := int(lat / 5)
+	if i >= len(robinsonTable)-1 {
+		return robinsonTable[len(robinsonTable)-1][0], robinsonTable[len(robinsonTable)-1][1]
+	}
+
+	t := lat/5 - float64(i)
+	r0, r1 := robinsonTable[i], robinsonTable[i+1]
+
+	return r0[0] + (r1[0]-r0[0])*t, r0[1] + (r1[1]-r0[1])*t
+}
+
+// Robinson is the Robinson projection of the spherical Earth, a compromise projection often used for world maps,
+// centred on the prime meridian, in metres (see SetEarthRadius). Robinson's table is interpolated linearly, which is
+// good enough for plotting. It can be used as a Projection.
+func Robinson(ll LatLon) (float64, float64) {
+	lat := float64(Wrap90(ll.Latitude))
+	λ := Wrap180(ll.Longitude).Radians()
+
+	x, y := robinsonInterpolate(math.Abs(lat))
+	if lat < 0 {
+		y = -y
+	}
+
+	return robinsonScaleX * earthRadius * x * λ, robinsonScaleY * earthRadius * y
+}
+
+// RobinsonInverse returns the point at the grid coordinates of the Robinson projection. Returns an invalid point if
+// the coordinates are outside the map.
+func RobinsonInverse(x, y float64) LatLon {
+	ty := math.Abs(y) / (robinsonScaleY * earthRadius)
+	if ty > 1 {
+		return invalidLatLon
+	}
+
+	// find the row of the table, Y increases with the latitude
+	i := sort.Search(len(robinsonTable), func(i int) bool { return robinsonTable[i][1] >= ty }) - 1
+	if i < 0 {
+		i = 0
+	}
+	if i >= len(robinsonTable)-1 {
+		i = len(robinsonTable) - 2
+	}
+
+	r0, r1 := robinsonTable[i], robinsonTable[i+1]
+	lat := 5 * (float64(i) + (ty-r0[1])/(r1[1]-r0[1]))
+	tx, _ := robinsonInterpolate(lat)
+
+	λ := x / (robinsonScaleX * earthRadius * tx)
+	if math.Abs(λ) > math.Pi*(1+1e-12) {
+		return invalidLatLon
+	}
+
+	if y < 0 {
+		lat = -lat
+	}
+
+	return LatLon{Latitude: Degrees(lat), Longitude: DegreesFromRadians(λ)}
+}
+
+// WebMercator is the spherical Mercator projection used by web maps (EPSG:3857), in metres, on the sphere with the
+// radius of the semi-major axis of WGS84. It can be used as a Projection. The coordinates of points beyond
+// MercatorMaxLat are outside the square map, and NaN is returned for the poles, where y is infinite.
+func WebMercator(ll LatLon) (float64, float64) {
+	if math.Abs(float64(ll.Latitude)) >= 90 {
+		return math.NaN(), math.NaN()
+	}
+
+	φ := ll.Latitude.Radians()
+	λ := Wrap180(ll.Longitude).Radians()
+
+	return wgs84.a * λ, wgs84.a * math.Log(math.Tan(math.Pi/4+φ/2))
+}
+
+// WebMercatorInverse returns the point at the grid coordinates of the WebMercator projection. Returns an invalid
+// point if the coordinates are outside the map.
+func WebMercatorInverse(x, y float64) LatLon {
+	λ := x / wgs84.a
+	if math.Abs(λ) > math.Pi*(1+1e-12) || math.IsNaN(y) {
+		return invalidLatLon
+	}
+
+	φ := 2*math.Atan(math.Exp(y/wgs84.a)) - math.Pi/2
+
+	return LatLon{Latitude: DegreesFromRadians(φ), Longitude: DegreesFromRadians(λ)}
+}
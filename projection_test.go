@@ -0,0 +1,95 @@
+package geod
+
+import (
+	"math"
+	"testing"
+
+	"github.com/starboard-nz/orb"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvergenceAndScaleFactor(t *testing.T) {
+	mercator := func(ll LatLon) (float64, float64) {
+		φ := ll.Latitude.Radians()
+		return earthRadius * ll.Longitude.Radians(), earthRadius * math.Log(math.Tan(math.Pi/4+φ/2))
+	}
+
+	for _, lat := range []Degrees{-60, -41.29, 0, 30, 80} {
+		ll := LatLon{Latitude: lat, Longitude: 174.78}
+		assert.InDelta(t, 0, float64(Convergence(ll, mercator)), 1e-9)
+		assert.InDelta(t, 1/math.Cos(lat.Radians()), ScaleFactor(ll, mercator, SphericalModel), 1e-6)
+	}
+
+	// the meridians of the azimuthal equidistant projection converge towards the pole
+	origin := NewLatLon(45, 0)
+	aeqd := func(ll LatLon) (float64, float64) {
+		return azimuthalEquidistant(origin, ll)
+	}
+	assert.InDelta(t, 0, float64(Convergence(NewLatLon(40, 0), aeqd)), 1e-9)
+	assert.InDelta(t, 1, ScaleFactor(NewLatLon(40, 0), aeqd, SphericalModel), 1e-6)
+	γ := Convergence(NewLatLon(45, 1), aeqd)
+	assert.InDelta(t, math.Sin(origin.Latitude.Radians()), float64(γ), 0.01)
+	assert.InDelta(t, -float64(γ), float64(Convergence(NewLatLon(45, -1), aeqd)), 1e-9)
+
+	// the step stays within the valid latitudes
+	assert.InDelta(t, 0, float64(Convergence(NewLatLon(90, 0), func(ll LatLon) (float64, float64) {
+		return float64(ll.Longitude), float64(ll.Latitude)
+	})), 1e-9)
+}
+
+func TestSinusoidal(t *testing.T) {
+	x, y := Sinusoidal(NewLatLon(0, 180))
+	assert.InDelta(t, math.Pi*earthRadius, x, 1e-6)
+	assert.Equal(t, 0.0, y)
+	x, y = Sinusoidal(NewLatLon(60, 90))
+	assert.InDelta(t, math.Pi/4*earthRadius, x, 1e-6)
+	assert.InDelta(t, math.Pi/3*earthRadius, y, 1e-6)
+
+	// equal-area
+	assert.InDelta(t, 1, ScaleFactor(NewLatLon(60, 0), Sinusoidal, SphericalModel), 1e-6)
+
+	for _, ll := range []LatLon{NewLatLon(-41.29, 174.78), NewLatLon(51.47, -0.0015), NewLatLon(0, -180), NewLatLon(89, 10)} {
+		back := SinusoidalInverse(Sinusoidal(ll))
+		assert.InDelta(t, float64(ll.Latitude), float64(back.Latitude), 1e-9)
+		assert.InDelta(t, float64(ll.Longitude), float64(back.Longitude), 1e-9)
+	}
+
+	assert.Equal(t, NewLatLon(90, 0), SinusoidalInverse(Sinusoidal(NewLatLon(90, 45))))
+	assert.False(t, SinusoidalInverse(math.Pi*earthRadius, math.Pi/3*earthRadius).Valid())
+	assert.False(t, SinusoidalInverse(0, 2*earthRadius).Valid())
+}
+
+func TestRobinson(t *testing.T) {
+	x, y := Robinson(NewLatLon(0, 180))
+	assert.InDelta(t, robinsonScaleX*math.Pi*earthRadius, x, 1e-6)
+	assert.Equal(t, 0.0, y)
+	x, y = Robinson(NewLatLon(-90, -180))
+	assert.InDelta(t, -0.5322*robinsonScaleX*math.Pi*earthRadius, x, 1e-6)
+	assert.InDelta(t, -robinsonScaleY*earthRadius, y, 1e-6)
+	_, y = Robinson(NewLatLon(42.5, 0))
+	assert.InDelta(t, (0.4958+0.5571)/2*robinsonScaleY*earthRadius, y, 1e-6)
+
+	for _, ll := range []LatLon{NewLatLon(-41.29, 174.78), NewLatLon(51.47, -0.0015), NewLatLon(0, -180), NewLatLon(90, 10), NewLatLon(-87.5, 0)} {
+		back := RobinsonInverse(Robinson(ll))
+		assert.InDelta(t, float64(ll.Latitude), float64(back.Latitude), 1e-9)
+		assert.InDelta(t, float64(ll.Longitude), float64(back.Longitude), 1e-9)
+	}
+
+	assert.False(t, RobinsonInverse(0, 1.5*earthRadius).Valid())
+	assert.False(t, RobinsonInverse(robinsonScaleX*math.Pi*earthRadius, robinsonScaleY*earthRadius).Valid())
+}
+
+func TestProjectGeometry(t *testing.T) {
+	poly := orb.Polygon{{{170, -40}, {175, -40}, {175, -35}, {170, -35}, {170, -40}}}
+
+	projected := ProjectGeometry(poly, Sinusoidal).(orb.Polygon)
+	x, y := Sinusoidal(NewLatLon(-35, 175))
+	assert.Equal(t, orb.Point{x, y}, projected[0][2])
+	assert.Equal(t, orb.Point{170, -40}, poly[0][0], "the input is not changed")
+
+	back := UnprojectGeometry(projected, SinusoidalInverse).(orb.Polygon)
+	for i, p := range poly[0] {
+		assert.InDelta(t, p[0], back[0][i][0], 1e-9)
+		assert.InDelta(t, p[1], back[0][i][1], 1e-9)
+	}
+}
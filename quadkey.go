@@ -0,0 +1,74 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"strings"
+)
+
+// MaxQuadKeyZoom is the highest zoom level supported by QuadKey and ParseQuadKey.
+const MaxQuadKeyZoom = 31
+
+// QuadKey returns the Bing Maps style quadkey of the Web Mercator tile (x, y) at the zoom level: one digit (0..3)
+// per zoom level, interleaving the bits of the tile coordinates. The quadkey of zoom level 0 is the empty string.
+// Returns "" if the tile is outside the world or the zoom level is out of range.
+func QuadKey(x, y, zoom int) string {
+	if zoom < 0 || zoom > MaxQuadKeyZoom || x < 0 || y < 0 || x >= 1<<zoom || y >= 1<<zoom {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.Grow(zoom)
+	for i := zoom; i > 0; i-- {
+		digit := byte('0')
+		mask := 1 << (i - 1)
+		if x&mask != 0 {
+			digit++
+		}
+		if y&mask != 0 {
+			digit += 2
+		}
+		sb.WriteByte(digit)
+	}
+
+	return sb.String()
+}
+
+// ParseQuadKey returns the tile coordinates and zoom level of a quadkey, which is the inverse of QuadKey.
+// Returns an error wrapping ErrInvalidQuadKey if the quadkey contains characters other than 0..3 or is too long.
+func ParseQuadKey(key string) (int, int, int, error) {
+	zoom := len(key)
+	if zoom > MaxQuadKeyZoom {
+		return 0, 0, 0, parseError(ErrInvalidQuadKey, key, "too many digits")
+	}
+
+	x, y := 0, 0
+	for i := 0; i < zoom; i++ {
+		mask := 1 << (zoom - i - 1)
+		switch key[i] {
+		case '0':
+		case '1':
+			x |= mask
+		case '2':
+			y |= mask
+		case '3':
+			x |= mask
+			y |= mask
+		default:
+			return 0, 0, 0, &ParseError{Err: ErrInvalidQuadKey, Input: key, Token: -1, Pos: i}
+		}
+	}
+
+	return x, y, zoom, nil
+}
+
+// QuadKey returns the quadkey of the tile containing the point at the zoom level. See QuadKey.
+func (mp MercatorPoint) QuadKey(zoom int) string {
+	x, y := mp.Tile(zoom)
+
+	return QuadKey(x, y, zoom)
+}
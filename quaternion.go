@@ -0,0 +1,103 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+)
+
+// Quaternion represents a rotation in 3 dimensions, W + Xi + Yj + Zk. Rotations are composed by multiplying them,
+// which is faster and more numerically stable than composing rotation matrices, for example for a chain of frame
+// transformations.
+type Quaternion struct {
+	W, X, Y, Z float64
+}
+
+// IdentityQuaternion returns the quaternion of no rotation
+func IdentityQuaternion() Quaternion {
+	return Quaternion{W: 1}
+}
+
+// QuaternionFromAxisAngle returns the quaternion for the rotation by `angle` around `axis`, clockwise looking along
+// the axis (i.e. following the right hand rule). The axis doesn't need to be a unit vector. Returns the identity
+// quaternion if the axis has zero length.
+func QuaternionFromAxisAngle(axis Vector3D, angle Degrees) Quaternion {
+	a := axis.Unit()
+	if a.Length() == 0 {
+		return IdentityQuaternion()
+	}
+
+	s, c := math.Sincos(angle.Radians() / 2)
+
+	return Quaternion{W: c, X: a.X * s, Y: a.Y * s, Z: a.Z * s}
+}
+
+// AxisAngle returns the (unit) axis and the angle (0°..360°) of the rotation. The axis is undefined for the identity
+// rotation, and an X axis is returned.
+func (q Quaternion) AxisAngle() (Vector3D, Degrees) {
+	q = q.Unit()
+
+	s := math.Sqrt(q.X*q.X + q.Y*q.Y + q.Z*q.Z)
+	if s == 0 {
+		return Vector3D{X: 1}, 0
+	}
+
+	θ := 2 * math.Atan2(s, q.W)
+
+	return Vector3D{X: q.X / s, Y: q.Y / s, Z: q.Z / s}, DegreesFromRadians(θ)
+}
+
+// Length returns the length (norm) of the quaternion, 1 for rotations.
+func (q Quaternion) Length() float64 {
+	return math.Sqrt(q.W*q.W + q.X*q.X + q.Y*q.Y + q.Z*q.Z)
+}
+
+// Unit normalizes the quaternion to unit length, returns the resulting quaternion. Repeated multiplications slowly
+// drift away from unit length, which can be corrected with this.
+func (q Quaternion) Unit() Quaternion {
+	len := q.Length()
+	if len == 1 || len == 0 {
+		return q
+	}
+
+	return Quaternion{W: q.W / len, X: q.X / len, Y: q.Y / len, Z: q.Z / len}
+}
+
+// Conjugate returns the conjugate of the quaternion, which is the inverse rotation for unit quaternions.
+func (q Quaternion) Conjugate() Quaternion {
+	return Quaternion{W: q.W, X: -q.X, Y: -q.Y, Z: -q.Z}
+}
+
+// Times returns the (Hamilton) product q × `other`, which is the rotation `other` followed by the rotation `q`.
+func (q Quaternion) Times(other Quaternion) Quaternion {
+	return Quaternion{
+		W: q.W*other.W - q.X*other.X - q.Y*other.Y - q.Z*other.Z,
+		X: q.W*other.X + q.X*other.W + q.Y*other.Z - q.Z*other.Y,
+		Y: q.W*other.Y - q.X*other.Z + q.Y*other.W + q.Z*other.X,
+		Z: q.W*other.Z + q.X*other.Y - q.Y*other.X + q.Z*other.W,
+	}
+}
+
+// Rotate returns the vector `v` rotated by the (unit) quaternion.
+func (q Quaternion) Rotate(v Vector3D) Vector3D {
+	// v' = v + 2w(u × v) + 2u × (u × v), where u is the vector part of q
+	u := Vector3D{X: q.X, Y: q.Y, Z: q.Z}
+	uv := u.Cross(v)
+
+	return v.Plus(uv.Times(2 * q.W)).Plus(u.Cross(uv).Times(2))
+}
+
+// Matrix returns the rotation matrix of the (unit) quaternion.
+func (q Quaternion) Matrix() Matrix3 {
+	w, x, y, z := q.W, q.X, q.Y, q.Z
+
+	return Matrix3{
+		{1 - 2*(y*y+z*z), 2 * (x*y - w*z), 2 * (x*z + w*y)},
+		{2 * (x*y + w*z), 1 - 2*(x*x+z*z), 2 * (y*z - w*x)},
+		{2 * (x*z - w*y), 2 * (y*z + w*x), 1 - 2*(x*x+y*y)},
+	}
+}
@@ -0,0 +1,40 @@
+package geod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuaternion(t *testing.T) {
+	z := Vector3D{0, 0, 1}
+	q := QuaternionFromAxisAngle(z, 90)
+	assertVectorInDelta(t, Vector3D{-2, 1, 3}, q.Rotate(Vector3D{1, 2, 3}), 1e-12)
+	assertVectorInDelta(t, q.Rotate(Vector3D{1, 2, 3}), q.Matrix().Times(Vector3D{1, 2, 3}), 1e-12)
+
+	axis, angle := q.AxisAngle()
+	assertVectorInDelta(t, z, axis, 1e-12)
+	assert.InDelta(t, 90, float64(angle), 1e-12)
+
+	axis, angle = QuaternionFromAxisAngle(Vector3D{1, 1, 0}, 30).AxisAngle()
+	assertVectorInDelta(t, Vector3D{1, 1, 0}.Unit(), axis, 1e-12)
+	assert.InDelta(t, 30, float64(angle), 1e-12)
+
+	_, angle = IdentityQuaternion().AxisAngle()
+	assert.Equal(t, Degrees(0), angle)
+	assert.Equal(t, IdentityQuaternion(), QuaternionFromAxisAngle(Vector3D{}, 45))
+
+	// composition
+	x := QuaternionFromAxisAngle(Vector3D{1, 0, 0}, 90)
+	v := Vector3D{1, 2, 3}
+	assertVectorInDelta(t, q.Rotate(x.Rotate(v)), q.Times(x).Rotate(v), 1e-12)
+	assertVectorInDelta(t, v, q.Conjugate().Rotate(q.Rotate(v)), 1e-12)
+
+	// 360 rotations of 1° are a full turn
+	r := IdentityQuaternion()
+	step := QuaternionFromAxisAngle(Vector3D{1, 2, 3}, 1)
+	for i := 0; i < 360; i++ {
+		r = step.Times(r)
+	}
+	assertVectorInDelta(t, v, r.Unit().Rotate(v), 1e-9)
+}
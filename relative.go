@@ -0,0 +1,70 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+)
+
+// RelativeBearing returns the bearing of `target` relative to the `heading` of the observer at `observer`, in the
+// range -180..180: 0 is dead ahead, positive to starboard (right) and negative to port (left), for example to point a
+// camera or to report a contact as "30° on the port bow".
+//
+// Arguments:
+//
+// observer - the position of the observer
+// heading - the heading of the observer, in `Degrees` from North
+// target - the position of the target
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// Returns NaN if the positions are the same, which can be tested using `Degrees.Valid()`.
+//
+// Example:
+// rb := geod.RelativeBearing(ownShip, 90, contact, geod.SphericalModel)   // -30: 30° on the port bow
+func RelativeBearing(observer LatLon, heading Degrees, target LatLon, model EarthModel,
+	modelArgs ...interface{}) Degrees {
+	if observer.Equals(target) {
+		return Degrees(math.NaN())
+	}
+
+	return Wrap180(model(observer, modelArgs...).InitialBearingTo(target) - heading)
+}
+
+// TargetAspect returns the aspect (angle on the bow) of the target at `target` with the heading `targetHeading`, as
+// seen by the observer at `observer`: the bearing of the observer relative to the heading of the target, in the range
+// -180..180. 0 means the target is heading straight towards the observer, ±180 straight away from it, and the
+// observer sees the starboard (right) side of the target if the aspect is positive and the port (left) side if it is
+// negative, for example to tell if a crossing vessel will pass ahead or astern.
+//
+// Arguments:
+//
+// observer - the position of the observer
+// target - the position of the target
+// targetHeading - the heading of the target, in `Degrees` from North
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// Returns NaN if the positions are the same, which can be tested using `Degrees.Valid()`.
+//
+// Example:
+// aspect := geod.TargetAspect(ownShip, contact, 270, geod.SphericalModel) // 45: green 45, starboard side
+func TargetAspect(observer, target LatLon, targetHeading Degrees, model EarthModel, modelArgs ...interface{}) Degrees {
+	return RelativeBearing(target, targetHeading, observer, model, modelArgs...)
+}
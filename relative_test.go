@@ -0,0 +1,46 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRelativeBearing(t *testing.T) {
+	observer := NewLatLon(0, 0)
+	target := NewLatLon(1, 1) // bearing 45°
+
+	for _, model := range []EarthModel{SphericalModel, RhumbModel, VincentyModel} {
+		b := float64(InitialBearing(observer, target, model))
+		assert.InDelta(t, b, float64(RelativeBearing(observer, 0, target, model)), 1e-9)
+		assert.InDelta(t, b-90, float64(RelativeBearing(observer, 90, target, model)), 1e-9)
+		assert.InDelta(t, b-200, float64(RelativeBearing(observer, 200, target, model)), 1e-9)
+		assert.InDelta(t, 180, math.Abs(float64(RelativeBearing(observer, Degrees(b+180), target, model))), 1e-9)
+		assert.InDelta(t, b+10, float64(RelativeBearing(observer, 360-10, target, model)), 1e-9)
+	}
+
+	assert.False(t, RelativeBearing(observer, 0, observer, VincentyModel).Valid())
+}
+
+func TestTargetAspect(t *testing.T) {
+	observer := NewLatLon(0, 0)
+	target := NewLatLon(0, 1) // due East of the observer
+
+	// heading straight at the observer, and away
+	assert.InDelta(t, 0, float64(TargetAspect(observer, target, 270, SphericalModel)), 1e-9)
+	assert.InDelta(t, 180, float64(TargetAspect(observer, target, 90, SphericalModel)), 1e-9)
+
+	// heading North the observer is on its port side, heading South on its starboard side
+	assert.InDelta(t, -90, float64(TargetAspect(observer, target, 0, SphericalModel)), 1e-9)
+	assert.InDelta(t, 90, float64(TargetAspect(observer, target, 180, VincentyModel)), 1e-9)
+	assert.InDelta(t, 45, float64(TargetAspect(observer, target, 225, RhumbModel)), 1e-9)
+
+	assert.False(t, TargetAspect(observer, observer, 0, SphericalModel).Valid())
+}
@@ -0,0 +1,92 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+)
+
+// Matrix3 is a 3x3 matrix, indexed by row then column, used for rotating vectors between frames.
+type Matrix3 [3][3]float64
+
+// Times returns the product of the matrix and the vector `v` (m × v).
+func (m Matrix3) Times(v Vector3D) Vector3D {
+	return Vector3D{
+		X: m[0][0]*v.X + m[0][1]*v.Y + m[0][2]*v.Z,
+		Y: m[1][0]*v.X + m[1][1]*v.Y + m[1][2]*v.Z,
+		Z: m[2][0]*v.X + m[2][1]*v.Y + m[2][2]*v.Z,
+	}
+}
+
+// Transpose returns the transpose of the matrix, which is the inverse of a rotation matrix.
+func (m Matrix3) Transpose() Matrix3 {
+	var t Matrix3
+	for i := 0; i < 3; i++ {
+		for j := 0; j < 3; j++ {
+			t[i][j] = m[j][i]
+		}
+	}
+
+	return t
+}
+
+// ENURotation returns the rotation matrix from ECEF (earth-centered earth-fixed) to the local East-North-Up frame at
+// the (geodetic) position `ll`. Use Transpose() for the rotation from ENU to ECEF.
+//
+// The rows of the matrix are the East, North and Up unit vectors in ECEF coordinates.
+func ENURotation(ll LatLon) Matrix3 {
+	sinφ, cosφ := math.Sincos(ll.Latitude.Radians())
+	sinλ, cosλ := math.Sincos(ll.Longitude.Radians())
+
+	return Matrix3{
+		{-sinλ, cosλ, 0},
+		{-sinφ * cosλ, -sinφ * sinλ, cosφ},
+		{cosφ * cosλ, cosφ * sinλ, sinφ},
+	}
+}
+
+// NEDRotation returns the rotation matrix from ECEF (earth-centered earth-fixed) to the local North-East-Down frame
+// at the (geodetic) position `ll`. Use Transpose() for the rotation from NED to ECEF.
+//
+// The rows of the matrix are the North, East and Down unit vectors in ECEF coordinates.
+func NEDRotation(ll LatLon) Matrix3 {
+	enu := ENURotation(ll)
+
+	return Matrix3{
+		enu[1],
+		enu[0],
+		{-enu[2][0], -enu[2][1], -enu[2][2]},
+	}
+}
+
+// ECEFToENU rotates the ECEF vector, such as a velocity or an offset between two points, to the local East-North-Up
+// frame at `ll`, returning the East, North and Up components as X, Y and Z.
+//
+// Example:
+// v := geod.Vector3D{X: 10, Y: 0, Z: 0}         // 10 m/s in the ECEF frame
+// enu := v.ECEFToENU(geod.NewLatLon(0, 90))     // {X: -10, Y: 0, Z: 0}, heading West
+func (v Vector3D) ECEFToENU(ll LatLon) Vector3D {
+	return ENURotation(ll).Times(v)
+}
+
+// ENUToECEF rotates the vector with East, North and Up components X, Y and Z in the local frame at `ll` to the
+// ECEF frame.
+func (v Vector3D) ENUToECEF(ll LatLon) Vector3D {
+	return ENURotation(ll).Transpose().Times(v)
+}
+
+// ECEFToNED rotates the ECEF vector to the local North-East-Down frame at `ll`, returning the North, East and Down
+// components as X, Y and Z.
+func (v Vector3D) ECEFToNED(ll LatLon) Vector3D {
+	return NEDRotation(ll).Times(v)
+}
+
+// NEDToECEF rotates the vector with North, East and Down components X, Y and Z in the local frame at `ll` to the
+// ECEF frame.
+func (v Vector3D) NEDToECEF(ll LatLon) Vector3D {
+	return NEDRotation(ll).Transpose().Times(v)
+}
@@ -0,0 +1,39 @@
+package geod
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assertVectorInDelta(t *testing.T, expected, actual Vector3D, delta float64) {
+	t.Helper()
+	assert.InDelta(t, expected.X, actual.X, delta)
+	assert.InDelta(t, expected.Y, actual.Y, delta)
+	assert.InDelta(t, expected.Z, actual.Z, delta)
+}
+
+func TestFrameRotations(t *testing.T) {
+	v := Vector3D{X: 10, Y: 0, Z: 0}
+	assertVectorInDelta(t, Vector3D{X: -10, Y: 0, Z: 0}, v.ECEFToENU(NewLatLon(0, 90)), 1e-12)
+	assertVectorInDelta(t, Vector3D{X: 0, Y: 0, Z: 10}, v.ECEFToENU(NewLatLon(0, 0)), 1e-12)
+	assertVectorInDelta(t, Vector3D{X: 0, Y: 0, Z: -10}, v.ECEFToNED(NewLatLon(0, 0)), 1e-12)
+	assertVectorInDelta(t, Vector3D{X: 0, Y: 10, Z: 0}, Vector3D{X: 0, Y: 0, Z: 10}.ECEFToENU(NewLatLon(0, 0)), 1e-12)
+
+	ll := NewLatLon(-41.29, 174.78)
+	u := Vector3D{X: 1.5, Y: -2.5, Z: 7}
+	assertVectorInDelta(t, u, u.ECEFToENU(ll).ENUToECEF(ll), 1e-12)
+	assertVectorInDelta(t, u, u.ECEFToNED(ll).NEDToECEF(ll), 1e-12)
+	assert.InDelta(t, u.Length(), u.ECEFToENU(ll).Length(), 1e-12)
+
+	enu := u.ECEFToENU(ll)
+	assertVectorInDelta(t, Vector3D{X: enu.Y, Y: enu.X, Z: -enu.Z}, u.ECEFToNED(ll), 1e-12)
+
+	// the Up vector is the normal to the ellipsoid
+	p := NewLatLonEllipsodial(-41.29, 174.78, 0)
+	normal := Vector3D(NewLatLonEllipsodial(-41.29, 174.78, 1).Cartesian()).Minus(Vector3D(p.Cartesian()))
+	assertVectorInDelta(t, Vector3D{X: 0, Y: 0, Z: 1}, normal.ECEFToENU(ll), 1e-9)
+
+	m := ENURotation(ll)
+	assert.Equal(t, m, m.Transpose().Transpose())
+}
@@ -0,0 +1,328 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/starboard-nz/orb"
+)
+
+// S2 cell IDs
+//
+// This is a minimal implementation of the cell numbering of the S2 Geometry library (https://s2geometry.io), so that
+// cell IDs can be exchanged with S2-based stores, such as BigQuery's S2_CELLIDFROMPOINT, without another geometry
+// library. The cube faces, the quadratic projection and the Hilbert curve ordering match S2, so IDs are identical.
+
+// S2CellID is an S2 cell ID. The value is the same 64 bit integer that S2 uses; BigQuery stores it as a signed INT64,
+// use int64(id) to convert.
+type S2CellID uint64
+
+// S2MaxLevel is the level of the smallest S2 cells, around 1cm across.
+const S2MaxLevel = 30
+
+const (
+	s2PosBits = 2*S2MaxLevel + 1
+	s2MaxSize = 1 << S2MaxLevel
+
+	// Hilbert curve orientation bits
+	s2SwapMask   = 1
+	s2InvertMask = 2
+)
+
+var (
+	// s2IJToPos is the position within the parent of the child cell with i, j bits (i<<1 | j), for each orientation
+	s2IJToPos = [4][4]int{{0, 1, 3, 2}, {0, 3, 1, 2}, {2, 3, 1, 0}, {2, 1, 3, 0}}
+	// s2PosToIJ is the inverse of s2IJToPos
+	s2PosToIJ = [4][4]int{{0, 1, 3, 2}, {0, 2, 3, 1}, {3, 2, 0, 1}, {3, 1, 0, 2}}
+	// s2PosToOrientation is the change of orientation of the child cell at each position
+	s2PosToOrientation = [4]int{s2SwapMask, 0, 0, s2InvertMask | s2SwapMask}
+)
+
+// ToS2CellID returns the ID of the S2 cell at `level` (0..S2MaxLevel) containing the point.
+// Panics if the level is out of range.
+func ToS2CellID(ll LatLon, level int) S2CellID {
+	if level < 0 || level > S2MaxLevel {
+		panic("invalid S2 level")
+	}
+
+	φ := ll.Latitude.Radians()
+	λ := ll.Longitude.Radians()
+	face, u, v := s2XYZToFaceUV(math.Cos(φ)*math.Cos(λ), math.Cos(φ)*math.Sin(λ), math.Sin(φ))
+	i := s2STToIJ(s2UVToST(u))
+	j := s2STToIJ(s2UVToST(v))
+
+	return s2CellIDFromFaceIJ(face, i, j).Parent(level)
+}
+
+// S2FaceCell returns the level 0 cell of one of the 6 faces of the cube (0..5).
+func S2FaceCell(face int) S2CellID {
+	return S2CellID(uint64(face)<<s2PosBits + s2LSBForLevel(0))
+}
+
+// S2CellIDFromToken returns the cell ID for a token, as returned by Token. Returns 0, which is not a valid cell, if
+// the token cannot be parsed.
+func S2CellIDFromToken(token string) S2CellID {
+	if len(token) > 16 {
+		return 0
+	}
+
+	n, err := strconv.ParseUint(token, 16, 64)
+	if err != nil {
+		return 0
+	}
+
+	return S2CellID(n << (4 * (16 - len(token))))
+}
+
+// s2LSBForLevel returns the lowest set bit of cell IDs at the level
+func s2LSBForLevel(level int) uint64 {
+	return 1 << (2 * (S2MaxLevel - level))
+}
+
+// s2XYZToFaceUV returns the cube face the direction points to, and the coordinates on the face
+func s2XYZToFaceUV(x, y, z float64) (int, float64, float64) {
+	face := 0
+	ax, ay, az := math.Abs(x), math.Abs(y), math.Abs(z)
+	value := x
+	if ay > ax {
+		face, value = 1, y
+		if az > ay {
+			face, value = 2, z
+		}
+	} else if az > ax {
+		face, value = 2, z
+	}
+	if value < 0 {
+		face += 3
+	}
+
+	switch face {
+	case 0:
+		return face, y / x, z / x
+	case 1:
+		return face, -x / y, z / y
+	case 2:
+		return face, -x / z, -y / z
+	case 3:
+		return face, z / x, y / x
+	case 4:
+		return face, z / y, -x / y
+	default:
+		return face, -y / z, -x / z
+	}
+}
+
+// s2FaceUVToXYZ returns the (not unit length) direction of the point on the cube face
+func s2FaceUVToXYZ(face int, u, v float64) (float64, float64, float64) {
+	switch face {
+	case 0:
+		return 1, u, v
+	case 1:
+		return -u, 1, v
+	case 2:
+		return -u, -v, 1
+	case 3:
+		return -1, -v, -u
+	case 4:
+		return v, -1, -u
+	default:
+		return v, u, -1
+	}
+}
+
+// s2UVToST converts face coordinates (-1..1) to cell space coordinates (0..1) using S2's quadratic projection
+func s2UVToST(u float64) float64 {
+	if u >= 0 {
+		return 0.5 * math.Sqrt(1+3*u)
+	}
+
+	return 1 - 0.5*math.Sqrt(1-3*u)
+}
+
+// s2STToUV is the inverse of s2UVToST
+func s2STToUV(s float64) float64 {
+	if s >= 0.5 {
+		return (4*s*s - 1) / 3
+	}
+
+	return (1 - 4*(1-s)*(1-s)) / 3
+}
+
+// s2STToIJ converts cell space coordinates to the index of the leaf cell
+func s2STToIJ(s float64) int {
+	return int(math.Max(0, math.Min(s2MaxSize-1, math.Floor(s2MaxSize*s))))
+}
+
+// s2CellIDFromFaceIJ returns the leaf cell at i, j on the face
+func s2CellIDFromFaceIJ(face, i, j int) S2CellID {
+	orientation := face & s2SwapMask
+	pos := uint64(0)
+
+	for k := S2MaxLevel - 1; k >= 0; k-- {
+		ij := ((i>>k)&1)<<1 | (j>>k)&1
+		p := s2IJToPos[orientation][ij]
+		pos = pos<<2 | uint64(p)
+		orientation ^= s2PosToOrientation[p]
+	}
+
+	return S2CellID(uint64(face)<<s2PosBits | pos<<1 | 1)
+}
+
+// faceIJ returns the face of the cell and the i, j indexes of its lowest leaf cell
+func (id S2CellID) faceIJ() (int, int, int) {
+	face := id.Face()
+	orientation := face & s2SwapMask
+	i, j := 0, 0
+
+	for k := 1; k <= id.Level(); k++ {
+		p := int(uint64(id)>>(s2PosBits-2*k)) & 3
+		ij := s2PosToIJ[orientation][p]
+		i |= (ij >> 1) << (S2MaxLevel - k)
+		j |= (ij & 1) << (S2MaxLevel - k)
+		orientation ^= s2PosToOrientation[p]
+	}
+
+	return face, i, j
+}
+
+// lsb returns the lowest set bit of the ID
+func (id S2CellID) lsb() uint64 {
+	return uint64(id) & -uint64(id)
+}
+
+// Valid returns true if the ID is a valid S2 cell.
+func (id S2CellID) Valid() bool {
+	return id.Face() < 6 && id.lsb()&0x1555555555555555 != 0
+}
+
+// Face returns the cube face of the cell (0..5).
+func (id S2CellID) Face() int {
+	return int(uint64(id) >> s2PosBits)
+}
+
+// Level returns the level of the cell, 0 for the faces to S2MaxLevel for leaf cells.
+func (id S2CellID) Level() int {
+	return S2MaxLevel - bits.TrailingZeros64(uint64(id))/2
+}
+
+// Parent returns the cell at `level` containing the cell. `level` must not be greater than the cell's level.
+func (id S2CellID) Parent(level int) S2CellID {
+	lsb := s2LSBForLevel(level)
+
+	return S2CellID((uint64(id) & -lsb) | lsb)
+}
+
+// Children returns the 4 cells one level down, in Hilbert curve order. Must not be called on leaf cells.
+func (id S2CellID) Children() [4]S2CellID {
+	lsb := id.lsb() >> 2
+	first := uint64(id) - id.lsb() + lsb
+
+	return [4]S2CellID{
+		S2CellID(first),
+		S2CellID(first + 2*lsb),
+		S2CellID(first + 4*lsb),
+		S2CellID(first + 6*lsb),
+	}
+}
+
+// Contains returns true if `other` is the cell or one of its descendants.
+func (id S2CellID) Contains(other S2CellID) bool {
+	lsb := id.lsb()
+
+	return uint64(other) >= uint64(id)-(lsb-1) && uint64(other) <= uint64(id)+(lsb-1)
+}
+
+// faceSTToLatLon returns the point at cell space coordinates on the face
+func faceSTToLatLon(face int, s, t float64) LatLon {
+	x, y, z := s2FaceUVToXYZ(face, s2STToUV(s), s2STToUV(t))
+
+	return LatLon{
+		Latitude:  DegreesFromRadians(math.Atan2(z, math.Hypot(x, y))),
+		Longitude: DegreesFromRadians(math.Atan2(y, x)),
+	}
+}
+
+// LatLon returns the centre of the cell.
+func (id S2CellID) LatLon() LatLon {
+	face, i, j := id.faceIJ()
+	size := 1 << (S2MaxLevel - id.Level())
+
+	return faceSTToLatLon(face, (float64(i)+float64(size)/2)/s2MaxSize, (float64(j)+float64(size)/2)/s2MaxSize)
+}
+
+// Vertices returns the 4 corners of the cell, anticlockwise. The edges of S2 cells are great circle arcs.
+func (id S2CellID) Vertices() [4]LatLon {
+	face, i, j := id.faceIJ()
+	size := 1 << (S2MaxLevel - id.Level())
+	s0, t0 := float64(i)/s2MaxSize, float64(j)/s2MaxSize
+	s1, t1 := float64(i+size)/s2MaxSize, float64(j+size)/s2MaxSize
+
+	return [4]LatLon{
+		faceSTToLatLon(face, s0, t0),
+		faceSTToLatLon(face, s1, t0),
+		faceSTToLatLon(face, s1, t1),
+		faceSTToLatLon(face, s0, t1),
+	}
+}
+
+// Polygon returns the cell's corners as a closed polygon. Longitudes are made continuous around the cell, so cells
+// with an edge on the antimeridian don't mix -180 and 180. The straight edges are only a good approximation of the
+// cell's edges for small cells.
+func (id S2CellID) Polygon() orb.Polygon {
+	vertices := id.Vertices()
+	ring := make(orb.Ring, 0, len(vertices)+1)
+	for i, v := range vertices {
+		lon := float64(v.Longitude)
+		if i > 0 {
+			prev := ring[i-1][0]
+			lon = prev + float64(Wrap180(Degrees(lon-prev)))
+		}
+		ring = append(ring, orb.Point{lon, float64(v.Latitude)})
+	}
+
+	shift := 0.0
+	if b := ring.Bound(); b.Min[0] < -180 {
+		shift = 360
+	} else if b.Min[0] >= 180 {
+		shift = -360
+	}
+	for i := range ring {
+		ring[i][0] += shift
+	}
+
+	return orb.Polygon{append(ring, ring[0])}
+}
+
+// Token returns the compact hexadecimal representation of the ID used by S2, with trailing zeros removed.
+func (id S2CellID) Token() string {
+	if id == 0 {
+		return "X"
+	}
+
+	return strings.TrimRight(fmt.Sprintf("%016x", uint64(id)), "0")
+}
+
+// String returns the cell as "face/positions", for example "3/0231", matching S2's debug format.
+func (id S2CellID) String() string {
+	if !id.Valid() {
+		return "invalid"
+	}
+
+	var sb strings.Builder
+	sb.WriteString(strconv.Itoa(id.Face()))
+	sb.WriteByte('/')
+	for level := 1; level <= id.Level(); level++ {
+		sb.WriteByte(byte('0' + (uint64(id)>>(s2PosBits-2*level))&3))
+	}
+
+	return sb.String()
+}
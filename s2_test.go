@@ -0,0 +1,105 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestToS2CellID(t *testing.T) {
+	// expected values from the S2 Geometry library
+	tests := []struct {
+		ll     LatLon
+		level  int
+		id     uint64
+		token  string
+		str    string
+		centre LatLon
+	}{
+		{NewLatLon(39.596880390715, 54.947089007968), 5, 4624070917402656768, "402c", "2/00011",
+			NewLatLon(39.770260571222, 52.928302827268)},
+		{NewLatLon(-13.025972722528, 2.766982458401), 28, 1956089296412149200, "1b256cd9ed9705d",
+			"0/3121022312123033123023200232", NewLatLon(-13.025972680199, 2.766982400970)},
+		{NewLatLon(-5.681344182726, -53.889092449208), 18, 10588286061249560576, "92f12612d5", "4/211320210300211222",
+			NewLatLon(-5.681246511910, -53.889089833911)},
+		{NewLatLon(-56.503630455232, 117.367479845393), 1, 12393906174523604992, "ac", "5/1",
+			NewLatLon(-59.491041133797, 135)},
+		{NewLatLon(-50.709241929534, -176.375498301142), 5, 12088787299769253888, "a7c4", "5/03320",
+			NewLatLon(-51.253775018892, -175.323213896375)},
+		{NewLatLon(-4.176094678432, -0.198843777689), 19, 381464261190221824, "054b3bb976c", "0/0222112131313023231",
+			NewLatLon(-4.176069681367, -0.198899499618)},
+	}
+
+	for _, test := range tests {
+		id := ToS2CellID(test.ll, test.level)
+		assert.Equal(t, test.id, uint64(id))
+		assert.True(t, id.Valid())
+		assert.Equal(t, test.level, id.Level())
+		assert.Equal(t, test.token, id.Token())
+		assert.Equal(t, test.str, id.String())
+		assert.Equal(t, id, S2CellIDFromToken(test.token))
+		assert.InDelta(t, float64(test.centre.Latitude), float64(id.LatLon().Latitude), 1e-9)
+		assert.InDelta(t, float64(test.centre.Longitude), float64(id.LatLon().Longitude), 1e-9)
+
+		// round trip through the centre
+		assert.Equal(t, id, ToS2CellID(id.LatLon(), test.level))
+	}
+
+	assert.Panics(t, func() { ToS2CellID(NewLatLon(0, 0), S2MaxLevel+1) })
+	assert.Equal(t, S2CellID(0), S2CellIDFromToken("xyz"))
+	assert.False(t, S2CellID(0).Valid())
+	assert.Equal(t, "X", S2CellID(0).Token())
+}
+
+func TestS2CellIDHierarchy(t *testing.T) {
+	ll := NewLatLon(-41.2865, 174.7762)
+	id := ToS2CellID(ll, 12)
+
+	parent := id.Parent(10)
+	assert.Equal(t, 10, parent.Level())
+	assert.Equal(t, ToS2CellID(ll, 10), parent)
+	assert.True(t, parent.Contains(id))
+	assert.False(t, id.Contains(parent))
+
+	children := id.Children()
+	found := 0
+	for _, child := range children {
+		assert.Equal(t, 13, child.Level())
+		assert.Equal(t, id, child.Parent(12))
+		if child == ToS2CellID(ll, 13) {
+			found++
+		}
+	}
+	assert.Equal(t, 1, found)
+
+	for face := 0; face < 6; face++ {
+		f := S2FaceCell(face)
+		assert.Equal(t, 0, f.Level())
+		assert.Equal(t, face, f.Face())
+	}
+	assert.Equal(t, S2FaceCell(2), ToS2CellID(NewLatLon(90, 0), 0))
+}
+
+func TestS2CellPolygon(t *testing.T) {
+	id := ToS2CellID(NewLatLon(10, 179.99), 8)
+	p := id.Polygon()
+	require.Len(t, p, 1)
+	require.Len(t, p[0], 5)
+	assert.Equal(t, p[0][0], p[0][4])
+
+	// the antimeridian is a cell edge, with continuous longitudes
+	b := p.Bound()
+	assert.Less(t, b.Max[0]-b.Min[0], 1.0)
+	assert.InDelta(t, 180, b.Max[0], 1e-9)
+
+	b = ToS2CellID(NewLatLon(-60, -179.99), 8).Polygon().Bound()
+	assert.Less(t, b.Max[0]-b.Min[0], 1.0)
+	assert.InDelta(t, -180, b.Min[0], 1e-9)
+}
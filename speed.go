@@ -0,0 +1,103 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/starboard-nz/units"
+)
+
+// SpeedBetween returns the average speed and the course (initial bearing) travelling from `p1` at time `t1` to `p2`
+// at time `t2`, using the given `model`.
+//
+// Arguments:
+//
+// p1, t1 - first position and the time it was recorded
+// p2, t2 - second position and the time it was recorded
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// Returns the speed and the course in `Degrees` from North. If the positions are identical the speed is 0 and the
+// course is NaN, which can be tested using `Degrees.Valid()`.
+// ErrInvalidTimeDelta is returned if `t2` is not after `t1`.
+//
+// Example:
+// sog, cog, err := geod.SpeedBetween(p1, t1, p2, t2, geod.VincentyModel)
+// knots := sog.Knot()
+func SpeedBetween(p1 LatLon, t1 time.Time, p2 LatLon, t2 time.Time, model EarthModel,
+	modelArgs ...interface{}) (units.Speed, Degrees, error) {
+
+	dt := t2.Sub(t1)
+	if dt <= 0 {
+		return units.Mps(math.NaN()), Degrees(math.NaN()), fmt.Errorf("%w: %v", ErrInvalidTimeDelta, dt)
+	}
+
+	if p1.Equals(p2) {
+		return units.Mps(0), Degrees(math.NaN()), nil
+	}
+
+	m := model(p1, modelArgs...)
+	dist := m.DistanceTo(p2).Metre()
+
+	return units.Mps(float64(dist) / dt.Seconds()), m.InitialBearingTo(p2), nil
+}
+
+// TravelTime returns the time it takes to travel the `distance` at the constant `speed`, rounded to the nearest
+// nanosecond. ErrInvalidSpeed is returned if the speed is not positive.
+//
+// Example:
+// d, err := geod.TravelTime(units.NM(120), units.Knot(12))    // 10h
+func TravelTime(distance units.Distance, speed units.Speed) (time.Duration, error) {
+	mps := float64(speed.Mps())
+	if !(mps > 0) {
+		return 0, fmt.Errorf("%w: %v", ErrInvalidSpeed, speed)
+	}
+
+	return time.Duration(math.Round(float64(distance.Metre()) / mps * float64(time.Second))), nil
+}
+
+// ETA returns the estimated time of arrival travelling from `start` to `end` at the constant `speed`, departing at
+// `departure`, with the distance calculated using the given `model`.
+//
+// Arguments:
+//
+// start - starting point
+// end - destination
+// speed - the speed over ground
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions.
+//
+// departure - the time of departure from `start`
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// ErrInvalidSpeed is returned if the speed is not positive.
+//
+// Example:
+// eta, err := geod.ETA(p1, p2, units.Knot(12), geod.RhumbModel, time.Now())
+func ETA(start, end LatLon, speed units.Speed, model EarthModel, departure time.Time,
+	modelArgs ...interface{}) (time.Time, error) {
+
+	d, err := TravelTime(model(start, modelArgs...).DistanceTo(end), speed)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return departure.Add(d), nil
+}
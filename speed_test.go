@@ -0,0 +1,81 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/starboard-nz/units"
+)
+
+func TestSpeedBetween(t *testing.T) {
+	p1 := NewLatLon(29.56600166666667, -93.65966499999999)
+	p2 := NewLatLon(-45.83760666666667, -94.61307166666667)
+	t1 := time.Unix(1680404293, 0)
+	t2 := time.Unix(1680719495, 0)
+
+	sog, cog, err := SpeedBetween(p1, t1, p2, t2, RhumbModel)
+	require.NoError(t, err)
+
+	dist := NewLatLonRhumb(p1.Latitude, p1.Longitude).DistanceTo(p2)
+	assert.InDelta(t, float64(dist.NM())/t2.Sub(t1).Hours(), float64(sog.Knot()), 1e-9)
+	assert.InDelta(t, 51.71, float64(sog.Knot()), 0.01)
+	assert.InDelta(t, 180.66, float64(cog), 0.01)
+
+	_, _, err = SpeedBetween(p1, t2, p2, t1, RhumbModel)
+	assert.ErrorIs(t, err, ErrInvalidTimeDelta)
+	_, _, err = SpeedBetween(p1, t1, p2, t1, RhumbModel)
+	assert.ErrorIs(t, err, ErrInvalidTimeDelta)
+
+	sog, cog, err = SpeedBetween(p1, t1, p1, t2, VincentyModel)
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, float64(sog.Mps()))
+	assert.False(t, cog.Valid())
+}
+
+func TestTravelTime(t *testing.T) {
+	d, err := TravelTime(units.NM(120), units.Knot(12))
+	require.NoError(t, err)
+	assert.Equal(t, 10*time.Hour, d)
+
+	d, err = TravelTime(units.Km(1), units.Mps(4))
+	require.NoError(t, err)
+	assert.Equal(t, 250*time.Second, d)
+
+	_, err = TravelTime(units.Km(1), units.Mps(0))
+	assert.ErrorIs(t, err, ErrInvalidSpeed)
+	_, err = TravelTime(units.Km(1), units.Kph(-5))
+	assert.ErrorIs(t, err, ErrInvalidSpeed)
+}
+
+func TestETA(t *testing.T) {
+	p1 := NewLatLon(51.127, 1.338)
+	p2 := NewLatLon(50.964, 1.853)
+	departure := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	eta, err := ETA(p1, p2, units.Kph(40.31), RhumbModel, departure)
+	require.NoError(t, err)
+	assert.WithinDuration(t, departure.Add(time.Hour), eta, 5*time.Second)
+
+	// the round trip with SpeedBetween
+	eta, err = ETA(p1, p2, units.Knot(15), VincentyModel, departure, WGS84())
+	require.NoError(t, err)
+	sog, _, err := SpeedBetween(p1, departure, p2, eta, VincentyModel)
+	require.NoError(t, err)
+	assert.InDelta(t, 15, float64(sog.Knot()), 1e-6)
+
+	eta, err = ETA(p1, p1, units.Knot(15), VincentyModel, departure)
+	require.NoError(t, err)
+	assert.Equal(t, departure, eta)
+
+	_, err = ETA(p1, p2, units.Knot(0), VincentyModel, departure)
+	assert.ErrorIs(t, err, ErrInvalidSpeed)
+}
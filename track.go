@@ -0,0 +1,174 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+	"time"
+
+	"github.com/starboard-nz/units"
+)
+
+// Track is a sequence of positions of a moving object, in time order.
+type Track []Position
+
+// TrackStats are the statistics of a track, see Track.Stats.
+type TrackStats struct {
+	// Distance is the total distance between consecutive fixes
+	Distance units.Distance
+	// Duration is the time from the first fix to the last
+	Duration time.Duration
+	// MaxSpeed is the highest speed between consecutive fixes
+	MaxSpeed units.Speed
+	// AvgSpeed is the average speed, the distance over the duration
+	AvgSpeed units.Speed
+	// MaxTurnRate is the highest rate of turn at a fix, in degrees per minute
+	MaxTurnRate float64
+	// AvgTurnRate is the average rate of turn, the total change of course over the duration, in degrees per minute
+	AvgTurnRate float64
+}
+
+// segment returns the distance in metres, the duration and the course from fix i-1 to fix i of the track
+func (t Track) segment(i int, model EarthModel, modelArgs ...interface{}) (float64, time.Duration, Degrees) {
+	p1, p2 := t[i-1], t[i]
+	dt := p2.Time.Sub(p1.Time)
+	if p1.LatLon.Equals(p2.LatLon) {
+		return 0, dt, Degrees(math.NaN())
+	}
+
+	m := model(p1.LatLon, modelArgs...)
+
+	return float64(m.DistanceTo(p2.LatLon).Metre()), dt, m.InitialBearingTo(p2.LatLon)
+}
+
+// Stats returns the statistics of the track: the total distance, the speeds and the rates of turn, with distances and
+// courses calculated using the given `model`.
+//
+// Arguments:
+//
+// model - a function that converts a `LatLon` to a structure appropriate for the `Model` to be used
+//
+//	This is how you select the model you wish to use for the calculations. See the description of `Model`
+//	for list of available functions.
+//
+// modelArgs - additional arguments to pass to the `model` function, if needed, for example the `Ellipsoid`
+//
+//	for ellipsoid models.
+//
+// Segments between fixes with the same time (or out of order) are included in the distance, but not in the speeds.
+// The rate of turn at a fix is the change of course from the previous segment to the next one over half the time
+// between the previous and the next fix; fixes where the object is stationary are skipped. The speeds and turn rates
+// are 0 if they can't be calculated, for example for tracks with less than 2 (or 3) fixes.
+//
+// Example:
+// stats := track.Stats(geod.VincentyModel)
+// fmt.Printf("%.1fNM at %.1f knots\n", stats.Distance.NM(), stats.AvgSpeed.Knot())
+func (t Track) Stats(model EarthModel, modelArgs ...interface{}) TrackStats {
+	var (
+		distance, maxSpeed, totalTurn, maxTurnRate float64
+		prevCourse                                 = Degrees(math.NaN())
+		prevTime                                   time.Time
+	)
+
+	for i := 1; i < len(t); i++ {
+		d, dt, course := t.segment(i, model, modelArgs...)
+		distance += d
+		if dt > 0 {
+			maxSpeed = math.Max(maxSpeed, d/dt.Seconds())
+		}
+
+		if !course.Valid() {
+			continue
+		}
+
+		if prevCourse.Valid() {
+			turn := math.Abs(float64(Wrap180(course - prevCourse)))
+			totalTurn += turn
+			if dt := t[i].Time.Sub(prevTime); dt > 0 {
+				maxTurnRate = math.Max(maxTurnRate, turn/(dt.Minutes()/2))
+			}
+		}
+		prevCourse, prevTime = course, t[i-1].Time
+	}
+
+	stats := TrackStats{
+		Distance: units.Metre(distance),
+		MaxSpeed: units.Mps(maxSpeed),
+		AvgSpeed: units.Mps(0),
+	}
+
+	if len(t) > 1 {
+		stats.Duration = t[len(t)-1].Time.Sub(t[0].Time)
+	}
+	if stats.Duration > 0 {
+		stats.AvgSpeed = units.Mps(distance / stats.Duration.Seconds())
+		stats.AvgTurnRate = totalTurn / stats.Duration.Minutes()
+	}
+	stats.MaxTurnRate = maxTurnRate
+
+	return stats
+}
+
+// Jumps returns the indices of the fixes of the track that are reached from the previous fix at a speed above
+// `maxSpeed`, using the given `model` (see Stats), for quality control of position data. A fix that moved from the
+// previous one without any time passing is a jump too. A single bad fix is usually flagged twice: at the jump to it
+// and at the jump back.
+//
+// Example:
+// for _, i := range track.Jumps(units.Knot(50), geod.SphericalModel) {
+// log.Printf("implausible position %v at %v", track[i].LatLon, track[i].Time)
+// }
+func (t Track) Jumps(maxSpeed units.Speed, model EarthModel, modelArgs ...interface{}) []int {
+	limit := float64(maxSpeed.Mps())
+
+	var jumps []int
+	for i := 1; i < len(t); i++ {
+		if t.jump(i, limit, model, modelArgs...) {
+			jumps = append(jumps, i)
+		}
+	}
+
+	return jumps
+}
+
+// jump returns true if fix i of the track is reached from the previous fix at a speed above `limit` m/s
+func (t Track) jump(i int, limit float64, model EarthModel, modelArgs ...interface{}) bool {
+	d, dt, _ := t.segment(i, model, modelArgs...)
+
+	return d > 0 && (dt <= 0 || d/dt.Seconds() > limit)
+}
+
+// Split splits the track into segments (e.g. voyages) wherever the time between consecutive fixes is more than
+// `maxGap`, or the speed between them is more than `maxSpeed`, using the given `model` (see Stats). A fix that moved
+// from the previous one without any time passing starts a new segment too. Use 0 for `maxGap` or nil for `maxSpeed`
+// to split only by the other.
+//
+// The segments share the positions with the track, and are returned in order. Returns nil for empty tracks.
+//
+// Example:
+// voyages := track.Split(6*time.Hour, units.Knot(40), geod.SphericalModel)
+func (t Track) Split(maxGap time.Duration, maxSpeed units.Speed, model EarthModel, modelArgs ...interface{}) []Track {
+	if len(t) == 0 {
+		return nil
+	}
+
+	var segments []Track
+	start := 0
+	for i := 1; i < len(t); i++ {
+		split := maxGap > 0 && t[i].Time.Sub(t[i-1].Time) > maxGap
+		if !split && maxSpeed != nil {
+			split = t.jump(i, float64(maxSpeed.Mps()), model, modelArgs...)
+		}
+
+		if split {
+			segments = append(segments, t[start:i:i])
+			start = i
+		}
+	}
+
+	return append(segments, t[start:len(t):len(t)])
+}
@@ -0,0 +1,94 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/starboard-nz/units"
+)
+
+// testTrack returns a track starting at `start`, with a fix every minute at the given courses and speed
+func testTrack(start LatLon, courses []Degrees, speed units.Speed) Track {
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	track := Track{{LatLon: start, Time: t0}}
+	for i, course := range courses {
+		p := DestinationPoint(track[i].LatLon, 60*float64(speed.Mps()), course, SphericalModel)
+		track = append(track, Position{LatLon: p, Time: t0.Add(time.Duration(i+1) * time.Minute)})
+	}
+
+	return track
+}
+
+func TestTrackStats(t *testing.T) {
+	track := testTrack(NewLatLon(-41.3, 174.8), []Degrees{90, 90, 100, 120}, units.Knot(10))
+	stats := track.Stats(SphericalModel)
+
+	assert.InDelta(t, 4*60*float64(units.Knot(10).Mps()), float64(stats.Distance.Metre()), 1e-3)
+	assert.Equal(t, 4*time.Minute, stats.Duration)
+	assert.InDelta(t, 10, float64(stats.AvgSpeed.Knot()), 1e-6)
+	assert.InDelta(t, 10, float64(stats.MaxSpeed.Knot()), 1e-6)
+
+	// the turns are 0°, 10° and 20°, 1 minute apart (between the middles of the segments)
+	assert.InDelta(t, 20, stats.MaxTurnRate, 0.1)
+	assert.InDelta(t, 30.0/4, stats.AvgTurnRate, 0.1)
+
+	// a stop doesn't count as a turn
+	stopped := append(track[:3:3], Position{LatLon: track[2].LatLon, Time: track[2].Time.Add(time.Minute)})
+	stats = stopped.Stats(SphericalModel)
+	assert.InDelta(t, 0, stats.MaxTurnRate, 0.1)
+	assert.InDelta(t, 2*60*float64(units.Knot(10).Mps())/180, float64(stats.AvgSpeed.Mps()), 1e-6)
+
+	stats = Track{track[0]}.Stats(SphericalModel)
+	assert.Zero(t, float64(stats.Distance.Metre()))
+	assert.Zero(t, float64(stats.AvgSpeed.Mps()))
+	assert.Zero(t, float64(stats.MaxSpeed.Mps()))
+	assert.Zero(t, stats.Duration)
+}
+
+func TestTrackJumps(t *testing.T) {
+	track := testTrack(NewLatLon(-41.3, 174.8), []Degrees{90, 90, 90, 90, 90}, units.Knot(10))
+	assert.Empty(t, track.Jumps(units.Knot(12), VincentyModel))
+
+	// a bad fix is flagged on the way there and back
+	track[2].LatLon = NewLatLon(-41.0, 175.0)
+	assert.Equal(t, []int{2, 3}, track.Jumps(units.Knot(12), VincentyModel))
+
+	// moving without time passing
+	track[4].Time = track[3].Time
+	assert.Equal(t, []int{2, 3, 4}, track.Jumps(units.Knot(12), VincentyModel))
+}
+
+func TestTrackSplit(t *testing.T) {
+	track := testTrack(NewLatLon(-41.3, 174.8), []Degrees{90, 90, 90, 90, 90, 90}, units.Knot(10))
+
+	segments := track.Split(time.Hour, units.Knot(20), SphericalModel)
+	assert.Equal(t, []Track{track}, segments)
+
+	// a gap
+	for i := 4; i < len(track); i++ {
+		track[i].Time = track[i].Time.Add(2 * time.Hour)
+	}
+	segments = track.Split(time.Hour, units.Knot(20), VincentyModel)
+	assert.Equal(t, []Track{track[:4], track[4:]}, segments)
+	assert.Equal(t, []Track{track}, track.Split(0, units.Knot(20), SphericalModel))
+
+	// a jump
+	track[2].LatLon = NewLatLon(-41.0, 175.0)
+	segments = track.Split(time.Hour, units.Knot(20), SphericalModel)
+	assert.Equal(t, []Track{track[:2], track[2:3], track[3:4], track[4:]}, segments)
+	assert.Equal(t, []Track{track[:4], track[4:]}, track.Split(time.Hour, nil, SphericalModel))
+
+	// the segments don't overwrite each other
+	segments[0] = append(segments[0], track[6])
+	assert.Equal(t, NewLatLon(-41.0, 175.0), track[2].LatLon)
+
+	assert.Nil(t, Track{}.Split(time.Hour, units.Knot(20), SphericalModel))
+}
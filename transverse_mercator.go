@@ -0,0 +1,143 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+)
+
+// transverseMercatorTolerance is the precision of the tangent of the latitude found by TransverseMercator.Inverse,
+// which normally takes 2 or 3 of the transverseMercatorIterations
+const (
+	transverseMercatorTolerance  = 1e-12
+	transverseMercatorIterations = 10
+)
+
+// TransverseMercator is the Transverse Mercator projection of an ellipsoid, for example for UTM zones or national
+// grids such as the British National Grid. It uses Krüger's series in the third flattening to 6th order, as described
+// by Karney (https://arxiv.org/abs/1002.1417), which is accurate to a few nanometres within 3900km of the central
+// meridian. See https://www.movable-type.co.uk/scripts/latlong-utm-mgrs.html.
+type TransverseMercator struct {
+	lon0 Degrees
+	k0   float64
+	// false easting and northing, including the northing of the origin
+	x0, y0 float64
+
+	e      float64 // eccentricity
+	radius float64 // the rectifying radius, 2π⋅radius is the circumference of a meridian
+	α      [6]float64
+	β      [6]float64
+}
+
+// NewTransverseMercator returns the Transverse Mercator projection of the ellipsoid with the origin at `lat0`, `lon0`
+// (`lon0` is the central meridian), the scale factor `k0` on the central meridian, and the grid coordinates of the
+// origin `falseEasting` and `falseNorthing`, in metres.
+//
+// Example:
+// // the British National Grid, on OSGB36
+// osgb36, _ := geod.LookupDatum("OSGB36")
+// tm := geod.NewTransverseMercator(osgb36.Ellipsoid(), 49, -2, 0.9996012717, 400000, -100000)
+// e, n := tm.Project(geod.NewLatLon(52.65757, 1.71792))      // 651409.9, 313177.3
+func NewTransverseMercator(ellipsoid Ellipsoid, lat0, lon0 Degrees, k0, falseEasting, falseNorthing float64) TransverseMercator {
+	n := ellipsoid.ThirdFlattening()
+	n2, n3, n4, n5, n6 := n*n, n*n*n, n*n*n*n, n*n*n*n*n, n*n*n*n*n*n
+
+	tm := TransverseMercator{
+		lon0:   lon0,
+		k0:     k0,
+		x0:     falseEasting,
+		e:      math.Sqrt(ellipsoid.EccentricitySq()),
+		radius: ellipsoid.a / (1 + n) * (1 + n2/4 + n4/64 + n6/256),
+		α: [6]float64{
+			1.0/2*n - 2.0/3*n2 + 5.0/16*n3 + 41.0/180*n4 - 127.0/288*n5 + 7891.0/37800*n6,
+			13.0/48*n2 - 3.0/5*n3 + 557.0/1440*n4 + 281.0/630*n5 - 1983433.0/1935360*n6,
+			61.0/240*n3 - 103.0/140*n4 + 15061.0/26880*n5 + 167603.0/181440*n6,
+			49561.0/161280*n4 - 179.0/168*n5 + 6601661.0/7257600*n6,
+			34729.0/80640*n5 - 3418889.0/1995840*n6,
+			212378941.0 / 319334400 * n6,
+		},
+		β: [6]float64{
+			1.0/2*n - 2.0/3*n2 + 37.0/96*n3 - 1.0/360*n4 - 81.0/512*n5 + 96199.0/604800*n6,
+			1.0/48*n2 + 1.0/15*n3 - 437.0/1440*n4 + 46.0/105*n5 - 1118711.0/3870720*n6,
+			17.0/480*n3 - 37.0/840*n4 - 209.0/4480*n5 + 5569.0/90720*n6,
+			4397.0/161280*n4 - 11.0/504*n5 - 830251.0/7257600*n6,
+			4583.0/161280*n5 - 108847.0/3991680*n6,
+			20648693.0 / 638668800 * n6,
+		},
+	}
+
+	// the northing of the origin, from the equator
+	_, y := tm.Project(LatLon{Latitude: lat0, Longitude: lon0})
+	tm.y0 = falseNorthing - y
+
+	return tm
+}
+
+// Project returns the grid coordinates (easting and northing) of the point, in metres. `tm.Project` can be used as a
+// Projection.
+func (tm TransverseMercator) Project(ll LatLon) (float64, float64) {
+	φ := ll.Latitude.Radians()
+	λ := Wrap180(ll.Longitude - tm.lon0).Radians()
+
+	// conformal latitude
+	τ := math.Tan(φ)
+	σ := math.Sinh(tm.e * math.Atanh(tm.e*τ/math.Sqrt(1+τ*τ)))
+	τʹ := τ*math.Sqrt(1+σ*σ) - σ*math.Sqrt(1+τ*τ)
+
+	cosλ := math.Cos(λ)
+	ξʹ := math.Atan2(τʹ, cosλ)
+	ηʹ := math.Asinh(math.Sin(λ) / math.Sqrt(τʹ*τʹ+cosλ*cosλ))
+
+	ξ, η := ξʹ, ηʹ
+	for j, α := range tm.α {
+		k := 2 * float64(j+1)
+		ξ += α * math.Sin(k*ξʹ) * math.Cosh(k*ηʹ)
+		η += α * math.Cos(k*ξʹ) * math.Sinh(k*ηʹ)
+	}
+
+	return tm.k0*tm.radius*η + tm.x0, tm.k0*tm.radius*ξ + tm.y0
+}
+
+// Inverse returns the point at the grid coordinates, the inverse of Project.
+func (tm TransverseMercator) Inverse(x, y float64) LatLon {
+	η := (x - tm.x0) / (tm.k0 * tm.radius)
+	ξ := (y - tm.y0) / (tm.k0 * tm.radius)
+
+	ξʹ, ηʹ := ξ, η
+	for j, β := range tm.β {
+		k := 2 * float64(j+1)
+		ξʹ -= β * math.Sin(k*ξ) * math.Cosh(k*η)
+		ηʹ -= β * math.Cos(k*ξ) * math.Sinh(k*η)
+	}
+
+	sinhηʹ := math.Sinh(ηʹ)
+	sinξʹ, cosξʹ := math.Sin(ξʹ), math.Cos(ξʹ)
+	τʹ := sinξʹ / math.Sqrt(sinhηʹ*sinhηʹ+cosξʹ*cosξʹ)
+
+	// Newton's method for the tangent of the latitude from the tangent of the conformal latitude
+	e2 := tm.e * tm.e
+	τ := τʹ
+	for i := 0; i < transverseMercatorIterations; i++ {
+		σ := math.Sinh(tm.e * math.Atanh(tm.e*τ/math.Sqrt(1+τ*τ)))
+		τi := τ*math.Sqrt(1+σ*σ) - σ*math.Sqrt(1+τ*τ)
+		δτ := (τʹ - τi) / math.Sqrt(1+τi*τi) * (1 + (1-e2)*τ*τ) / ((1 - e2) * math.Sqrt(1+τ*τ))
+		τ += δτ
+		if math.Abs(δτ) < transverseMercatorTolerance {
+			break
+		}
+	}
+
+	ll := LatLon{
+		Latitude:  DegreesFromRadians(math.Atan(τ)),
+		Longitude: Wrap180(tm.lon0 + DegreesFromRadians(math.Atan2(sinhηʹ, cosξʹ))),
+	}
+	if !ll.Valid() {
+		return invalidLatLon
+	}
+
+	return ll
+}
@@ -0,0 +1,39 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTransverseMercator(t *testing.T) {
+	// Ordnance Survey, A guide to coordinate systems in Great Britain, C.1
+	bng := NewTransverseMercator(airy1830, 49, -2, 0.9996012717, 400000, -100000)
+	ll := LatLon{Latitude: 52 + 39.0/60 + 27.2531/3600, Longitude: 1 + 43.0/60 + 4.5177/3600}
+	e, n := bng.Project(ll)
+	assert.InDelta(t, 651409.903, e, 1e-3)
+	assert.InDelta(t, 313177.270, n, 1e-3)
+
+	back := bng.Inverse(e, n)
+	assert.InDelta(t, float64(ll.Latitude), float64(back.Latitude), 1e-10)
+	assert.InDelta(t, float64(ll.Longitude), float64(back.Longitude), 1e-10)
+
+	// UTM zone 31N, from GeographicLib
+	utm := NewTransverseMercator(wgs84, 0, 3, 0.9996, 500000, 0)
+	e, n = utm.Project(NewLatLon(0, 0))
+	assert.InDelta(t, 166021.443081, e, 1e-6)
+	assert.InDelta(t, 0, n, 1e-6)
+
+	// far from the central meridian
+	for _, ll := range []LatLon{NewLatLon(-45, 40), NewLatLon(80, -20), NewLatLon(0, 3)} {
+		back := utm.Inverse(utm.Project(ll))
+		assert.InDelta(t, float64(ll.Latitude), float64(back.Latitude), 1e-9)
+		assert.InDelta(t, float64(ll.Longitude), float64(back.Longitude), 1e-9)
+	}
+}
@@ -0,0 +1,81 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+
+	"github.com/starboard-nz/units"
+)
+
+// TriangleSolution holds the sides, angles and area of a spherical triangle ABC, see SphericalTriangle.
+type TriangleSolution struct {
+	// Sides are the lengths of the sides opposite A, B and C, i.e. BC, CA and AB
+	Sides [3]units.Distance
+	// Angles are the interior angles at A, B and C, NaN at a vertex where one of the sides has zero length
+	Angles [3]Degrees
+	// Excess is the spherical excess, the sum of the angles minus 180°
+	Excess Degrees
+	// Area is the area of the triangle in square metres
+	Area float64
+}
+
+// SphericalTriangle solves the spherical triangle with vertices `a`, `b` and `c`, whose sides are great circle arcs,
+// returning the lengths of its sides, its angles and its area. The spherical Earth radius is used (see
+// SetEarthRadius). The triangle is the smaller one of the two defined by the 3 points, so its angles are less than
+// 180°, and its area less than a hemisphere.
+//
+// The spherical excess (and area) is calculated using the formula of van Oosterom and Strackee, which is stable for
+// small and thin triangles: tan(E/2) = |a⋅(b×c)| / (1 + a⋅b + b⋅c + c⋅a), where a, b, c are the n-vectors of the
+// vertices.
+//
+// Example:
+// t := geod.SphericalTriangle(geod.NewLatLon(0, 0), geod.NewLatLon(0, 90), geod.NewLatLon(90, 0))
+// // t.Angles are all 90°, t.Excess is 90°, t.Area is 1/8 of the surface of the Earth
+func SphericalTriangle(a, b, c LatLon) TriangleSolution {
+	va, vb, vc := a.ToNvector(), b.ToNvector(), c.ToNvector()
+
+	side := func(p, q Vector3D) units.Distance {
+		return units.Metre(p.AngleTo(q, nil) * earthRadius)
+	}
+
+	// the angle at p between the great circles to q and r
+	angle := func(p, q, r Vector3D) Degrees {
+		n1 := p.Cross(q)
+		n2 := p.Cross(r)
+		if n1.Length() == 0 || n2.Length() == 0 {
+			return Degrees(math.NaN())
+		}
+
+		return DegreesFromRadians(n1.AngleTo(n2, nil))
+	}
+
+	e := SphericalExcess([]Vector3D{va, vb, vc})
+
+	return TriangleSolution{
+		Sides:  [3]units.Distance{side(vb, vc), side(vc, va), side(va, vb)},
+		Angles: [3]Degrees{angle(va, vb, vc), angle(vb, vc, va), angle(vc, va, vb)},
+		Excess: DegreesFromRadians(e),
+		Area:   e * earthRadius * earthRadius,
+	}
+}
+
+// SphericalExcess returns the spherical excess in radians of the polygon with the n-vectors `vertices`, whose edges are
+// great circle arcs, which is its area on the unit sphere. The polygon may be closed (with the last point the same as
+// the first) or not, and it must be smaller than a hemisphere.
+//
+// The excess is the sum of the signed excesses of the triangles formed by each edge and the first vertex, each
+// calculated with the formula of van Oosterom and Strackee, see SphericalTriangle.
+func SphericalExcess(vertices []Vector3D) float64 {
+	E := 0.0
+	for i := 1; i < len(vertices)-1; i++ {
+		a, b, c := vertices[0], vertices[i], vertices[i+1]
+		E += 2 * math.Atan2(a.Dot(b.Cross(c)), 1+a.Dot(b)+b.Dot(c)+c.Dot(a))
+	}
+
+	return math.Abs(E)
+}
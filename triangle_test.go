@@ -0,0 +1,72 @@
+package geod
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSphericalTriangle(t *testing.T) {
+	tr := SphericalTriangle(NewLatLon(0, 0), NewLatLon(0, 90), NewLatLon(90, 0))
+	for i := 0; i < 3; i++ {
+		assert.InDelta(t, 90, float64(tr.Angles[i]), 1e-12)
+		assert.InDelta(t, math.Pi/2*earthRadius, float64(tr.Sides[i].Metre()), 1e-6)
+	}
+	assert.InDelta(t, 90, float64(tr.Excess), 1e-12)
+	assert.InDelta(t, math.Pi*earthRadius*earthRadius/2, tr.Area, 1)
+
+	// a small triangle is close to a planar one, and the sides agree with the spherical model
+	a, b, c := NewLatLon(-41.29, 174.78), NewLatLon(-41.20, 174.90), NewLatLon(-41.35, 174.95)
+	tr = SphericalTriangle(a, b, c)
+	assert.InDelta(t, float64(Distance(b, c, SphericalModel).Metre()), float64(tr.Sides[0].Metre()), 1e-6)
+	assert.InDelta(t, float64(Distance(c, a, SphericalModel).Metre()), float64(tr.Sides[1].Metre()), 1e-6)
+	assert.InDelta(t, float64(Distance(a, b, SphericalModel).Metre()), float64(tr.Sides[2].Metre()), 1e-6)
+	assert.InDelta(t, 180+float64(tr.Excess), float64(tr.Angles[0]+tr.Angles[1]+tr.Angles[2]), 1e-9)
+	assert.Greater(t, float64(tr.Excess), 0.0)
+
+	angleA := math.Abs(float64(InitialBearing(a, b, SphericalModel) - InitialBearing(a, c, SphericalModel)))
+	assert.InDelta(t, angleA, float64(tr.Angles[0]), 1e-9)
+
+	// the same area in either orientation
+	assert.InDelta(t, tr.Area, SphericalTriangle(a, c, b).Area, 1e-6)
+
+	// degenerate triangles
+	tr = SphericalTriangle(a, a, c)
+	assert.True(t, math.IsNaN(float64(tr.Angles[0])))
+	assert.InDelta(t, 0, tr.Area, 1e-3)
+	tr = SphericalTriangle(NewLatLon(0, 0), NewLatLon(0, 1), NewLatLon(0, 2))
+	assert.InDelta(t, 180, float64(tr.Angles[1]), 1e-9)
+	assert.InDelta(t, 0, tr.Area, 1e-3)
+}
+
+func TestSphericalExcess(t *testing.T) {
+	nvectors := func(lls ...LatLon) []Vector3D {
+		vs := make([]Vector3D, len(lls))
+		for i, ll := range lls {
+			vs[i] = ll.ToNvector()
+		}
+
+		return vs
+	}
+
+	// the same as the triangle
+	a, b, c := NewLatLon(-41.29, 174.78), NewLatLon(-41.20, 174.90), NewLatLon(-41.35, 174.95)
+	assert.InDelta(t, SphericalTriangle(a, b, c).Excess.Radians(), SphericalExcess(nvectors(a, b, c)), 1e-15)
+
+	// a concave polygon, closed or not, in either orientation
+	concave := nvectors(NewLatLon(0, 0), NewLatLon(0, 2), NewLatLon(2, 2), NewLatLon(1, 1), NewLatLon(2, 0))
+	e := SphericalExcess(concave)
+	assert.InDelta(t, 3*(math.Pi/180)*(math.Pi/180), e, 1e-7)
+	assert.InDelta(t, e, SphericalExcess(append(concave, concave[0])), 1e-15)
+	assert.InDelta(t, e, SphericalExcess([]Vector3D{concave[4], concave[3], concave[2], concave[1], concave[0]}), 1e-15)
+
+	// a polygon around the north pole, the cap above 80°
+	var ring []LatLon
+	for lon := -180.0; lon < 180; lon += 1 {
+		ring = append(ring, NewLatLon(80, lon))
+	}
+	assert.InDelta(t, 2*math.Pi*(1-math.Sin(80*math.Pi/180)), SphericalExcess(nvectors(ring...)), 1e-4)
+
+	assert.Zero(t, SphericalExcess(nil))
+}
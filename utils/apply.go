@@ -0,0 +1,521 @@
+package utils
+
+import (
+	"errors"
+	"math"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+// Op is an operation that Apply performs on any geometry, returning a result of type T.
+type Op[T any] struct {
+	// Part returns the result for a single Point, LineString, Ring, Polygon or Bound
+	Part func(geom orb.Geometry) (T, error)
+	// Combine returns the result for a MultiPoint, MultiLineString, MultiPolygon or Collection from the results
+	// for each of its points, line strings, polygons or geometries, in order
+	Combine func(geom orb.Geometry, results []T) (T, error)
+}
+
+// Apply performs the operation on the geometry, by calling `op.Part` for each Point, LineString, Ring, Polygon or
+// Bound in it, and `op.Combine` to combine the results of the parts of MultiPoints, MultiLineStrings, MultiPolygons
+// and Collections (including nested Collections), so that callers don't need their own type switches.
+//
+// All the parts are processed even if the operation fails for some of them, and the first error is returned with the
+// combined result; what the result is then depends on the operation. The ready-made operations are LengthOp,
+// AreaOp, CentroidOp, BoundOp, DensifyOp and SimplifyOp.
+//
+// Example:
+// length, err := utils.Apply(mls, utils.LengthOp(geod.SphericalModel))
+func Apply[T any](geom orb.Geometry, op Op[T]) (T, error) {
+	var parts []orb.Geometry
+
+	switch g := geom.(type) {
+	case orb.MultiPoint:
+		for _, p := range g {
+			parts = append(parts, p)
+		}
+	case orb.MultiLineString:
+		for _, ls := range g {
+			parts = append(parts, ls)
+		}
+	case orb.MultiPolygon:
+		for _, p := range g {
+			parts = append(parts, p)
+		}
+	case orb.Collection:
+		parts = g
+	default:
+		return op.Part(geom)
+	}
+
+	var err error
+
+	results := make([]T, 0, len(parts))
+	for _, part := range parts {
+		res, err2 := Apply(part, op)
+		if err2 != nil && err == nil {
+			err = err2
+		}
+
+		results = append(results, res)
+	}
+
+	res, err2 := op.Combine(geom, results)
+	if err2 != nil && err == nil {
+		err = err2
+	}
+
+	return res, err
+}
+
+// sumOp returns the Op that adds the results of the parts
+func sumOp[T any](part func(orb.Geometry) T, add func(T, T) T) Op[T] {
+	return Op[T]{
+		Part: func(geom orb.Geometry) (T, error) {
+			return part(geom), nil
+		},
+		Combine: func(_ orb.Geometry, results []T) (T, error) {
+			sum := part(nil)
+			for _, r := range results {
+				sum = add(sum, r)
+			}
+
+			return sum, nil
+		},
+	}
+}
+
+// rings returns the rings of a Ring, Polygon or Bound
+func rings(geom orb.Geometry) []orb.Ring {
+	switch g := geom.(type) {
+	case orb.Ring:
+		return []orb.Ring{g}
+	case orb.Polygon:
+		return g
+	case orb.Bound:
+		return g.ToPolygon()
+	}
+
+	return nil
+}
+
+// LengthOp returns the Op for Apply that measures the length of lines and the perimeter of polygons, including
+// their holes, with the distances calculated by `model`. Points have no length.
+func LengthOp(model geod.EarthModel) Op[units.Distance] {
+	lineLength := func(ls []orb.Point) float64 {
+		l := 0.0
+		for i := 0; i < len(ls)-1; i++ {
+			l += pointDistance(ls[i], ls[i+1], model)
+		}
+
+		return l
+	}
+
+	return sumOp(func(geom orb.Geometry) units.Distance {
+		if ls, ok := geom.(orb.LineString); ok {
+			return units.Metre(lineLength(ls))
+		}
+
+		l := 0.0
+		for _, r := range rings(geom) {
+			l += lineLength(r)
+			if len(r) > 1 && r[0] != r[len(r)-1] {
+				l += pointDistance(r[len(r)-1], r[0], model)
+			}
+		}
+
+		return units.Metre(l)
+	}, func(a, b units.Distance) units.Distance {
+		return units.Metre(float64(a.Metre()) + float64(b.Metre()))
+	})
+}
+
+// areaMaxEdgeLength is the length in metres of the pieces that edges are split into to follow the model when
+// measuring areas and centroids
+const areaMaxEdgeLength = 10000
+
+// followModel returns the unit vectors of the points of the ring, with points added along the edges so that they
+// follow `model` within the accuracy of great circle pieces at most areaMaxEdgeLength long. Edges are already great
+// circles for the spherical model.
+func followModel(r orb.Ring, model geod.EarthModel) []geod.Vector3D {
+	if len(r) == 0 {
+		return nil
+	}
+
+	closed := r
+	if r[0] != r[len(r)-1] {
+		closed = append(append(orb.Ring(nil), r...), r[0])
+	}
+
+	_, spherical := model(geod.LatLon{}).(geod.LatLonSpherical)
+
+	vs := make([]geod.Vector3D, 0, len(closed))
+	for i := 0; i < len(closed)-1; i++ {
+		ll0 := geod.LatLon{Latitude: geod.Degrees(closed[i][1]), Longitude: geod.Degrees(closed[i][0])}
+		ll1 := geod.LatLon{Latitude: geod.Degrees(closed[i+1][1]), Longitude: geod.Degrees(closed[i+1][0])}
+		vs = append(vs, ll0.ToNvector())
+
+		if spherical || ll0.Equals(ll1) {
+			continue
+		}
+
+		m0 := model(ll0)
+		n := int(math.Ceil(float64(m0.DistanceTo(ll1).Metre()) / areaMaxEdgeLength))
+		fractions := make([]float64, 0, n)
+		for k := 1; k < n; k++ {
+			fractions = append(fractions, float64(k)/float64(n))
+		}
+		for _, ll := range m0.IntermediatePointsTo(ll1, fractions) {
+			vs = append(vs, ll.ToNvector())
+		}
+	}
+
+	return vs
+}
+
+// ringArea returns the area of the ring on the unit sphere, see geod.SphericalExcess
+func ringArea(vs []geod.Vector3D) float64 {
+	return geod.SphericalExcess(vs)
+}
+
+// polygonArea returns the area of the rings, the first being the outer ring and the others holes, in square metres
+func polygonArea(rs []orb.Ring, model geod.EarthModel) float64 {
+	area := 0.0
+	for i, r := range rs {
+		a := ringArea(followModel(r, model))
+		if i == 0 {
+			area += a
+		} else {
+			area -= a
+		}
+	}
+
+	return area * geod.EarthRadius() * geod.EarthRadius()
+}
+
+// AreaOp returns the Op for Apply that measures the area of polygons in square metres, excluding their holes, with
+// their edges following `model`. The area is calculated on a spherical Earth (see geod.SetEarthRadius), with edges
+// split into great circle pieces to follow models other than the spherical model. Rings must be smaller than a
+// hemisphere. Lines and points have no area.
+func AreaOp(model geod.EarthModel) Op[float64] {
+	return sumOp(func(geom orb.Geometry) float64 {
+		return polygonArea(rings(geom), model)
+	}, func(a, b float64) float64 {
+		return a + b
+	})
+}
+
+// Centroid is the result of CentroidOp.
+type Centroid struct {
+	// Point is the centroid, NaN for empty geometries
+	Point orb.Point
+	// Dimension is the dimension of the parts of the geometry the centroid is calculated from, the highest of its
+	// parts: 2 for polygons, 1 for lines, 0 for points, -1 if the geometry is empty
+	Dimension int
+	// Weight is the area of the polygons in square metres, the length of the lines in metres, or the number of
+	// points, depending on the dimension
+	Weight float64
+}
+
+// CentroidOp returns the Op for Apply that finds the centroid of a geometry, as defined by OGC: the centroid of its
+// parts with the highest dimension, weighted by area, length or number. For example the centroid of a Collection
+// with a Polygon and a Point is the centroid of the polygon.
+//
+// The centroid is calculated on the sphere, as the direction of the sum of the unit vectors of the points, the
+// midpoints of the segments of lines, or the area elements of polygons, weighted by length or area. The segments of
+// lines and edges of polygons follow `model`, and rings must not enclose a pole.
+func CentroidOp(model geod.EarthModel) Op[Centroid] {
+	empty := Centroid{Point: orb.Point{math.NaN(), math.NaN()}, Dimension: -1}
+
+	toPoint := func(v geod.Vector3D) orb.Point {
+		ll := geod.NvectorToLatLon(v)
+
+		return orb.Point{float64(ll.Longitude), float64(ll.Latitude)}
+	}
+
+	return Op[Centroid]{
+		Part: func(geom orb.Geometry) (Centroid, error) {
+			switch g := geom.(type) {
+			case orb.Point:
+				return Centroid{Point: g, Dimension: 0, Weight: 1}, nil
+			case orb.LineString:
+				if len(g) == 0 {
+					return empty, nil
+				}
+
+				var (
+					sum    geod.Vector3D
+					length float64
+				)
+				for i := 0; i < len(g)-1; i++ {
+					ll0 := geod.LatLon{Latitude: geod.Degrees(g[i][1]), Longitude: geod.Degrees(g[i][0])}
+					ll1 := geod.LatLon{Latitude: geod.Degrees(g[i+1][1]), Longitude: geod.Degrees(g[i+1][0])}
+					if ll0.Equals(ll1) {
+						continue
+					}
+
+					m0 := model(ll0)
+					l := float64(m0.DistanceTo(ll1).Metre())
+					sum = sum.Plus(m0.MidPointTo(ll1).ToNvector().Times(l))
+					length += l
+				}
+
+				if length == 0 {
+					// all the points are the same
+					return Centroid{Point: g[0], Dimension: 0, Weight: 1}, nil
+				}
+
+				return Centroid{Point: toPoint(sum), Dimension: 1, Weight: length}, nil
+			}
+
+			rs := rings(geom)
+			if len(rs) == 0 || len(rs[0]) == 0 {
+				return empty, nil
+			}
+
+			var sum geod.Vector3D
+			for i, r := range rs {
+				vs := followModel(r, model)
+
+				// the integral of the unit vectors over the area of the ring
+				var ring, mean geod.Vector3D
+				for j, a := range vs {
+					b := vs[(j+1)%len(vs)]
+					n := a.Cross(b)
+					if l := n.Length(); l > 0 {
+						ring = ring.Plus(n.Times(math.Atan2(l, a.Dot(b)) / (2 * l)))
+					}
+					mean = mean.Plus(a)
+				}
+
+				// clockwise rings give a vector pointing away from the ring
+				if ring.Dot(mean) < 0 {
+					ring = ring.Negate()
+				}
+
+				if i == 0 {
+					sum = sum.Plus(ring)
+				} else {
+					sum = sum.Minus(ring)
+				}
+			}
+
+			area := polygonArea(rs, model)
+			if area == 0 {
+				return Centroid{Point: rs[0][0], Dimension: 0, Weight: 1}, nil
+			}
+
+			return Centroid{Point: toPoint(sum), Dimension: 2, Weight: area}, nil
+		},
+		Combine: func(_ orb.Geometry, results []Centroid) (Centroid, error) {
+			c := empty
+			for _, r := range results {
+				if r.Dimension > c.Dimension {
+					c = Centroid{Dimension: r.Dimension}
+				}
+			}
+
+			if c.Dimension < 0 {
+				return c, nil
+			}
+
+			var sum geod.Vector3D
+			for _, r := range results {
+				if r.Dimension == c.Dimension {
+					ll := geod.LatLon{Latitude: geod.Degrees(r.Point[1]), Longitude: geod.Degrees(r.Point[0])}
+					sum = sum.Plus(ll.ToNvector().Times(r.Weight))
+					c.Weight += r.Weight
+				}
+			}
+
+			c.Point = toPoint(sum)
+
+			return c, nil
+		},
+	}
+}
+
+// BoundOp returns the Op for Apply that finds the bound of a geometry, including the parts of the segments of lines
+// and the edges of polygons that curve north or south of their ends according to `model` (see
+// geod.LatitudeExtremes). Bounds of geometries crossing the antimeridian are handled as for geod.Bound.Union. Empty
+// geometries have a Bound with NaN latitudes, which is ignored when combining bounds.
+func BoundOp(model geod.EarthModel) Op[geod.Bound] {
+	empty := geod.Bound{
+		Min: geod.LatLon{Latitude: geod.Degrees(math.NaN())},
+		Max: geod.LatLon{Latitude: geod.Degrees(math.NaN())},
+	}
+	isEmpty := func(b geod.Bound) bool {
+		return math.IsNaN(float64(b.Min.Latitude))
+	}
+
+	union := func(a, b geod.Bound) geod.Bound {
+		switch {
+		case isEmpty(a):
+			return b
+		case isEmpty(b):
+			return a
+		}
+
+		return a.Union(b)
+	}
+
+	lineBound := func(ls []orb.Point, closed bool) geod.Bound {
+		b := empty
+		for i, p := range ls {
+			ll := geod.LatLon{Latitude: geod.Degrees(p[1]), Longitude: geod.Degrees(p[0])}
+			b = union(b, geod.NewBound(ll, ll))
+
+			j := i + 1
+			if j == len(ls) {
+				if !closed {
+					break
+				}
+				j = 0
+			}
+
+			if p != ls[j] {
+				ll1 := geod.LatLon{Latitude: geod.Degrees(ls[j][1]), Longitude: geod.Degrees(ls[j][0])}
+				north, south := geod.LatitudeExtremes(ll, ll1, model)
+				b.Max.Latitude = geod.Degrees(math.Max(float64(b.Max.Latitude), float64(north.Latitude)))
+				b.Min.Latitude = geod.Degrees(math.Min(float64(b.Min.Latitude), float64(south.Latitude)))
+			}
+		}
+
+		return b
+	}
+
+	return sumOp(func(geom orb.Geometry) geod.Bound {
+		switch g := geom.(type) {
+		case orb.Point:
+			return lineBound([]orb.Point{g}, false)
+		case orb.LineString:
+			return lineBound(g, false)
+		}
+
+		b := empty
+		for _, r := range rings(geom) {
+			b = union(b, lineBound(r, true))
+		}
+
+		return b
+	}, union)
+}
+
+// rebuild returns the multi-geometry or collection `geom` with its parts replaced by `parts`, dropping parts that
+// are nil, and returning nil if no parts are left
+func rebuild(geom orb.Geometry, parts []orb.Geometry) orb.Geometry {
+	switch geom.(type) {
+	case orb.MultiPoint:
+		mp := make(orb.MultiPoint, 0, len(parts))
+		for _, p := range parts {
+			if p != nil {
+				mp = append(mp, p.(orb.Point))
+			}
+		}
+		if len(mp) == 0 {
+			return nil
+		}
+
+		return mp
+	case orb.MultiLineString:
+		mls := make(orb.MultiLineString, 0, len(parts))
+		for _, p := range parts {
+			if p != nil {
+				mls = append(mls, p.(orb.LineString))
+			}
+		}
+		if len(mls) == 0 {
+			return nil
+		}
+
+		return mls
+	case orb.MultiPolygon:
+		mp := make(orb.MultiPolygon, 0, len(parts))
+		for _, p := range parts {
+			if p != nil {
+				mp = append(mp, p.(orb.Polygon))
+			}
+		}
+		if len(mp) == 0 {
+			return nil
+		}
+
+		return mp
+	}
+
+	c := make(orb.Collection, 0, len(parts))
+	for _, p := range parts {
+		if p != nil {
+			c = append(c, p)
+		}
+	}
+
+	return c
+}
+
+// DensifyOp returns the Op for Apply that densifies lines and polygons with DensifyRing (see DensifyPolygon for the
+// arguments). Bounds are densified as polygons, and points are returned unchanged. Like DensifyPolygon, the
+// densified geometry is returned with ErrToleranceTooLow if the tolerance couldn't be met everywhere.
+func DensifyOp(model, refModel geod.EarthModel, tolerance units.Distance, opts ...DensifyOption) Op[orb.Geometry] {
+	return Op[orb.Geometry]{
+		Part: func(geom orb.Geometry) (orb.Geometry, error) {
+			switch g := geom.(type) {
+			case orb.LineString:
+				if len(g) < 2 {
+					return g, nil
+				}
+
+				if tolerance.Metre() <= 0 {
+					return nil, ErrInvalidTolerance
+				}
+
+				config := newDensifyConfig(opts)
+
+				var err error
+
+				dls := orb.LineString{g[0]}
+				for i := 1; i < len(g); i++ {
+					var err2 error
+					dls, err2 = appendDensifiedSegment(dls, g[i-1], g[i], i-1, model, refModel, tolerance, config)
+					if err2 != nil {
+						if !errors.Is(err2, ErrToleranceTooLow) {
+							return nil, err2
+						}
+
+						err = err2
+					}
+				}
+
+				return dls, err
+			case orb.Ring:
+				return DensifyRing(g, model, refModel, tolerance, opts...)
+			case orb.Polygon:
+				return DensifyPolygon(g, model, refModel, tolerance, opts...)
+			case orb.Bound:
+				return DensifyPolygon(g.ToPolygon(), model, refModel, tolerance, opts...)
+			}
+
+			return geom, nil
+		},
+		Combine: func(geom orb.Geometry, results []orb.Geometry) (orb.Geometry, error) {
+			return rebuild(geom, results), nil
+		},
+	}
+}
+
+// SimplifyOp returns the Op for Apply that simplifies lines and polygons with Simplify. As for Simplify, parts that
+// collapse are removed.
+func SimplifyOp(tolerance units.Distance, model geod.EarthModel) Op[orb.Geometry] {
+	s := simplifier{tolerance: float64(tolerance.Metre()), model: model}
+
+	return Op[orb.Geometry]{
+		Part: func(geom orb.Geometry) (orb.Geometry, error) {
+			return s.geometry(geom), nil
+		},
+		Combine: func(geom orb.Geometry, results []orb.Geometry) (orb.Geometry, error) {
+			return rebuild(geom, results), nil
+		},
+	}
+}
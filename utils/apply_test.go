@@ -0,0 +1,150 @@
+package utils_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+func TestApplyLength(t *testing.T) {
+	degree := 111194.93
+
+	mls := orb.MultiLineString{{{0, 0}, {1, 0}}, {{5, 0}, {5, 1}, {6, 1}}}
+	l, err := utils.Apply(mls, utils.LengthOp(geod.SphericalModel))
+	require.NoError(t, err)
+	assert.InDelta(t, (2+math.Cos(1*math.Pi/180))*degree, float64(l.Metre()), 1)
+
+	// perimeter, including the holes, of a rhumb line square with an unclosed ring
+	poly := orb.Polygon{
+		{{0, 0}, {1, 0}, {1, 1}, {0, 1}},
+		{{0.25, 0.25}, {0.25, 0.75}, {0.75, 0.75}, {0.75, 0.25}, {0.25, 0.25}},
+	}
+	l, err = utils.Apply(orb.Collection{poly, orb.Point{3, 3}}, utils.LengthOp(geod.RhumbModel))
+	require.NoError(t, err)
+	assert.InDelta(t, (3+math.Cos(1*math.Pi/180)+1.5+0.5*math.Cos(0.75*math.Pi/180))*degree, float64(l.Metre()), 1)
+
+	l, err = utils.Apply(orb.Point{1, 1}, utils.LengthOp(geod.RhumbModel))
+	require.NoError(t, err)
+	assert.Equal(t, 0.0, float64(l.Metre()))
+}
+
+func TestApplyArea(t *testing.T) {
+	R := geod.EarthRadius()
+	square := orb.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}
+
+	// the area between parallels is 2πR²(sin φ2 - sin φ1) per 360°
+	expected := R * R * math.Pi / 180 * math.Sin(math.Pi/180)
+	a, err := utils.Apply(square, utils.AreaOp(geod.RhumbModel))
+	require.NoError(t, err)
+	assert.InEpsilon(t, expected, a, 1e-6)
+
+	// the great circle along the northern edge curves north of the parallel
+	a, err = utils.Apply(square, utils.AreaOp(geod.SphericalModel))
+	require.NoError(t, err)
+	assert.Greater(t, a, expected)
+	assert.InEpsilon(t, expected, a, 1e-3)
+
+	// holes are excluded, and the orientation of the rings doesn't matter
+	holed := orb.Polygon{square[0], {{0.5, 0}, {0.5, 1}, {1, 1}, {1, 0}, {0.5, 0}}}
+	a, err = utils.Apply(orb.Collection{orb.MultiPolygon{holed}, orb.LineString{{0, 0}, {1, 1}}}, utils.AreaOp(geod.RhumbModel))
+	require.NoError(t, err)
+	assert.InEpsilon(t, expected/2, a, 1e-6)
+}
+
+func TestApplyCentroid(t *testing.T) {
+	c, err := utils.Apply(orb.MultiPoint{{0, 0}, {2, 0}}, utils.CentroidOp(geod.SphericalModel))
+	require.NoError(t, err)
+	assert.Equal(t, 0, c.Dimension)
+	assert.Equal(t, 2.0, c.Weight)
+	assert.InDelta(t, 1, c.Point[0], 1e-9)
+	assert.InDelta(t, 0, c.Point[1], 1e-9)
+
+	// weighted by length
+	c, err = utils.Apply(orb.LineString{{0, 0}, {3, 0}, {3, 1}}, utils.CentroidOp(geod.SphericalModel))
+	require.NoError(t, err)
+	assert.Equal(t, 1, c.Dimension)
+	assert.InDelta(t, 1.875, c.Point[0], 1e-3)
+	assert.InDelta(t, 0.125, c.Point[1], 1e-3)
+
+	// the polygon has more area near the equator, and the point is ignored
+	square := orb.Polygon{{{0, 0}, {0, 2}, {2, 2}, {2, 0}, {0, 0}}}
+	c, err = utils.Apply(orb.Collection{orb.Point{10, 10}, square}, utils.CentroidOp(geod.RhumbModel))
+	require.NoError(t, err)
+	assert.Equal(t, 2, c.Dimension)
+	assert.InDelta(t, 1, c.Point[0], 1e-9)
+	assert.Less(t, c.Point[1], 1.0)
+	assert.Greater(t, c.Point[1], 0.999)
+
+	a, err := utils.Apply(square, utils.AreaOp(geod.RhumbModel))
+	require.NoError(t, err)
+	assert.InEpsilon(t, a, c.Weight, 1e-12)
+
+	// a hole in the eastern half moves the centroid west
+	holed := orb.Polygon{square[0], {{1, 0.5}, {1.5, 0.5}, {1.5, 1.5}, {1, 1.5}, {1, 0.5}}}
+	c, err = utils.Apply(holed, utils.CentroidOp(geod.RhumbModel))
+	require.NoError(t, err)
+	assert.InDelta(t, (4-0.5*1.25)/3.5, c.Point[0], 1e-3)
+
+	c, err = utils.Apply(orb.Collection{}, utils.CentroidOp(geod.RhumbModel))
+	require.NoError(t, err)
+	assert.Equal(t, -1, c.Dimension)
+	assert.True(t, math.IsNaN(c.Point[0]))
+}
+
+func TestApplyBound(t *testing.T) {
+	ls := orb.LineString{{-30, 50}, {30, 50}}
+
+	b, err := utils.Apply(ls, utils.BoundOp(geod.SphericalModel))
+	require.NoError(t, err)
+	assert.InDelta(t, 53.99479, float64(b.Max.Latitude), 1e-5)
+	assert.Equal(t, geod.Degrees(50), b.Min.Latitude)
+	assert.Equal(t, geod.Degrees(-30), b.Min.Longitude)
+	assert.Equal(t, geod.Degrees(30), b.Max.Longitude)
+
+	b, err = utils.Apply(ls, utils.BoundOp(geod.RhumbModel))
+	require.NoError(t, err)
+	assert.Equal(t, geod.Degrees(50), b.Max.Latitude)
+
+	// across the antimeridian
+	b, err = utils.Apply(orb.Collection{orb.MultiPoint{{170, 0}, {-170, 1}}, orb.MultiLineString{}}, utils.BoundOp(geod.RhumbModel))
+	require.NoError(t, err)
+	assert.True(t, b.CrossesAntimeridian())
+	assert.Equal(t, geod.Degrees(170), b.Min.Longitude)
+	assert.Equal(t, geod.Degrees(-170), b.Max.Longitude)
+	assert.Equal(t, geod.Degrees(1), b.Max.Latitude)
+}
+
+func TestApplyDensifySimplify(t *testing.T) {
+	mls := orb.MultiLineString{{{0, 10}, {20, 10}}, {{0, 20}, {20, 20}}}
+	g, err := utils.Apply(orb.Collection{mls, orb.Point{1, 1}}, utils.DensifyOp(geod.SphericalModel, geod.RhumbModel, units.Metre(100)))
+	require.NoError(t, err)
+	require.IsType(t, orb.Collection{}, g)
+
+	c := g.(orb.Collection)
+	require.Len(t, c, 2)
+	assert.Equal(t, orb.Point{1, 1}, c[1])
+
+	dense := c[0].(orb.MultiLineString)
+	require.Len(t, dense, 2)
+	for i, ls := range dense {
+		assert.Greater(t, len(ls), 10)
+		assert.Equal(t, mls[i][0], ls[0])
+		assert.Equal(t, mls[i][1], ls[len(ls)-1])
+	}
+
+	// simplifying removes the points again, and the polygon that collapses
+	mp := orb.MultiPolygon{{{{0, 0}, {0.001, 0}, {0.001, 0.00001}, {0, 0}}}, {{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}}
+	g, err = utils.Apply(orb.Collection{dense, mp}, utils.SimplifyOp(units.Metre(200), geod.SphericalModel))
+	require.NoError(t, err)
+	assert.Equal(t, orb.Collection{mls, orb.MultiPolygon{mp[1]}}, g)
+
+	_, err = utils.Apply(mls, utils.DensifyOp(geod.SphericalModel, geod.RhumbModel, units.Metre(0)))
+	assert.ErrorIs(t, err, utils.ErrInvalidTolerance)
+}
@@ -0,0 +1,148 @@
+package utils
+
+import (
+	"math"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+// nearestPointSamples is the number of points checked along a segment before refining the nearest one
+const nearestPointSamples = 8
+
+// nearestPointTolerance is the precision of the fraction of the segment where the nearest point is, fine enough for
+// the 1cm tolerance of the topological predicates on segments of thousands of kilometres
+const nearestPointTolerance = 1e-9
+
+// NearestPointOnSegment returns the point on the segment p0-p1 that is closest to `point`, and its distance from
+// `point`. The shape of the segment and the distance are defined by `model`.
+func NearestPointOnSegment(point, p0, p1 orb.Point, model geod.EarthModel) (orb.Point, units.Distance) {
+	ll := geod.LatLon{Latitude: geod.Degrees(point[1]), Longitude: geod.Degrees(point[0])}
+	ll0 := geod.LatLon{Latitude: geod.Degrees(p0[1]), Longitude: geod.Degrees(p0[0])}
+	ll1 := geod.LatLon{Latitude: geod.Degrees(p1[1]), Longitude: geod.Degrees(p1[0])}
+
+	m := model(ll)
+	distanceTo := func(other geod.LatLon) float64 {
+		return float64(m.DistanceTo(other).Metre())
+	}
+
+	nearest, d := ll0, distanceTo(ll0)
+	if d1 := distanceTo(ll1); d1 < d {
+		nearest, d = ll1, d1
+	}
+
+	if !ll0.Equals(ll1) {
+		m0 := model(ll0)
+		negDistanceAt := func(fraction float64) float64 {
+			return -distanceTo(m0.IntermediatePointTo(ll1, fraction))
+		}
+
+		negD, fraction := maximiseOnSegment(negDistanceAt, nearestPointSamples, nearestPointTolerance)
+		if -negD < d {
+			nearest, d = m0.IntermediatePointTo(ll1, fraction), -negD
+		}
+	}
+
+	return orb.Point{float64(nearest.Longitude), float64(nearest.Latitude)}, units.Metre(d)
+}
+
+// OnBoundary returns true if the point is within `tolerance` of the boundary of the polygon, including the boundaries
+// of its holes. The shape of the edges and the distances are defined by `model`.
+func OnBoundary(poly orb.Polygon, point orb.Point, tolerance units.Distance, model geod.EarthModel) bool {
+	ll := geod.LatLon{Latitude: geod.Degrees(point[1]), Longitude: geod.Degrees(point[0])}
+	tol := tolerance.Metre()
+
+	for _, ring := range poly {
+		if len(ring) == 0 {
+			continue
+		}
+
+		// see the NOTE on containment about densification
+		if !geod.BoundFromOrb(ring.Bound()).Pad(tolerance).Contains(ll) {
+			continue
+		}
+
+		if _, d := NearestPointOnSegment(point, ring[len(ring)-1], ring[0], model); d.Metre() <= tol {
+			return true
+		}
+
+		for i := 0; i < len(ring)-1; i++ {
+			if _, d := NearestPointOnSegment(point, ring[i], ring[i+1], model); d.Metre() <= tol {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// SignedDistance returns the distance from the point to the nearest edge of the polygon, including the edges of its
+// holes: negative if the point is inside the polygon and positive if it is outside, so for example a point within
+// 500m of entering the polygon has a distance between 0 and 500m. The shape of the edges and the distances are defined
+// by `model`, and the point is tested with PolygonContains (see the NOTE on containment about densification).
+// Returns NaN for an empty polygon.
+func SignedDistance(poly orb.Polygon, point orb.Point, model geod.EarthModel) units.Distance {
+	d := math.Inf(1)
+	for _, ring := range poly {
+		if len(ring) == 0 {
+			continue
+		}
+
+		if _, di := NearestPointOnSegment(point, ring[len(ring)-1], ring[0], model); float64(di.Metre()) < d {
+			d = float64(di.Metre())
+		}
+
+		for i := 0; i < len(ring)-1; i++ {
+			if _, di := NearestPointOnSegment(point, ring[i], ring[i+1], model); float64(di.Metre()) < d {
+				d = float64(di.Metre())
+			}
+		}
+	}
+
+	if math.IsInf(d, 1) {
+		return units.Metre(math.NaN())
+	}
+
+	if d > 0 && PolygonContains(poly, point, model) {
+		d = -d
+	}
+
+	return units.Metre(d)
+}
+
+// NearestPolygon returns the index of the polygon of the multipolygon whose boundary, including the boundaries of its
+// holes, is closest to the point, the closest point on that boundary and its distance from the point, for example to
+// find the nearest zone or port. Points inside a polygon are measured to its boundary too, see SignedDistance for
+// telling them apart. The shape of the edges and the distances are defined by `model`.
+// Returns -1, an empty point and NaN if there are no edges.
+//
+// All the edges are checked: the polygons can't be skipped by the distance to their bounds, as great circle and
+// geodesic edges bulge outside the bounds of their vertices.
+func NearestPolygon(mp orb.MultiPolygon, point orb.Point, model geod.EarthModel) (int, orb.Point, units.Distance) {
+	best, nearest, d := -1, orb.Point{}, math.Inf(1)
+	check := func(i int, p0, p1 orb.Point) {
+		if np, di := NearestPointOnSegment(point, p0, p1, model); float64(di.Metre()) < d {
+			best, nearest, d = i, np, float64(di.Metre())
+		}
+	}
+
+	for i, poly := range mp {
+		for _, ring := range poly {
+			if len(ring) == 0 {
+				continue
+			}
+
+			check(i, ring[len(ring)-1], ring[0])
+			for k := 0; k < len(ring)-1; k++ {
+				check(i, ring[k], ring[k+1])
+			}
+		}
+	}
+
+	if best < 0 {
+		return -1, orb.Point{}, units.Metre(math.NaN())
+	}
+
+	return best, nearest, units.Metre(d)
+}
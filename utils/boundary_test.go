@@ -0,0 +1,142 @@
+package utils_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+func TestNearestPointOnSegment(t *testing.T) {
+	p0 := orb.Point{0, 0}
+	p1 := orb.Point{10, 0}
+
+	np, d := utils.NearestPointOnSegment(orb.Point{5, 1}, p0, p1, geod.SphericalModel)
+	assert.InDelta(t, 5, np[0], 1e-4)
+	assert.InDelta(t, 0, np[1], 1e-9)
+	assert.InDelta(t, 111.1949, float64(d.Km()), 1e-4)
+
+	// beyond the end of the segment
+	np, d = utils.NearestPointOnSegment(orb.Point{12, 0}, p0, p1, geod.SphericalModel)
+	assert.Equal(t, p1, np)
+	assert.InDelta(t, 222.3898, float64(d.Km()), 1e-4)
+
+	// great circles bulge towards the pole, rhumb lines don't
+	p0 = orb.Point{0, 60}
+	p1 = orb.Point{40, 60}
+	_, dg := utils.NearestPointOnSegment(orb.Point{20, 61}, p0, p1, geod.SphericalModel)
+	_, dr := utils.NearestPointOnSegment(orb.Point{20, 61}, p0, p1, geod.RhumbModel)
+	assert.Less(t, float64(dg.Km()), float64(dr.Km()))
+	assert.InDelta(t, 111.1949, float64(dr.Km()), 1e-3)
+
+	// at the start of the segment
+	np, d = utils.NearestPointOnSegment(p0, p0, p1, geod.VincentyModel)
+	assert.Equal(t, p0, np)
+	assert.Equal(t, 0.0, float64(d.Metre()))
+}
+
+func TestOnBoundary(t *testing.T) {
+	p := orb.Polygon{
+		{{0, 0}, {3, 0}, {3, 3}, {0, 3}, {0, 0}},
+		{{1, 1}, {1, 2}, {2, 2}, {2, 1}, {1, 1}},
+	}
+
+	cases := []struct {
+		name   string
+		point  orb.Point
+		result bool
+	}{
+		{"on outer edge", orb.Point{1.5, 0}, true},
+		{"near outer edge inside", orb.Point{1.5, 0.00005}, true},
+		{"near outer edge outside", orb.Point{1.5, -0.00005}, true},
+		{"near vertex", orb.Point{3.00005, 3.00005}, true},
+		{"near hole edge", orb.Point{1.5, 0.99995}, true},
+		{"inside", orb.Point{0.5, 0.5}, false},
+		{"in hole", orb.Point{1.5, 1.5}, false},
+		{"outside", orb.Point{1.5, -0.5}, false},
+	}
+
+	for _, model := range []geod.EarthModel{geod.PlanarModel, geod.RhumbModel, geod.SphericalModel} {
+		for _, tc := range cases {
+			assert.Equal(t, tc.result, utils.OnBoundary(p, tc.point, units.Metre(10), model), tc.name)
+		}
+	}
+
+	// antimeridian crossing in 0..360
+	p = orb.Polygon{{{170, -10}, {190, -10}, {190, 10}, {170, 10}, {170, -10}}}
+	assert.True(t, utils.OnBoundary(p, orb.Point{-170.00001, 0}, units.Metre(10), geod.SphericalModel))
+	assert.False(t, utils.OnBoundary(p, orb.Point{-175, 0}, units.Metre(10), geod.SphericalModel))
+}
+
+func TestSignedDistance(t *testing.T) {
+	// 2° square with a hole
+	poly := orb.Polygon{
+		{{0, -1}, {2, -1}, {2, 1}, {0, 1}, {0, -1}},
+		{{0.5, -0.5}, {1.5, -0.5}, {1.5, 0.5}, {0.5, 0.5}, {0.5, -0.5}},
+	}
+	degree := 111194.93
+
+	d := utils.SignedDistance(poly, orb.Point{0.1, 0}, geod.SphericalModel)
+	assert.InDelta(t, -0.1*degree, float64(d.Metre()), 1)
+
+	d = utils.SignedDistance(poly, orb.Point{-0.2, 0}, geod.SphericalModel)
+	assert.InDelta(t, 0.2*degree, float64(d.Metre()), 1)
+
+	// in the hole is outside
+	d = utils.SignedDistance(poly, orb.Point{1, 0}, geod.SphericalModel)
+	assert.InDelta(t, 0.5*degree, float64(d.Metre()), 1)
+
+	d = utils.SignedDistance(poly, orb.Point{2, 0}, geod.SphericalModel)
+	assert.InDelta(t, 0.0, float64(d.Metre()), 0.001)
+
+	assert.True(t, math.IsNaN(float64(utils.SignedDistance(orb.Polygon{}, orb.Point{0, 0}, geod.SphericalModel).Metre())))
+}
+
+func TestNearestPolygon(t *testing.T) {
+	square := func(x, y, size float64) orb.Polygon {
+		return orb.Polygon{{{x, y}, {x + size, y}, {x + size, y + size}, {x, y + size}, {x, y}}}
+	}
+	mp := orb.MultiPolygon{square(0, 0, 1), square(3, 0, 1), square(10, 10, 2)}
+
+	i, np, d := utils.NearestPolygon(mp, orb.Point{2.8, 0.5}, geod.SphericalModel)
+	assert.Equal(t, 1, i)
+	assert.InDelta(t, 3, np[0], 1e-9)
+	assert.InDelta(t, 0.5, np[1], 1e-4)
+	assert.InDelta(t, 0.2*111.1949, float64(d.Km()), 0.01)
+
+	// the same as checking every polygon
+	for _, p := range []orb.Point{{1.9, 0.5}, {0.5, 0.5}, {8, 11}, {-20, 40}} {
+		i, _, d = utils.NearestPolygon(mp, p, geod.RhumbModel)
+		for k, poly := range mp {
+			assert.LessOrEqual(t, float64(d.Metre()), math.Abs(float64(utils.SignedDistance(poly, p, geod.RhumbModel).Metre())))
+			if k == i {
+				assert.InDelta(t, float64(d.Metre()), math.Abs(float64(utils.SignedDistance(poly, p, geod.RhumbModel).Metre())), 1e-6)
+			}
+		}
+	}
+
+	// inside a polygon, closer to its hole than to its outer ring
+	holed := orb.MultiPolygon{{square(0, 0, 10)[0], square(4, 4, 1)[0]}}
+	i, np, d = utils.NearestPolygon(holed, orb.Point{3.5, 4.5}, geod.SphericalModel)
+	assert.Equal(t, 0, i)
+	assert.InDelta(t, 4, np[0], 1e-9)
+	assert.InDelta(t, 0.5*111.1949*math.Cos(4.5*math.Pi/180), float64(d.Km()), 0.05)
+
+	// the great circle edge of a bulges north of its bound, closer than the decoy b
+	a := orb.Polygon{{{0, 50}, {90, 50}, {90, 60}, {0, 60}, {0, 50}}}
+	b := square(-136, 86.5, 1)
+	for _, mp := range []orb.MultiPolygon{{a, b}, {b, a}} {
+		i, _, d = utils.NearestPolygon(mp, orb.Point{45, 80}, geod.SphericalModel)
+		assert.Equal(t, a, mp[i])
+		assert.InDelta(t, 1357, float64(d.Km()), 1)
+	}
+
+	i, _, d = utils.NearestPolygon(nil, orb.Point{0, 0}, geod.SphericalModel)
+	assert.Equal(t, -1, i)
+	assert.True(t, math.IsNaN(float64(d.Metre())))
+}
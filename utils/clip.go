@@ -0,0 +1,230 @@
+package utils
+
+import (
+	"math"
+	"sort"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+)
+
+// ZoneCrossing is a point where a line enters or leaves a polygon.
+type ZoneCrossing struct {
+	// Point is where the line crosses the boundary of the polygon
+	Point orb.Point
+	// Segment is the index of the segment of the line (from point Segment to point Segment+1) that crosses
+	Segment int
+	// Fraction is the position of the crossing along the segment, by distance, 0..1
+	Fraction float64
+	// Entering is true if the line enters the polygon at the crossing, false if it leaves
+	Entering bool
+}
+
+// lineCut is a point where a segment of the line meets the boundary of the polygon
+type lineCut struct {
+	fraction float64
+	point    orb.Point
+}
+
+// clipFractionTolerance is how close cuts along a segment have to be to be merged
+const clipFractionTolerance = 1e-12
+
+// ClipLineToPolygon returns the parts of the line inside the polygon, and the points where the line enters and leaves
+// the polygon, in order along the line. The fractions of the crossings can be used to interpolate the times of
+// the crossings for tracks, for example to compute the time spent in a zone.
+//
+// The intersections of the line with the boundary are found with SegmentIntersection, and the parts of the line
+// between the intersections are tested with PolygonContains with the given model, so the same NOTE about
+// densification applies, to both the line and the polygon. Polygons crossing the antimeridian should use 0..360
+// longitudes, as for the containment functions, and the line must use the same range.
+//
+// Lines that touch the boundary without crossing it have no crossings, and parts of the line along the boundary are
+// considered inside.
+func ClipLineToPolygon(ls orb.LineString, poly orb.Polygon, model geod.EarthModel) (orb.MultiLineString, []ZoneCrossing) {
+	if len(ls) == 0 || len(poly) == 0 {
+		return nil, nil
+	}
+
+	var (
+		parts   orb.MultiLineString
+		current orb.LineString
+	)
+
+	crossings := walkLine(ls, poly, model, func(a, b orb.Point, in bool) {
+		if !in {
+			if len(current) > 0 {
+				parts = append(parts, current)
+				current = nil
+			}
+
+			return
+		}
+
+		if len(current) == 0 {
+			current = append(current, a)
+		}
+		if b != a || len(current) == 1 && len(ls) == 1 {
+			current = append(current, b)
+		}
+	})
+
+	if len(current) > 0 {
+		parts = append(parts, current)
+	}
+
+	return parts, crossings
+}
+
+// BoundaryCrossings returns the points where the line crosses the boundary of the polygon, including the boundaries of
+// its holes, with the segments of the line they are on and whether the line enters or leaves the polygon, in order
+// along the line. These are the crossings returned by ClipLineToPolygon, without the parts of the line, so the same
+// notes apply. Segments of the line that don't meet the boundary are found with a SegmentIndex of the rings and not
+// tested for containment, so long lines are processed quickly.
+//
+// Example:
+//
+//	for _, c := range utils.BoundaryCrossings(track, zone, geod.SphericalModel) {
+//		if c.Entering {
+//			entry := times[c.Segment].Add(time.Duration(c.Fraction * float64(times[c.Segment+1].Sub(times[c.Segment]))))
+//			...
+//		}
+//	}
+func BoundaryCrossings(track orb.LineString, poly orb.Polygon, model geod.EarthModel) []ZoneCrossing {
+	if len(track) < 2 || len(poly) == 0 {
+		return nil
+	}
+
+	return walkLine(track, poly, model, nil)
+}
+
+// walkLine splits the line at its intersections with the boundary of the polygon, calls `piece` (if not nil) for
+// each piece in order along the line, with whether it is inside the polygon, and returns the crossings. Only the
+// pieces of segments that meet the boundary are tested for containment, the others are on the same side as the end
+// of the previous segment. A line with a single point is a piece from the point to itself.
+func walkLine(ls orb.LineString, poly orb.Polygon, model geod.EarthModel,
+	piece func(a, b orb.Point, in bool)) []ZoneCrossing {
+	bounds := orb.PolygonBoundsFromPolygon(poly)
+	contains := func(p orb.Point) bool {
+		return PolygonWithBoundContains(poly, bounds, p, model)
+	}
+	edges := newSegmentIndexes(poly)
+
+	var crossings []ZoneCrossing
+
+	inside := contains(ls[0])
+	if len(ls) == 1 {
+		if piece != nil {
+			piece(ls[0], ls[0], inside)
+		}
+
+		return nil
+	}
+
+	for i := 0; i < len(ls)-1; i++ {
+		cuts, touches := segmentCuts(ls[i], ls[i+1], edges, model)
+		if !touches {
+			if piece != nil {
+				piece(ls[i], ls[i+1], inside)
+			}
+
+			continue
+		}
+
+		ll0 := geod.LatLon{Latitude: geod.Degrees(ls[i][1]), Longitude: geod.Degrees(ls[i][0])}
+		ll1 := geod.LatLon{Latitude: geod.Degrees(ls[i+1][1]), Longitude: geod.Degrees(ls[i+1][0])}
+		m0 := model(ll0)
+
+		for j := 0; j < len(cuts)-1; j++ {
+			a, b := cuts[j], cuts[j+1]
+
+			mid := ls[i]
+			if !ll0.Equals(ll1) {
+				ll := m0.IntermediatePointTo(ll1, (a.fraction+b.fraction)/2)
+				mid = orb.Point{float64(ll.Longitude), float64(ll.Latitude)}
+			}
+
+			in := contains(mid)
+			if in != inside {
+				crossings = append(crossings, ZoneCrossing{Point: a.point, Segment: i, Fraction: a.fraction, Entering: in})
+				inside = in
+			}
+
+			if piece != nil {
+				piece(a.point, b.point, in)
+			}
+		}
+	}
+
+	return crossings
+}
+
+// newSegmentIndexes returns the indexes of the segments of the rings, for segmentCuts
+func newSegmentIndexes(rings []orb.Ring) []*SegmentIndex {
+	indexes := make([]*SegmentIndex, len(rings))
+	for i, r := range rings {
+		indexes[i] = NewSegmentIndex(orb.LineString(r))
+	}
+
+	return indexes
+}
+
+// segmentCuts returns the start and end of the segment and its intersections with the indexed edges (e.g. the rings
+// of a polygon), including the ends of the edges along it, sorted by their distance from `p0`, and whether the
+// segment meets any edge, including at its ends.
+// Zero length segments are reported as meeting the edges.
+func segmentCuts(p0, p1 orb.Point, edges []*SegmentIndex, model geod.EarthModel) ([]lineCut, bool) {
+	cuts := []lineCut{{fraction: 0, point: p0}, {fraction: 1, point: p1}}
+
+	ll0 := geod.LatLon{Latitude: geod.Degrees(p0[1]), Longitude: geod.Degrees(p0[0])}
+	ll1 := geod.LatLon{Latitude: geod.Degrees(p1[1]), Longitude: geod.Degrees(p1[0])}
+	if ll0.Equals(ll1) {
+		return cuts, true
+	}
+
+	m0 := model(ll0)
+	length := float64(m0.DistanceTo(ll1).Metre())
+	touches := false
+
+	for _, index := range edges {
+		for _, k := range index.Search(math.Min(p0[0], p1[0]), math.Max(p0[0], p1[0])) {
+			q0, q1 := index.Segment(k)
+			is, _, _, overlap := SegmentIntersectionParams(p0, p1, q0, q1)
+			if is == nil {
+				continue
+			}
+			touches = true
+
+			// a collinear edge is cut at its ends too, so that the pieces are either along it or not
+			points := []orb.Point{*is}
+			if overlap {
+				for _, q := range []orb.Point{q0, q1} {
+					if on, _, _, _ := SegmentIntersectionParams(p0, p1, q, q); on != nil {
+						points = append(points, q)
+					}
+				}
+			}
+
+			for _, p := range points {
+				ll := geod.LatLon{Latitude: geod.Degrees(p[1]), Longitude: geod.Degrees(p[0])}
+				f := float64(m0.DistanceTo(ll).Metre()) / length
+				if f > clipFractionTolerance && f < 1-clipFractionTolerance {
+					cuts = append(cuts, lineCut{fraction: f, point: p})
+				}
+			}
+		}
+	}
+
+	sort.Slice(cuts, func(i, j int) bool { return cuts[i].fraction < cuts[j].fraction })
+
+	// merge the intersections at the vertices of the polygon
+	merged := cuts[:1]
+	for _, c := range cuts[1:] {
+		if c.fraction-merged[len(merged)-1].fraction > clipFractionTolerance {
+			merged = append(merged, c)
+		} else if c.fraction == 1 {
+			merged[len(merged)-1] = c
+		}
+	}
+
+	return merged, touches
+}
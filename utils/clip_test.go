@@ -0,0 +1,109 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+)
+
+func TestClipLineToPolygon(t *testing.T) {
+	square := orb.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}}
+
+	// straight through
+	ls := orb.LineString{{-0.5, 0.5}, {1.5, 0.5}}
+	parts, crossings := utils.ClipLineToPolygon(ls, square, geod.RhumbModel)
+	require.Len(t, parts, 1)
+	require.Len(t, parts[0], 2)
+	assert.InDelta(t, 0, parts[0][0][0], 1e-9)
+	assert.InDelta(t, 1, parts[0][1][0], 1e-9)
+	require.Len(t, crossings, 2)
+	assert.True(t, crossings[0].Entering)
+	assert.False(t, crossings[1].Entering)
+	assert.Equal(t, 0, crossings[0].Segment)
+	assert.InDelta(t, 0.25, crossings[0].Fraction, 1e-6)
+	assert.InDelta(t, 0.75, crossings[1].Fraction, 1e-6)
+
+	// starting inside, leaving and coming back
+	ls = orb.LineString{{0.5, 0.5}, {2, 0.5}, {2, 0.8}, {0.5, 0.8}}
+	parts, crossings = utils.ClipLineToPolygon(ls, square, geod.RhumbModel)
+	require.Len(t, parts, 2)
+	assert.Equal(t, orb.Point{0.5, 0.5}, parts[0][0])
+	assert.Equal(t, orb.Point{0.5, 0.8}, parts[1][len(parts[1])-1])
+	require.Len(t, crossings, 2)
+	assert.False(t, crossings[0].Entering)
+	assert.Equal(t, 0, crossings[0].Segment)
+	assert.True(t, crossings[1].Entering)
+	assert.Equal(t, 2, crossings[1].Segment)
+
+	// through a hole
+	holed := orb.Polygon{square[0], {{0.4, 0.4}, {0.6, 0.4}, {0.6, 0.6}, {0.4, 0.6}, {0.4, 0.4}}}
+	parts, crossings = utils.ClipLineToPolygon(orb.LineString{{-1, 0.5}, {2, 0.5}}, holed, geod.RhumbModel)
+	assert.Len(t, parts, 2)
+	assert.Len(t, crossings, 4)
+
+	// through a vertex, and touching a vertex without entering
+	_, crossings = utils.ClipLineToPolygon(orb.LineString{{-1, -1}, {2, 2}}, square, geod.RhumbModel)
+	assert.Len(t, crossings, 2)
+	parts, crossings = utils.ClipLineToPolygon(orb.LineString{{-1, 1}, {1, -1}}, orb.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}, geod.RhumbModel)
+	assert.Empty(t, crossings)
+	assert.Empty(t, parts)
+
+	// outside
+	parts, crossings = utils.ClipLineToPolygon(orb.LineString{{2, 2}, {3, 3}}, square, geod.RhumbModel)
+	assert.Nil(t, parts)
+	assert.Nil(t, crossings)
+
+	// across the antimeridian
+	am := orb.Polygon{{{179, -1}, {181, -1}, {181, 1}, {179, 1}, {179, -1}}}
+	parts, crossings = utils.ClipLineToPolygon(orb.LineString{{178, 0}, {182, 0}}, am, geod.SphericalModel)
+	require.Len(t, parts, 1)
+	assert.InDelta(t, 179, parts[0][0][0], 1e-9)
+	assert.InDelta(t, 181, parts[0][1][0], 1e-9)
+	assert.Len(t, crossings, 2)
+}
+
+func TestBoundaryCrossings(t *testing.T) {
+	holed := orb.Polygon{
+		{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}},
+		{{0.4, 0.4}, {0.6, 0.4}, {0.6, 0.6}, {0.4, 0.6}, {0.4, 0.4}},
+	}
+
+	// a long zig-zag track, mostly away from the polygon
+	var track orb.LineString
+	for i := 0; i <= 200; i++ {
+		track = append(track, orb.Point{-5 + float64(i)*0.05, 0.5 + 0.3*float64(i%2)})
+	}
+
+	crossings := utils.BoundaryCrossings(track, holed, geod.SphericalModel)
+	_, expected := utils.ClipLineToPolygon(track, holed, geod.SphericalModel)
+	assert.Equal(t, expected, crossings)
+	require.NotEmpty(t, crossings)
+
+	for i, c := range crossings {
+		assert.Equal(t, i%2 == 0, c.Entering)
+		assert.InDelta(t, track[c.Segment][0]+c.Fraction*(track[c.Segment+1][0]-track[c.Segment][0]), c.Point[0], 1e-6)
+		if i > 0 {
+			prev := crossings[i-1]
+			assert.True(t, c.Segment > prev.Segment || c.Segment == prev.Segment && c.Fraction > prev.Fraction)
+		}
+	}
+
+	// through the vertices of the polygon and of the hole, and touching a vertex without entering
+	assert.Len(t, utils.BoundaryCrossings(orb.LineString{{-1, -1}, {2, 2}}, holed, geod.RhumbModel), 4)
+	triangle := orb.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}
+	assert.Empty(t, utils.BoundaryCrossings(orb.LineString{{-1, 1}, {1, -1}}, triangle, geod.RhumbModel))
+
+	// starting on the boundary
+	crossings = utils.BoundaryCrossings(orb.LineString{{0, 0.2}, {-1, 0.2}}, holed, geod.RhumbModel)
+	require.Len(t, crossings, 1)
+	assert.False(t, crossings[0].Entering)
+	assert.Equal(t, 0.0, crossings[0].Fraction)
+
+	assert.Nil(t, utils.BoundaryCrossings(orb.LineString{{2, 2}, {3, 3}}, holed, geod.RhumbModel))
+	assert.Nil(t, utils.BoundaryCrossings(orb.LineString{{0.5, 0.2}}, holed, geod.RhumbModel))
+}
@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"sort"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+// ClusterNoise is the cluster label ClusterPoints gives to points that don't belong to any cluster.
+const ClusterNoise = -1
+
+// unclassified marks points not yet visited by ClusterPoints
+const unclassified = -2
+
+// ClusterPoints groups the points using the DBSCAN density based clustering algorithm, with distances measured using
+// the given model. A point is a core point of a cluster if there are at least `minPts` points (including itself)
+// within `eps` distance, and clusters are formed by core points within `eps` of each other and the points within `eps`
+// of them.
+//
+// Returns the cluster label of each point, numbered from 0, or ClusterNoise for points not in any cluster.
+//
+// Candidate neighbours are found using a window of latitudes, sized using the length of a degree of latitude at the
+// equator according to the model, so it works across the antimeridian and near the poles.
+func ClusterPoints(points []orb.Point, eps units.Distance, minPts int, model geod.EarthModel) []int {
+	n := len(points)
+	labels := make([]int, n)
+	for i := range labels {
+		labels[i] = unclassified
+	}
+
+	// points sorted by latitude
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return points[order[a]][1] < points[order[b]][1] })
+
+	lats := make([]float64, n)
+	for k, i := range order {
+		lats[k] = points[i][1]
+	}
+
+	window := float64(eps.Metre()) / minDegreeLatitude(model)
+
+	neighbours := func(i int) []int {
+		ll := geod.LatLon{Latitude: geod.Degrees(points[i][1]), Longitude: geod.Degrees(points[i][0])}
+		m := model(ll)
+
+		var res []int
+		for k := sort.SearchFloat64s(lats, points[i][1]-window); k < n && lats[k] <= points[i][1]+window; k++ {
+			j := order[k]
+			llj := geod.LatLon{Latitude: geod.Degrees(points[j][1]), Longitude: geod.Degrees(points[j][0])}
+			if m.DistanceTo(llj).Metre() <= eps.Metre() {
+				res = append(res, j)
+			}
+		}
+
+		return res
+	}
+
+	cluster := 0
+	for i := range points {
+		if labels[i] != unclassified {
+			continue
+		}
+
+		seeds := neighbours(i)
+		if len(seeds) < minPts {
+			labels[i] = ClusterNoise
+			continue
+		}
+
+		labels[i] = cluster
+		for len(seeds) > 0 {
+			j := seeds[0]
+			seeds = seeds[1:]
+
+			if labels[j] == ClusterNoise {
+				// border point
+				labels[j] = cluster
+			}
+			if labels[j] != unclassified {
+				continue
+			}
+
+			labels[j] = cluster
+			if nj := neighbours(j); len(nj) >= minPts {
+				seeds = append(seeds, nj...)
+			}
+		}
+
+		cluster++
+	}
+
+	return labels
+}
+
+// minDegreeLatitude returns a lower bound of the length of a degree of latitude in metres, according to the model.
+// Points that differ by more than d/minDegreeLatitude(model) degrees of latitude are more than d metres apart.
+func minDegreeLatitude(model geod.EarthModel) float64 {
+	// a small margin for rounding errors and ellipsoids whose degree of latitude is shortest at the equator
+	degree := float64(model(geod.LatLon{}).DistanceTo(geod.LatLon{Latitude: 1}).Metre())
+
+	return degree / 1.001
+}
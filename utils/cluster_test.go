@@ -0,0 +1,60 @@
+package utils_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+func TestClusterPoints(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	var points []orb.Point
+	blob := func(centre orb.Point, n int, size float64) {
+		for i := 0; i < n; i++ {
+			points = append(points, orb.Point{centre[0] + (rng.Float64()-0.5)*size, centre[1] + (rng.Float64()-0.5)*size})
+		}
+	}
+
+	blob(orb.Point{174.76, -36.85}, 20, 0.005)
+	blob(orb.Point{179.999, 10}, 10, 0.001) // across the antimeridian
+	blob(orb.Point{-179.999, 10}, 10, 0.001)
+	blob(orb.Point{0, 89.996}, 10, 0.002) // around the pole
+	blob(orb.Point{180, 89.996}, 10, 0.002)
+	points = append(points, orb.Point{0, 0}, orb.Point{10, 10})
+
+	for _, model := range []geod.EarthModel{geod.SphericalModel, geod.VincentyModel} {
+		labels := utils.ClusterPoints(points, units.Km(1), 4, model)
+		require.Len(t, labels, len(points))
+
+		for i := 1; i < 20; i++ {
+			assert.Equal(t, labels[0], labels[i])
+		}
+		for i := 21; i < 40; i++ {
+			assert.Equal(t, labels[20], labels[i])
+		}
+		for i := 41; i < 60; i++ {
+			assert.Equal(t, labels[40], labels[i])
+		}
+		assert.NotEqual(t, labels[0], labels[20])
+		assert.NotEqual(t, labels[20], labels[40])
+		assert.NotEqual(t, utils.ClusterNoise, labels[0])
+		assert.NotEqual(t, utils.ClusterNoise, labels[20])
+		assert.NotEqual(t, utils.ClusterNoise, labels[40])
+		assert.Equal(t, utils.ClusterNoise, labels[60])
+		assert.Equal(t, utils.ClusterNoise, labels[61])
+	}
+
+	// too sparse for any clusters
+	labels := utils.ClusterPoints(points, units.Metre(1), 4, geod.SphericalModel)
+	for _, l := range labels {
+		assert.Equal(t, utils.ClusterNoise, l)
+	}
+}
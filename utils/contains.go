@@ -7,31 +7,64 @@ import (
 	"github.com/starboard-nz/orb"
 )
 
+// ContainsOption selects how the containment functions decide if a point is inside a ring.
+type ContainsOption func(*containsConfig)
+
+type containsConfig struct {
+	windingNumber bool
+	workers       int
+}
+
+// WithWindingNumber makes the containment functions sum the angles subtended by the ring's edges at the point
+// (the winding number algorithm) instead of casting a ray. It's slower, but more robust for degenerate vertices and
+// self-touching rings. Points are inside if the ring winds around them at least once in either direction.
+func WithWindingNumber() ContainsOption {
+	return func(c *containsConfig) {
+		c.windingNumber = true
+	}
+}
+
+// parallelMinSegments is the number of segments below which the ray casting isn't split across goroutines
+const parallelMinSegments = 4096
+
+// WithWorkers makes the containment functions split the segments of rings with many vertices (thousands, e.g.
+// densified EEZ boundaries) across `workers` goroutines when casting rays, combining the number of crossings found
+// by each. It has no effect on small rings, or with WithWindingNumber. The number of goroutines is limited by
+// geod.MaxWorkers.
+func WithWorkers(workers int) ContainsOption {
+	return func(c *containsConfig) {
+		c.workers = workers
+	}
+}
+
+func newContainsConfig(opts []ContainsOption) containsConfig {
+	var c containsConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
 // NOTE for the following containment functions, we assume the passed Ring/Polygon/Multipolygon has been sufficiently
 // densified according to the relevant EarthModel so that the bounds tests will be accurate - this is releveant in the
 // case of spherical and ellipsoidal EarthModels, the bounds will still be accurate if using the rhumb model.
 
 // RingContains returns true if the point is inside the ring.
 // Points on the boundary of the external ring are considered in, points on the bondary of a hole are not.
-func RingContains(r orb.Ring, point orb.Point, isHole bool, model geod.EarthModel) bool {
+func RingContains(r orb.Ring, point orb.Point, isHole bool, model geod.EarthModel, opts ...ContainsOption) bool {
 	if !r.Bound().Contains(point) {
 		return false
 	}
 
-	c, on := rayIntersect(point, r[0], r[len(r)-1], model)
-	if on {
-		return !isHole
+	config := newContainsConfig(opts)
+	if config.windingNumber {
+		return windingContains(r, point, isHole, model)
 	}
 
-	for i := 0; i < len(r)-1; i++ {
-		inter, on := rayIntersect(point, r[i], r[i+1], model)
-		if on {
-			return !isHole
-		}
-
-		if inter {
-			c = !c
-		}
+	c, on := raycast(r, point, model, config.workers)
+	if on {
+		return !isHole
 	}
 
 	return c
@@ -39,13 +72,13 @@ func RingContains(r orb.Ring, point orb.Point, isHole bool, model geod.EarthMode
 
 // PolygonContains checks if the point is within the polygon.
 // Points on the boundary of the external ring are considered in, points on the bondary of a hole are not.
-func PolygonContains(p orb.Polygon, point orb.Point, model geod.EarthModel) bool {
-	if !RingContains(p[0], point, false, model) {
+func PolygonContains(p orb.Polygon, point orb.Point, model geod.EarthModel, opts ...ContainsOption) bool {
+	if !RingContains(p[0], point, false, model, opts...) {
 		return false
 	}
 
 	for i := 1; i < len(p); i++ {
-		if RingContains(p[i], point, true, model) {
+		if RingContains(p[i], point, true, model, opts...) {
 			return false
 		}
 	}
@@ -55,9 +88,9 @@ func PolygonContains(p orb.Polygon, point orb.Point, model geod.EarthModel) bool
 
 // MultiPolygonContains checks if the point is within the multi-polygon.
 // Points on the boundary of the external ring are considered in, points on the bondary of a hole are not.
-func MultiPolygonContains(mp orb.MultiPolygon, point orb.Point, model geod.EarthModel) bool {
+func MultiPolygonContains(mp orb.MultiPolygon, point orb.Point, model geod.EarthModel, opts ...ContainsOption) bool {
 	for _, p := range mp {
-		if PolygonContains(p, point, model) {
+		if PolygonContains(p, point, model, opts...) {
 			return true
 		}
 	}
@@ -69,7 +102,7 @@ func MultiPolygonContains(mp orb.MultiPolygon, point orb.Point, model geod.Earth
 // This is an optimization of RingContains that avoids re-calculating the bound for each
 // point that is tested.
 // Points on the boundary of the external ring are considered in, points on the bondary of a hole are not.
-func RingWithBoundContains(r orb.Ring, bound orb.Bound, point orb.Point, isHole bool, model geod.EarthModel) bool {
+func RingWithBoundContains(r orb.Ring, bound orb.Bound, point orb.Point, isHole bool, model geod.EarthModel, opts ...ContainsOption) bool {
 
 	if bound.IsZero() || bound.IsEmpty() {
 		bound = r.Bound()
@@ -79,20 +112,14 @@ func RingWithBoundContains(r orb.Ring, bound orb.Bound, point orb.Point, isHole
 		return false
 	}
 
-	c, on := rayIntersect(point, r[len(r)-1], r[0], model)
-	if on {
-		return !isHole // A point intersecting the edge of a hole also intersects the "inner" border of the external ring
+	config := newContainsConfig(opts)
+	if config.windingNumber {
+		return windingContains(r, point, isHole, model)
 	}
 
-	for i := 0; i < len(r)-1; i++ {
-		inter, on := rayIntersect(point, r[i], r[i+1], model)
-		if on {
-			return !isHole
-		}
-
-		if inter {
-			c = !c // If an odd number of ray intersections are detected contains will be true
-		}
+	c, on := raycast(r, point, model, config.workers)
+	if on {
+		return !isHole // A point intersecting the edge of a hole also intersects the "inner" border of the external ring
 	}
 
 	return c
@@ -103,17 +130,17 @@ func RingWithBoundContains(r orb.Ring, bound orb.Bound, point orb.Point, isHole
 // This is an optimization of PolygonContains that avoids re-calculating the bounds for each point
 // that is tested.
 // Points on the boundary of the external ring are considered in, points on the bondary of a hole are not.
-func PolygonWithBoundContains(poly orb.Polygon, bounds orb.PolygonBounds, point orb.Point, model geod.EarthModel) bool {
+func PolygonWithBoundContains(poly orb.Polygon, bounds orb.PolygonBounds, point orb.Point, model geod.EarthModel, opts ...ContainsOption) bool {
 	if bounds == nil {
 		bounds = orb.PolygonBoundsFromPolygon(poly)
 	}
 
-	if !RingWithBoundContains(poly[0], bounds[0], point, false, model) {
+	if !RingWithBoundContains(poly[0], bounds[0], point, false, model, opts...) {
 		return false
 	}
 
 	for i := 1; i < len(poly); i++ {
-		if RingWithBoundContains(poly[i], bounds[i], point, true, model) {
+		if RingWithBoundContains(poly[i], bounds[i], point, true, model, opts...) {
 			return false
 		}
 	}
@@ -126,13 +153,13 @@ func PolygonWithBoundContains(poly orb.Polygon, bounds orb.PolygonBounds, point
 // This is an optimization of MultiPolygonContains that avoids re-calculating the bounds for each point
 // that is tested.
 // Points on the boundary of the external ring are considered in, points on the bondary of a hole are not.
-func MultiPolygonWithBoundContains(mp orb.MultiPolygon, multiBounds orb.MultiPolygonBounds, point orb.Point, model geod.EarthModel) bool {
+func MultiPolygonWithBoundContains(mp orb.MultiPolygon, multiBounds orb.MultiPolygonBounds, point orb.Point, model geod.EarthModel, opts ...ContainsOption) bool {
 	if multiBounds == nil {
 		multiBounds = orb.MultiPolygonBoundsFromMultiPolygon(mp)
 	}
 
 	for i, poly := range mp {
-		if PolygonWithBoundContains(poly, multiBounds[i], point, model) {
+		if PolygonWithBoundContains(poly, multiBounds[i], point, model, opts...) {
 			return true
 		}
 	}
@@ -140,12 +167,85 @@ func MultiPolygonWithBoundContains(mp orb.MultiPolygon, multiBounds orb.MultiPol
 	return false
 }
 
-// We need to test these functions in -180 to 180 and the translated/denormalised polygons
+// raycast casts a ray from the point across the segments of the ring, including the segment closing the ring, and
+// returns true if it crosses an odd number of them, and if the point is on one of them. With more than 1 worker
+// the segments of large rings are split between goroutines.
+func raycast(r orb.Ring, point orb.Point, model geod.EarthModel, workers int) (c, on bool) {
+	if limit := geod.MaxWorkers(); workers > limit {
+		workers = limit
+	}
+
+	if workers <= 1 || len(r) < parallelMinSegments {
+		return raycastSegments(r, point, model, 0, len(r))
+	}
+
+	type result struct{ c, on bool }
+
+	results := make(chan result, workers)
+	chunk := (len(r) + workers - 1) / workers
+	n := 0
+	for from := 0; from < len(r); from += chunk {
+		to := from + chunk
+		if to > len(r) {
+			to = len(r)
+		}
+
+		n++
+		go func(from, to int) {
+			c, on := raycastSegments(r, point, model, from, to)
+			results <- result{c: c, on: on}
+		}(from, to)
+	}
+
+	for i := 0; i < n; i++ {
+		res := <-results
+		c = c != res.c // If an odd number of ray intersections are detected contains will be true
+		on = on || res.on
+	}
+
+	return c, on
+}
+
+// raycastSegments implements raycast for the segments `from` to `to`, where segment i is from r[i] to r[i+1] and
+// the last segment closes the ring.
+func raycastSegments(r orb.Ring, point orb.Point, model geod.EarthModel, from, to int) (c, on bool) {
+	for i := from; i < to; i++ {
+		j := i + 1
+		if j == len(r) {
+			j = 0
+		}
+
+		inter, on := RayIntersects(point, r[i], r[j], model)
+		if on {
+			return false, true
+		}
+
+		if inter {
+			c = !c
+		}
+	}
+
+	return c, false
+}
+
+// RayIntersects casts a ray from the point `p` due north (towards increasing latitude) and returns true if it
+// crosses the segment from segStart to segEnd, with the shape of the segment defined by `model`. This is the
+// primitive used by the containment functions: a point is inside a ring if its ray crosses an odd number of the
+// ring's segments. If `p` is on the segment (including its ends) `onSegment` is true and `crosses` is false.
+//
+// The longitudes are used as they are, so the point and the segment must use the same range, e.g. 0..360 for
+// segments crossing the antimeridian. Rays are cast in longitude/latitude space, so the segment is only crossed if
+// `p` is within the longitude range of the segment and below it; the shape of the segment according to `model` only
+// matters when `p` is within its latitude range.
+//
+// If `p` has the same longitude as an end of the segment (but isn't that end), it's moved east by the smallest
+// possible amount, so that a ray through a vertex shared by 2 segments of a ring is counted for only one of them
+// (the segment extending east of the vertex). It follows that a ray through the end of a segment running along a
+// meridian never crosses it, and that a point on a segment running along a meridian is on it.
+//
 // Original implementation: http://rosettacode.org/wiki/Ray-casting_algorithm#Go
-// This implementation of rayInteresect is specifically designed for use in ring containment because we move the x value of
-// p ever so slightly for cases were it will intersect the current segment and an adjacent polygon segment which would
-// negate the containment test.
-func rayIntersect(p, s, e orb.Point, model geod.EarthModel) (intersects, on bool) {
+func RayIntersects(p, segStart, segEnd orb.Point, model geod.EarthModel) (crosses, onSegment bool) {
+	s, e := segStart, segEnd
 	if s[0] > e[0] {
 		// s = start, e = end. Always get the smaller x/lng value for start.
 		// This probably doesn't work across the AM which is why we denormalise the polygons first.
@@ -199,14 +299,22 @@ func rayIntersect(p, s, e orb.Point, model geod.EarthModel) (intersects, on bool
 		}
 	}
 
-	bs := geod.InitialBearing( // Bearing of segment
-		geod.LatLon{Latitude: geod.Degrees(s[1]), Longitude: geod.Degrees(s[0])},
-		geod.LatLon{Latitude: geod.Degrees(e[1]), Longitude: geod.Degrees(e[0])},
-		model)
-	bp := geod.InitialBearing( // Bearing of line from segment start to p
-		geod.LatLon{Latitude: geod.Degrees(s[1]), Longitude: geod.Degrees(s[0])},
-		geod.LatLon{Latitude: geod.Degrees(p[1]), Longitude: geod.Degrees(p[0])},
-		model)
+	ms := model(geod.LatLon{Latitude: geod.Degrees(s[1]), Longitude: geod.Degrees(s[0])})
+
+	if _, planar := ms.(geod.LatLonPlanar); planar {
+		// the bearings are compared in degree space, which is the orientation of p relative to the segment
+		cross := (e[0]-s[0])*(p[1]-s[1]) - (e[1]-s[1])*(p[0]-s[0])
+		if cross == 0 {
+			return false, true
+		}
+
+		return cross < 0, false
+	}
+
+	bs := ms.InitialBearingTo( // Bearing of segment
+		geod.LatLon{Latitude: geod.Degrees(e[1]), Longitude: geod.Degrees(e[0])})
+	bp := ms.InitialBearingTo( // Bearing of line from segment start to p
+		geod.LatLon{Latitude: geod.Degrees(p[1]), Longitude: geod.Degrees(p[0])})
 
 	if bs == bp {
 		return false, true
@@ -214,3 +322,51 @@ func rayIntersect(p, s, e orb.Point, model geod.EarthModel) (intersects, on bool
 
 	return bs <= bp, false // Equal sign not needed here
 }
+
+// windingOnTolerance is how close (in degrees) to 180° the angle subtended by an edge has to be for the point to be
+// considered on the edge.
+const windingOnTolerance = 1e-9
+
+// windingContains implements RingContains using the winding number of the ring around the point.
+func windingContains(r orb.Ring, point orb.Point, isHole bool, model geod.EarthModel) bool {
+	winding, on := windingNumber(r, point, model)
+	if on {
+		return !isHole
+	}
+
+	return winding != 0
+}
+
+// windingNumber returns the number of times the ring winds around the point (positive clockwise) by adding up the
+// angles between the bearings from the point to consecutive vertices, and whether the point is on the ring.
+func windingNumber(r orb.Ring, point orb.Point, model geod.EarthModel) (int, bool) {
+	if len(r) == 0 {
+		return 0, false
+	}
+
+	m := model(geod.LatLon{Latitude: geod.Degrees(point[1]), Longitude: geod.Degrees(point[0])})
+
+	bearings := make([]float64, len(r))
+	for i, v := range r {
+		if v[0] == point[0] && v[1] == point[1] {
+			return 0, true
+		}
+
+		bearings[i] = float64(m.InitialBearingTo(geod.LatLon{Latitude: geod.Degrees(v[1]), Longitude: geod.Degrees(v[0])}))
+	}
+
+	var sum float64
+	for i := range bearings {
+		// the last edge closes the ring, it has zero length if the ring is closed already
+		next := bearings[(i+1)%len(bearings)]
+
+		δ := math.Mod(next-bearings[i]+540, 360) - 180
+		if math.Abs(δ) >= 180-windingOnTolerance {
+			return 0, true
+		}
+
+		sum += δ
+	}
+
+	return int(math.Round(sum / 360)), false
+}
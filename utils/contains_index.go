@@ -0,0 +1,166 @@
+package utils
+
+import (
+	"math"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+)
+
+// maxSegmentIndexBuckets is the maximum number of longitude intervals of a SegmentIndex
+const maxSegmentIndexBuckets = 4096
+
+// SegmentIndex is an index of the segments of a line (segment i is from point i to point i+1) by longitude, so that
+// algorithms can find the segments whose longitude range includes a longitude, or overlaps a range of longitudes,
+// without checking every segment. It's worth building for lines and rings with many vertices that are queried many
+// times. The longitudes are used as they are, so lines crossing the antimeridian should use 0..360 longitudes, as for
+// the containment functions. The line must not be modified after creating the index.
+type SegmentIndex struct {
+	line  orb.LineString
+	bound orb.Bound
+
+	// the segments spanning each of the equal longitude intervals of the bound
+	buckets [][]int32
+	width   float64
+}
+
+// NewSegmentIndex returns the index of the segments of the line. Rings can be indexed as lines too, as the last
+// point of orb rings is the same as the first.
+func NewSegmentIndex(ls orb.LineString) *SegmentIndex {
+	si := &SegmentIndex{line: ls, bound: ls.Bound()}
+	if len(ls) < 2 {
+		return si
+	}
+
+	n := len(ls)/8 + 1
+	if n > maxSegmentIndexBuckets {
+		n = maxSegmentIndexBuckets
+	}
+
+	si.buckets = make([][]int32, n)
+	si.width = (si.bound.Max[0] - si.bound.Min[0]) / float64(n)
+
+	for i := 0; i < len(ls)-1; i++ {
+		from, to := si.segmentBuckets(i)
+		for b := from; b <= to; b++ {
+			si.buckets[b] = append(si.buckets[b], int32(i))
+		}
+	}
+
+	return si
+}
+
+// bucket returns the index of the longitude interval of x
+func (si *SegmentIndex) bucket(x float64) int {
+	if si.width == 0 {
+		return 0
+	}
+
+	b := int((x - si.bound.Min[0]) / si.width)
+	if b < 0 {
+		return 0
+	}
+	if b >= len(si.buckets) {
+		return len(si.buckets) - 1
+	}
+
+	return b
+}
+
+// segmentBuckets returns the first and last longitude intervals spanned by segment i
+func (si *SegmentIndex) segmentBuckets(i int) (int, int) {
+	x0, x1 := si.line[i][0], si.line[i+1][0]
+
+	return si.bucket(math.Min(x0, x1)), si.bucket(math.Max(x0, x1))
+}
+
+// Line returns the indexed line.
+func (si *SegmentIndex) Line() orb.LineString {
+	return si.line
+}
+
+// Segment returns the start and end of segment i.
+func (si *SegmentIndex) Segment(i int) (orb.Point, orb.Point) {
+	return si.line[i], si.line[i+1]
+}
+
+// Search returns the indices of the segments whose longitude range may overlap the range from `minX` to `maxX`, in
+// no particular order. All the segments that overlap the range are returned, and possibly a few others nearby.
+func (si *SegmentIndex) Search(minX, maxX float64) []int {
+	if len(si.buckets) == 0 || maxX < si.bound.Min[0] || minX > si.bound.Max[0] {
+		return nil
+	}
+
+	from, to := si.bucket(minX), si.bucket(maxX)
+
+	var segments []int
+	for b := from; b <= to; b++ {
+		for _, i := range si.buckets[b] {
+			// segments spanning several intervals are only returned for the first one in the range
+			if first, _ := si.segmentBuckets(int(i)); first < b && b > from {
+				continue
+			}
+			segments = append(segments, int(i))
+		}
+	}
+
+	return segments
+}
+
+// RingIndex is a ring with its segments indexed by longitude, so that containment tests only check the segments
+// whose longitude range includes the point, instead of every segment. It's worth building for rings with many
+// vertices that are tested against many points. The ring must not be modified after creating the index.
+type RingIndex struct {
+	ring     orb.Ring
+	segments *SegmentIndex
+}
+
+// NewRingIndex returns the index of the ring.
+func NewRingIndex(r orb.Ring) *RingIndex {
+	ls := orb.LineString(r)
+	if len(r) > 0 && !r.Closed() {
+		// include the segment closing the ring
+		ls = append(append(orb.LineString(nil), r...), r[0])
+	}
+
+	return &RingIndex{ring: r, segments: NewSegmentIndex(ls)}
+}
+
+// Ring returns the indexed ring.
+func (ri *RingIndex) Ring() orb.Ring {
+	return ri.ring
+}
+
+// Contains returns true if the point is inside the ring, the same as RingContains. WithWorkers has no effect, as
+// only a few segments are checked.
+// Points on the boundary of the external ring are considered in, points on the boundary of a hole are not.
+func (ri *RingIndex) Contains(point orb.Point, isHole bool, model geod.EarthModel, opts ...ContainsOption) bool {
+	si := ri.segments
+	if len(ri.ring) == 0 || !si.bound.Contains(point) {
+		return false
+	}
+
+	config := newContainsConfig(opts)
+	if config.windingNumber {
+		return windingContains(ri.ring, point, isHole, model)
+	}
+
+	if len(si.buckets) == 0 {
+		// a single point
+		return !isHole && point == ri.ring[0]
+	}
+
+	c := false
+	for _, i := range si.buckets[si.bucket(point[0])] {
+		inter, on := RayIntersects(point, si.line[i], si.line[i+1], model)
+		if on {
+			return !isHole
+		}
+
+		if inter {
+			c = !c
+		}
+	}
+
+	return c
+}
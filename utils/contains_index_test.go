@@ -0,0 +1,103 @@
+package utils_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+)
+
+// starRing returns a star shaped ring with `n` points, centred on lon, lat
+func starRing(lon, lat float64, n int) orb.Ring {
+	ring := make(orb.Ring, 0, n+1)
+	for i := 0; i < n; i++ {
+		a := 2 * math.Pi * float64(i) / float64(n)
+		r := 5 + 2*math.Sin(50*a)
+		ring = append(ring, orb.Point{lon + r*math.Cos(a), lat + r*math.Sin(a)})
+	}
+
+	return append(ring, ring[0])
+}
+
+func TestRingIndex(t *testing.T) {
+	ring := starRing(175, -40, 5000)
+	ri := utils.NewRingIndex(ring)
+	require.Equal(t, ring, ri.Ring())
+
+	rng := rand.New(rand.NewSource(1)) // nolint:gosec
+	points := []orb.Point{ring[0], ring[1234], {175, -40}, {190, -40}}
+	for i := 0; i < 300; i++ {
+		points = append(points, orb.Point{168 + rng.Float64()*14, -47 + rng.Float64()*14})
+	}
+
+	for _, model := range []geod.EarthModel{geod.PlanarModel, geod.RhumbModel, geod.SphericalModel} {
+		for _, p := range points {
+			expected := utils.RingContains(ring, p, false, model)
+			assert.Equal(t, expected, ri.Contains(p, false, model), "%v", p)
+			assert.Equal(t, expected, utils.RingContains(ring, p, false, model, utils.WithWorkers(4)), "%v", p)
+			assert.Equal(t, utils.RingContains(ring, p, true, model), ri.Contains(p, true, model), "%v", p)
+			assert.Equal(t, utils.RingContains(ring, p, true, model),
+				utils.RingWithBoundContains(ring, orb.Bound{}, p, true, model, utils.WithWorkers(3)), "%v", p)
+		}
+	}
+
+	assert.False(t, utils.NewRingIndex(nil).Contains(orb.Point{0, 0}, false, geod.PlanarModel))
+}
+
+func TestSegmentIndex(t *testing.T) {
+	ls := orb.LineString(starRing(175, -40, 2000))
+	si := utils.NewSegmentIndex(ls)
+	require.Equal(t, ls, si.Line())
+
+	rng := rand.New(rand.NewSource(1)) // nolint:gosec
+	for n := 0; n < 200; n++ {
+		minX := 166 + rng.Float64()*18
+		maxX := minX + rng.Float64()*rng.Float64()*5
+
+		found := map[int]bool{}
+		for _, i := range si.Search(minX, maxX) {
+			assert.False(t, found[i], "segment %d returned twice", i)
+			found[i] = true
+		}
+
+		for i := 0; i < len(ls)-1; i++ {
+			p0, p1 := si.Segment(i)
+			if math.Max(p0[0], p1[0]) >= minX && math.Min(p0[0], p1[0]) <= maxX {
+				assert.True(t, found[i], "segment %d not found in %v..%v", i, minX, maxX)
+			}
+		}
+	}
+
+	assert.Nil(t, si.Search(190, 200))
+	assert.Nil(t, utils.NewSegmentIndex(orb.LineString{{1, 1}}).Search(0, 2))
+	assert.Equal(t, []int{0}, utils.NewSegmentIndex(orb.LineString{{1, 1}, {1, 2}}).Search(0, 2))
+}
+
+func BenchmarkRingContainsLarge(b *testing.B) {
+	ring := starRing(175, -40, 20000)
+	p := orb.Point{175.5, -40.5}
+
+	b.Run("Sequential", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			utils.RingContains(ring, p, false, geod.SphericalModel)
+		}
+	})
+	b.Run("Workers", func(b *testing.B) {
+		for n := 0; n < b.N; n++ {
+			utils.RingContains(ring, p, false, geod.SphericalModel, utils.WithWorkers(4))
+		}
+	})
+	b.Run("Index", func(b *testing.B) {
+		ri := utils.NewRingIndex(ring)
+		b.ResetTimer()
+		for n := 0; n < b.N; n++ {
+			ri.Contains(p, false, geod.SphericalModel)
+		}
+	})
+}
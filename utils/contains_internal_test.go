@@ -1,6 +1,7 @@
 package utils
 
 import (
+	"math"
 	"math/rand"
 	"testing"
 
@@ -8,7 +9,7 @@ import (
 	"github.com/starboard-nz/orb"
 )
 
-func BenchmarkRayIntersect(b *testing.B) {
+func benchmarkRayIntersect(b *testing.B, model geod.EarthModel) {
 	const N = 100000
 	s := make([]orb.Point, N)
 	e := make([]orb.Point, N)
@@ -31,7 +32,48 @@ func BenchmarkRayIntersect(b *testing.B) {
 	b.ResetTimer()
 
 	for n := 0; n < b.N; n++ {
-		rayIntersect(p[n%N], s[n%N], e[n%N], geod.RhumbModel)
+		RayIntersects(p[n%N], s[n%N], e[n%N], model)
+	}
+}
+
+func BenchmarkRayIntersect(b *testing.B) {
+	benchmarkRayIntersect(b, geod.RhumbModel)
+}
+
+func BenchmarkRayIntersectPlanar(b *testing.B) {
+	benchmarkRayIntersect(b, geod.PlanarModel)
+}
+
+func TestRayIntersectPlanar(t *testing.T) {
+	rng := rand.New(rand.NewSource(1)) // nolint:gosec
+	for i := 0; i < 10000; i++ {
+		s := orb.Point{rng.Float64() * 10, rng.Float64() * 10}
+		e := orb.Point{rng.Float64() * 10, rng.Float64() * 10}
+		p := orb.Point{rng.Float64() * 10, rng.Float64() * 10}
+
+		i, on := RayIntersects(p, s, e, geod.PlanarModel)
+
+		// the same test using the bearings of the planar model
+		if s[0] > e[0] {
+			s, e = e, s
+		}
+		expected := false
+		if p[0] >= s[0] && p[0] <= e[0] && p[1] <= math.Max(s[1], e[1]) {
+			bs := geod.InitialBearing(geod.LatLon{Latitude: geod.Degrees(s[1]), Longitude: geod.Degrees(s[0])},
+				geod.LatLon{Latitude: geod.Degrees(e[1]), Longitude: geod.Degrees(e[0])}, geod.PlanarModel)
+			bp := geod.InitialBearing(geod.LatLon{Latitude: geod.Degrees(s[1]), Longitude: geod.Degrees(s[0])},
+				geod.LatLon{Latitude: geod.Degrees(p[1]), Longitude: geod.Degrees(p[0])}, geod.PlanarModel)
+			expected = bs <= bp
+		}
+
+		if i != expected || on {
+			t.Errorf("RayIntersects(%v, %v, %v) = %v, %v, expected %v", p, s, e, i, on, expected)
+		}
+	}
+
+	i, on := RayIntersects(orb.Point{1, 1}, orb.Point{0, 0}, orb.Point{2, 2}, geod.PlanarModel)
+	if i || !on {
+		t.Errorf("point on the segment should be on")
 	}
 }
 
@@ -53,7 +95,7 @@ func TestRayInterSect(t *testing.T) {
 	p3 := orb.Point{5, 5} // Identical to end point
 	p4 := orb.Point{0, 0} // Identical to start point
 
-	i, on := rayIntersect(p, s, e, geod.RhumbModel)
+	i, on := RayIntersects(p, s, e, geod.RhumbModel)
 	if i { // Test shared x values don't trigger contains for both start and end of segment
 		t.Errorf("point sharing same x as end and being under the test segment should not intersect")
 	}
@@ -61,7 +103,7 @@ func TestRayInterSect(t *testing.T) {
 		t.Errorf("on should be false")
 	}
 
-	i, on = rayIntersect(p2, s, e, geod.RhumbModel)
+	i, on = RayIntersects(p2, s, e, geod.RhumbModel)
 	if i {
 		t.Errorf("point sharing same x as start point and being below the segment should intersect")
 	}
@@ -69,7 +111,7 @@ func TestRayInterSect(t *testing.T) {
 		t.Errorf("on should be false")
 	}
 
-	i, on = rayIntersect(p3, s, e, geod.RhumbModel)
+	i, on = RayIntersects(p3, s, e, geod.RhumbModel)
 	if i {
 		t.Errorf("intersection should be false as test point is identical to end point")
 	}
@@ -77,7 +119,7 @@ func TestRayInterSect(t *testing.T) {
 		t.Errorf("on should be true as test point is identical to end point")
 	}
 
-	i, on = rayIntersect(p4, s, e, geod.RhumbModel)
+	i, on = RayIntersects(p4, s, e, geod.RhumbModel)
 	if i {
 		t.Errorf("intersection should be false as test point is identical to end point")
 	}
@@ -92,7 +134,7 @@ func TestRayInterSect(t *testing.T) {
 	p3 = orb.Point{5, 0} // Identical to end point
 	p4 = orb.Point{0, 5} // Identical to start point
 
-	i, on = rayIntersect(p, s, e, geod.RhumbModel)
+	i, on = RayIntersects(p, s, e, geod.RhumbModel)
 	if i {
 		t.Errorf("point sharing same x as end and being under the test segment should not intersect")
 	}
@@ -100,7 +142,7 @@ func TestRayInterSect(t *testing.T) {
 		t.Errorf("on should be false")
 	}
 
-	i, on = rayIntersect(p2, s, e, geod.RhumbModel)
+	i, on = RayIntersects(p2, s, e, geod.RhumbModel)
 	if !i {
 		t.Errorf("point sharing same x as start point and being below the segment should intersect")
 	}
@@ -108,7 +150,7 @@ func TestRayInterSect(t *testing.T) {
 		t.Errorf("on should be false")
 	}
 
-	i, on = rayIntersect(p3, s, e, geod.RhumbModel)
+	i, on = RayIntersects(p3, s, e, geod.RhumbModel)
 	if i {
 		t.Errorf("intersection should be false as test point is identical to end point")
 	}
@@ -116,7 +158,7 @@ func TestRayInterSect(t *testing.T) {
 		t.Errorf("on should be true as test point is identical to end point")
 	}
 
-	i, on = rayIntersect(p4, s, e, geod.RhumbModel)
+	i, on = RayIntersects(p4, s, e, geod.RhumbModel)
 	if i {
 		t.Errorf("intersection should be false as test point is identical to end point")
 	}
@@ -10,7 +10,7 @@ import (
 	"github.com/starboard-nz/orb"
 )
 
-func testRingContains(t *testing.T, model geod.EarthModel) {
+func testRingContains(t *testing.T, model geod.EarthModel, opts ...utils.ContainsOption) {
 	ring := orb.Ring{
 		{0, 0}, {0, 1}, {1, 1}, {1, 0.5}, {2, 0.5},
 		{2, 1}, {3, 1}, {3, 0}, {0, 0},
@@ -77,7 +77,7 @@ func testRingContains(t *testing.T, model geod.EarthModel) {
 	for _, tc := range cases {
 		t.Run(tc.name, func(t *testing.T) {
 			ring.Reverse()
-			val := utils.RingContains(ring, tc.point, false, model)
+			val := utils.RingContains(ring, tc.point, false, model, opts...)
 
 			if val != tc.result {
 				t.Errorf("wrong containment: %v != %v", val, tc.result)
@@ -85,7 +85,7 @@ func testRingContains(t *testing.T, model geod.EarthModel) {
 
 			// should not care about orientation
 			ring.Reverse()
-			val = utils.RingContains(ring, tc.point, false, model)
+			val = utils.RingContains(ring, tc.point, false, model, opts...)
 			if val != tc.result {
 				t.Errorf("wrong containment: %v != %v", val, tc.result)
 			}
@@ -94,7 +94,7 @@ func testRingContains(t *testing.T, model geod.EarthModel) {
 
 	// points should all be in
 	for i, p := range ring {
-		if !utils.RingContains(ring, p, false, model) {
+		if !utils.RingContains(ring, p, false, model, opts...) {
 			t.Errorf("point index %d: should be inside", i)
 		}
 	}
@@ -102,7 +102,7 @@ func testRingContains(t *testing.T, model geod.EarthModel) {
 	// on all the segments should be in.
 	for i := 1; i < len(ring); i++ {
 		c := interpolate(ring[i], ring[i-1], 0.5)
-		if !utils.RingContains(ring, c, false, model) {
+		if !utils.RingContains(ring, c, false, model, opts...) {
 			t.Errorf("index %d centroid: should be inside", i)
 		}
 	}
@@ -110,18 +110,18 @@ func testRingContains(t *testing.T, model geod.EarthModel) {
 	// colinear with segments but outside
 	for i := 1; i < len(ring); i++ {
 		p := interpolate(ring[i], ring[i-1], 5)
-		if utils.RingContains(ring, p, false, model) {
+		if utils.RingContains(ring, p, false, model, opts...) {
 			t.Errorf("index %d centroid: should not be inside", i)
 		}
 
 		p = interpolate(ring[i], ring[i-1], -5)
-		if utils.RingContains(ring, p, false, model) {
+		if utils.RingContains(ring, p, false, model, opts...) {
 			t.Errorf("index %d centroid: should not be inside", i)
 		}
 	}
 }
 
-func testRingContainsAntimeridian360(t *testing.T, model geod.EarthModel) {
+func testRingContainsAntimeridian360(t *testing.T, model geod.EarthModel, opts ...utils.ContainsOption) {
 	ring := orb.Ring{
 		orb.Point{160, -10},
 		orb.Point{360 - 140, -10},
@@ -145,15 +145,15 @@ func testRingContainsAntimeridian360(t *testing.T, model geod.EarthModel) {
 		float64(Mid.Latitude) + 0.00001, // Latitude
 	}
 
-	inside := utils.RingContains(ring, A, false, model)
+	inside := utils.RingContains(ring, A, false, model, opts...)
 	assert.False(t, inside)
 
-	inside = utils.RingContains(ring, B, false, model)
+	inside = utils.RingContains(ring, B, false, model, opts...)
 	assert.True(t, inside)
 }
 
 // Does not currently work with -180..180
-func testRingContainsAntimeridian180(t *testing.T, model geod.EarthModel) {
+func testRingContainsAntimeridian180(t *testing.T, model geod.EarthModel, opts ...utils.ContainsOption) {
 	ring := orb.Ring{
 		orb.Point{160, -10},
 		orb.Point{-140, -10},
@@ -176,10 +176,10 @@ func testRingContainsAntimeridian180(t *testing.T, model geod.EarthModel) {
 		float64(Mid.Latitude) + 0.00001, // Latitude
 	}
 
-	inside := utils.RingContains(ring, A, false, model)
+	inside := utils.RingContains(ring, A, false, model, opts...)
 	assert.False(t, inside)
 
-	inside = utils.RingContains(ring, B, false, model)
+	inside = utils.RingContains(ring, B, false, model, opts...)
 	assert.True(t, inside)
 }
 
@@ -191,6 +191,14 @@ func TestRingContains(t *testing.T) {
 	t.Run("Rhumb/Antimeridian360", func(t *testing.T) { testRingContainsAntimeridian360(t, geod.RhumbModel) })
 	t.Run("Spherical/Antimeridian360", func(t *testing.T) { testRingContainsAntimeridian360(t, geod.SphericalModel) })
 
+	winding := utils.WithWindingNumber()
+	t.Run("Planar/Winding", func(t *testing.T) { testRingContains(t, geod.PlanarModel, winding) })
+	t.Run("Rhumb/Winding", func(t *testing.T) { testRingContains(t, geod.RhumbModel, winding) })
+	t.Run("Spherical/Winding", func(t *testing.T) { testRingContains(t, geod.SphericalModel, winding) })
+	t.Run("Planar/Antimeridian360/Winding", func(t *testing.T) { testRingContainsAntimeridian360(t, geod.PlanarModel, winding) })
+	t.Run("Rhumb/Antimeridian360/Winding", func(t *testing.T) { testRingContainsAntimeridian360(t, geod.RhumbModel, winding) })
+	t.Run("Spherical/Antimeridian360/Winding", func(t *testing.T) { testRingContainsAntimeridian360(t, geod.SphericalModel, winding) })
+
 	// Wrapping Longitudes from -180 to 180 doesn't work due to bounds testing
 	//t.Run("Planar/Antimeridian180", func(t *testing.T) { testRingContainsAntimeridian180(t, geod.PlanarModel) })
 	//t.Run("Rhumb/Antimeridian180", func(t *testing.T) { testRingContainsAntimeridian180(t, geod.RhumbModel) })
@@ -276,3 +284,86 @@ func interpolate(a, b orb.Point, percent float64) orb.Point {
 		a[1] + percent*(b[1]-a[1]),
 	}
 }
+
+func TestRingContainsWindingNumber(t *testing.T) {
+	winding := utils.WithWindingNumber()
+
+	// two squares touching at a vertex (1, 1), with repeated vertices
+	ring := orb.Ring{
+		{0, 0}, {1, 0}, {1, 0}, {1, 1}, {2, 1}, {2, 2}, {1, 2}, {1, 1}, {1, 1}, {0, 1}, {0, 0},
+	}
+
+	cases := []struct {
+		point  orb.Point
+		result bool
+	}{
+		{orb.Point{0.5, 0.5}, true},
+		{orb.Point{1.5, 1.5}, true},
+		{orb.Point{1, 1}, true},   // touching vertex
+		{orb.Point{1, 0.5}, true}, // on edge
+		{orb.Point{1.5, 0.5}, false},
+		{orb.Point{0.5, 1.5}, false},
+		{orb.Point{0.5, 1}, true}, // vertex x shared with the point
+		{orb.Point{1.5, 0.9}, false},
+	}
+
+	for _, model := range []geod.EarthModel{geod.PlanarModel, geod.RhumbModel, geod.SphericalModel} {
+		for _, tc := range cases {
+			assert.Equal(t, tc.result, utils.RingContains(ring, tc.point, false, model, winding), "%v", tc.point)
+			assert.Equal(t, tc.result, utils.RingWithBoundContains(ring, orb.Bound{}, tc.point, false, model, winding), "%v", tc.point)
+		}
+	}
+
+	p := orb.Polygon{
+		{{0, 0}, {3, 0}, {3, 3}, {0, 3}, {0, 0}},
+		{{1, 1}, {1, 2}, {2, 2}, {2, 1}, {1, 1}},
+	}
+	assert.True(t, utils.PolygonContains(p, orb.Point{0.5, 1.5}, geod.SphericalModel, winding))
+	assert.False(t, utils.PolygonContains(p, orb.Point{1.5, 1.5}, geod.SphericalModel, winding))
+	assert.True(t, utils.PolygonContains(p, orb.Point{2, 1.5}, geod.SphericalModel, winding))
+	assert.True(t, utils.MultiPolygonContains(orb.MultiPolygon{p}, orb.Point{0.5, 1.5}, geod.SphericalModel, winding))
+	assert.False(t, utils.MultiPolygonWithBoundContains(orb.MultiPolygon{p}, nil, orb.Point{1.5, 1.5}, geod.SphericalModel, winding))
+}
+
+func TestRayIntersects(t *testing.T) {
+	s, e := orb.Point{0, 0}, orb.Point{2, 2}
+
+	cases := []struct {
+		point     orb.Point
+		crosses   bool
+		onSegment bool
+	}{
+		{orb.Point{1, 0}, true, false},  // below
+		{orb.Point{1, 2}, false, false}, // above
+		{orb.Point{3, 0}, false, false}, // east of the segment
+		{orb.Point{0, 0}, false, true},  // start
+		{orb.Point{2, 2}, false, true},  // end
+		{orb.Point{0, -1}, true, false}, // below the start: counted for the segment extending east
+		{orb.Point{2, 1}, false, false}, // below the end: not counted
+	}
+
+	for _, tc := range cases {
+		crosses, on := utils.RayIntersects(tc.point, s, e, geod.RhumbModel)
+		assert.Equal(t, tc.crosses, crosses, "%v", tc.point)
+		assert.Equal(t, tc.onSegment, on, "%v", tc.point)
+
+		// the direction of the segment doesn't matter
+		crosses, on = utils.RayIntersects(tc.point, e, s, geod.RhumbModel)
+		assert.Equal(t, tc.crosses, crosses, "%v", tc.point)
+		assert.Equal(t, tc.onSegment, on, "%v", tc.point)
+	}
+
+	// the great circle from (0, 0) to (40, 20) is north of the rhumb line
+	crosses, _ := utils.RayIntersects(orb.Point{19.5, 10.3}, orb.Point{0, 0}, orb.Point{40, 20}, geod.SphericalModel)
+	assert.True(t, crosses)
+	crosses, _ = utils.RayIntersects(orb.Point{19.5, 10.3}, orb.Point{0, 0}, orb.Point{40, 20}, geod.RhumbModel)
+	assert.False(t, crosses)
+
+	// but the shape of the segment is ignored outside of its latitude range
+	crosses, _ = utils.RayIntersects(orb.Point{20, 10.5}, orb.Point{0, 10}, orb.Point{40, 10}, geod.SphericalModel)
+	assert.False(t, crosses)
+
+	// on a segment along a meridian
+	_, on := utils.RayIntersects(orb.Point{1, 1}, orb.Point{1, 0}, orb.Point{1, 2}, geod.SphericalModel)
+	assert.True(t, on)
+}
@@ -0,0 +1,109 @@
+package utils
+
+import (
+	"math"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+)
+
+// CoverageFraction returns the fraction of the area of the cell covered by the multipolygon, from 0 to 1, for
+// example to rasterise zones onto the cells returned by Grid. The rings of the polygons are clipped to the cell, and
+// the areas are measured as with AreaOp, with the edges following `model`.
+//
+// The cell must be convex, as grid cells are, and the polygons must not overlap each other. The clipping is done in
+// longitude/latitude space, so the same NOTE about densification as for the containment functions applies, to both
+// the cell and the polygons. Cells and polygons crossing the antimeridian should use 0..360 longitudes.
+//
+// Example:
+//
+//	cells, err := utils.Grid(bound, units.Km(10), geod.RhumbModel)
+//	...
+//	for i, cell := range cells {
+//		coverage[i] = utils.CoverageFraction(cell[0], zone, geod.RhumbModel)
+//	}
+func CoverageFraction(cell orb.Ring, poly orb.MultiPolygon, model geod.EarthModel) float64 {
+	cellArea := ringArea(followModel(cell, model))
+	if cellArea == 0 {
+		return 0
+	}
+
+	cellBound := cell.Bound()
+	covered := 0.0
+	for _, p := range poly {
+		if len(p) == 0 || !p.Bound().Intersects(cellBound) {
+			continue
+		}
+
+		for i, r := range p {
+			clipped := clipRingToConvex(r, cell)
+			if len(clipped) < 3 {
+				continue
+			}
+
+			if i == 0 {
+				covered += ringArea(followModel(clipped, model))
+			} else {
+				covered -= ringArea(followModel(clipped, model))
+			}
+		}
+	}
+
+	return math.Min(math.Max(covered/cellArea, 0), 1)
+}
+
+// clipRingToConvex returns the part of the ring inside the convex ring `clip`, using the Sutherland-Hodgman
+// algorithm in longitude/latitude space. Concave rings may produce parts connected by edges along the boundary of
+// `clip`, which enclose no area.
+func clipRingToConvex(r, clip orb.Ring) orb.Ring {
+	// the orientation of the clipping ring, as the sign of its area
+	orientation := 0.0
+	for i := 0; i < len(clip)-1; i++ {
+		orientation += clip[i][0]*clip[i+1][1] - clip[i+1][0]*clip[i][1]
+	}
+	if orientation == 0 {
+		return nil
+	}
+
+	cross := func(a, b, p orb.Point) float64 {
+		return (b[0]-a[0])*(p[1]-a[1]) - (b[1]-a[1])*(p[0]-a[0])
+	}
+
+	output := orb.Ring(r)
+	if len(output) > 1 && output[0] == output[len(output)-1] {
+		output = output[:len(output)-1]
+	}
+
+	for k := 0; k < len(clip)-1 && len(output) > 0; k++ {
+		a, b := clip[k], clip[k+1]
+		if a == b {
+			continue
+		}
+
+		inside := func(p orb.Point) bool {
+			return cross(a, b, p)*orientation >= 0
+		}
+
+		input := output
+		output = make(orb.Ring, 0, len(input)+2)
+		for i, e := range input {
+			s := input[(i+len(input)-1)%len(input)]
+
+			if inside(e) != inside(s) {
+				// the intersection of the edge s-e with the line a-b
+				cs, ce := cross(a, b, s), cross(a, b, e)
+				t := cs / (cs - ce)
+				output = append(output, orb.Point{s[0] + t*(e[0]-s[0]), s[1] + t*(e[1]-s[1])})
+			}
+			if inside(e) {
+				output = append(output, e)
+			}
+		}
+	}
+
+	if len(output) > 0 {
+		output = append(output, output[0])
+	}
+
+	return output
+}
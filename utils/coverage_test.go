@@ -0,0 +1,55 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+func TestCoverageFraction(t *testing.T) {
+	cell := orb.Ring{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}
+	box := func(minX, minY, maxX, maxY float64) orb.Ring {
+		return orb.Ring{{minX, minY}, {maxX, minY}, {maxX, maxY}, {minX, maxY}, {minX, minY}}
+	}
+
+	for _, model := range []geod.EarthModel{geod.RhumbModel, geod.SphericalModel} {
+		// the western half of the cell
+		half := orb.MultiPolygon{{box(-1, -1, 0.5, 2)}}
+		assert.InDelta(t, 0.5, utils.CoverageFraction(cell, half, model), 1e-4)
+
+		assert.InDelta(t, 1, utils.CoverageFraction(cell, orb.MultiPolygon{{box(-1, -1, 2, 2)}}, model), 1e-9)
+		assert.Equal(t, 0.0, utils.CoverageFraction(cell, orb.MultiPolygon{{box(2, 2, 3, 3)}}, model))
+		assert.Equal(t, 0.0, utils.CoverageFraction(cell, nil, model))
+
+		// a polygon with a hole covering the middle of the cell, with the rings in the other direction
+		holed := orb.MultiPolygon{{box(-1, -1, 2, 2), {{0.25, 0.25}, {0.25, 0.75}, {0.75, 0.75}, {0.75, 0.25}, {0.25, 0.25}}}}
+		assert.InDelta(t, 0.75, utils.CoverageFraction(cell, holed, model), 1e-3)
+
+		// 2 polygons and a concave one, an L covering 3 quarters of the cell
+		two := orb.MultiPolygon{{box(-1, -1, 0.25, 2)}, {box(0.75, -1, 2, 2)}}
+		assert.InDelta(t, 0.5, utils.CoverageFraction(cell, two, model), 1e-3)
+		l := orb.MultiPolygon{{{{-1, -1}, {2, -1}, {2, 0.5}, {0.5, 0.5}, {0.5, 2}, {-1, 2}, {-1, -1}}}}
+		assert.InDelta(t, 0.75, utils.CoverageFraction(cell, l, model), 1e-3)
+	}
+
+	// grid cells, with the bound covered by one polygon
+	bound := geod.NewBound(geod.NewLatLon(-41, 174), geod.NewLatLon(-40, 175))
+	cells, err := utils.Grid(bound, units.Km(25), geod.SphericalModel)
+	require.NoError(t, err)
+
+	zone := orb.MultiPolygon{{box(174, -41, 175, -40)}}
+	for _, cell := range cells {
+		fraction := utils.CoverageFraction(cell[0], zone, geod.SphericalModel)
+		assert.GreaterOrEqual(t, fraction, 0.0)
+		assert.LessOrEqual(t, fraction, 1.0)
+		if c := cell.Bound().Center(); c[0] < 174.8 && c[1] < -40.2 {
+			assert.InDelta(t, 1, fraction, 1e-6)
+		}
+	}
+}
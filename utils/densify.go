@@ -3,6 +3,7 @@ package utils
 import (
 	"errors"
 	"fmt"
+	"math"
 
 	geod "github.com/starboard-nz/go-geodesy"
 	"github.com/starboard-nz/orb"
@@ -16,21 +17,134 @@ var (
 	ErrInvalidGeometry  = errors.New("invalid geometry")
 )
 
+// DensifyOption changes how the Densify functions measure the error of a segment.
+type DensifyOption func(*densifyConfig)
+
+type densifyConfig struct {
+	maxErrorSamples int
+	report          *DensifyReport
+
+	// the polygon and ring being densified, for the report
+	polygon, ring int
+}
+
+func newDensifyConfig(opts []DensifyOption) densifyConfig {
+	var c densifyConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	return c
+}
+
+// WithMaxErrorSearch makes the Densify functions use MaxSegmentError with the given number of samples, instead of
+// only checking the error at the middle of each segment. This is slower, but guarantees the tolerance also where the
+// largest difference between the models is away from the middle of the segment.
+func WithMaxErrorSearch(samples int) DensifyOption {
+	return func(c *densifyConfig) {
+		c.maxErrorSamples = samples
+	}
+}
+
+// WithReport makes the Densify functions append a SegmentReport for each segment they densify to `report`, for
+// example to monitor whether the tolerance is met by data pipelines, without checking the densified geometries. The
+// same report can be used for several calls, the segments are appended to it. The report must not be shared by
+// concurrent calls.
+func WithReport(report *DensifyReport) DensifyOption {
+	return func(c *densifyConfig) {
+		c.report = report
+	}
+}
+
+// SegmentReport describes how a segment was densified.
+type SegmentReport struct {
+	// Polygon, Ring and Segment identify the segment, from point Segment to the next point of ring Ring of polygon
+	// Polygon. Polygon is 0 unless densifying multipolygons, Ring is 0 unless densifying polygons.
+	Polygon, Ring, Segment int
+	// PointsAdded is the number of points inserted into the segment
+	PointsAdded int
+	// MaxError is the largest error of the parts of the densified segment, measured as when densifying (at the middle
+	// of each part, or with MaxSegmentError if WithMaxErrorSearch is used)
+	MaxError units.Distance
+	// DepthLimitReached is true if the segment couldn't be densified further before meeting the tolerance, in which
+	// case MaxError is larger than the tolerance and ErrToleranceTooLow is returned
+	DepthLimitReached bool
+}
+
+// DensifyReport is the report of the Densify functions, see WithReport.
+type DensifyReport struct {
+	// Segments has a SegmentReport for each densified segment, in the order they were densified
+	Segments []SegmentReport
+}
+
+// PointsAdded returns the total number of points inserted into the segments.
+func (r *DensifyReport) PointsAdded() int {
+	n := 0
+	for _, sr := range r.Segments {
+		n += sr.PointsAdded
+	}
+
+	return n
+}
+
+// MaxError returns the largest error of all the segments.
+func (r *DensifyReport) MaxError() units.Distance {
+	e := 0.0
+	for _, sr := range r.Segments {
+		e = math.Max(e, float64(sr.MaxError.Metre()))
+	}
+
+	return units.Metre(e)
+}
+
+// ToleranceMet returns true if the tolerance was met for all the segments.
+func (r *DensifyReport) ToleranceMet() bool {
+	for _, sr := range r.Segments {
+		if sr.DepthLimitReached {
+			return false
+		}
+	}
+
+	return true
+}
+
 // The Densify functions work across the antimeridian in both the -180..180 and the 0..360 range, however,
 // the resulting densified polygons will always be in the -180..180 range.
 
 // DensifyMultiPolygon inserts points into the multipolygon using the given Model, until the maximum distance between
 // model and the reference model is less than the tolerance, where model defines the shape of the lines between points
 // (e.g. great circle arc or rhumb line).
-func DensifyMultiPolygon(mp orb.MultiPolygon, model, refModel geod.EarthModel, tolerance units.Distance) (orb.MultiPolygon, error) {
+// The polygons are densified in parallel, see geod.SetMaxWorkers.
+func DensifyMultiPolygon(mp orb.MultiPolygon, model, refModel geod.EarthModel, tolerance units.Distance, opts ...DensifyOption) (orb.MultiPolygon, error) {
 	var (
 		dmp orb.MultiPolygon
 		err error
 	)
 
-	for _, polygon := range mp {
-		dp, err2 := DensifyPolygon(polygon, model, refModel, tolerance)
-		if err2 != nil {
+	config := newDensifyConfig(opts)
+
+	polygons := make([]orb.Polygon, len(mp))
+	errs := make([]error, len(mp))
+	// each polygon has its own report, as they are densified in parallel
+	reports := make([]DensifyReport, len(mp))
+	geod.ParallelFor(len(mp), func(i int) {
+		c := config
+		c.polygon = i
+		if c.report != nil {
+			c.report = &reports[i]
+		}
+
+		polygons[i], errs[i] = densifyPolygon(mp[i], model, refModel, tolerance, c)
+	})
+
+	if config.report != nil {
+		for _, r := range reports {
+			config.report.Segments = append(config.report.Segments, r.Segments...)
+		}
+	}
+
+	for i, dp := range polygons {
+		if err2 := errs[i]; err2 != nil {
 			if !errors.Is(err2, ErrToleranceTooLow) {
 				return nil, err2
 			}
@@ -46,14 +160,19 @@ func DensifyMultiPolygon(mp orb.MultiPolygon, model, refModel geod.EarthModel, t
 
 // DensifyPolygon inserts points into the polygon using the given Model, until the maximum distance between
 // planar geometry and the given model is less than the tolerance.
-func DensifyPolygon(poly orb.Polygon, model, refModel geod.EarthModel, tolerance units.Distance) (orb.Polygon, error) {
+func DensifyPolygon(poly orb.Polygon, model, refModel geod.EarthModel, tolerance units.Distance, opts ...DensifyOption) (orb.Polygon, error) {
+	return densifyPolygon(poly, model, refModel, tolerance, newDensifyConfig(opts))
+}
+
+func densifyPolygon(poly orb.Polygon, model, refModel geod.EarthModel, tolerance units.Distance, config densifyConfig) (orb.Polygon, error) {
 	var (
 		dp  orb.Polygon
 		err error
 	)
 
-	for _, ring := range poly {
-		dr, err2 := DensifyRing(ring, model, refModel, tolerance)
+	for i, ring := range poly {
+		config.ring = i
+		dr, err2 := densifyRingInto(make(orb.Ring, 0, len(ring)), ring, model, refModel, tolerance, config)
 		if err2 != nil {
 			if !errors.Is(err2, ErrToleranceTooLow) {
 				return nil, err2
@@ -70,21 +189,34 @@ func DensifyPolygon(poly orb.Polygon, model, refModel geod.EarthModel, tolerance
 
 // DensifyRing inserts points into the ring using the given Model, until the maximum distance between
 // planar geometry and the given model is less than the tolerance.
-func DensifyRing(ring orb.Ring, model, refModel geod.EarthModel, tolerance units.Distance) (orb.Ring, error) {
+func DensifyRing(ring orb.Ring, model, refModel geod.EarthModel, tolerance units.Distance, opts ...DensifyOption) (orb.Ring, error) {
+	return DensifyRingInto(make(orb.Ring, 0, len(ring)), ring, model, refModel, tolerance, opts...)
+}
+
+// DensifyRingInto is the same as DensifyRing, but appends the densified ring to `dst` and returns the extended
+// slice, so that a buffer can be reused when densifying many rings, e.g. DensifyRingInto(buf[:0], ...).
+func DensifyRingInto(dst orb.Ring, ring orb.Ring, model, refModel geod.EarthModel, tolerance units.Distance, opts ...DensifyOption) (orb.Ring, error) {
+	return densifyRingInto(dst, ring, model, refModel, tolerance, newDensifyConfig(opts))
+}
+
+func densifyRingInto(dst orb.Ring, ring orb.Ring, model, refModel geod.EarthModel, tolerance units.Distance, config densifyConfig) (orb.Ring, error) {
 	if len(ring) < 2 {
 		return nil, fmt.Errorf("%w: ring has %d points only", ErrInvalidGeometry, len(ring))
 	}
 
+	if tolerance.Metre() <= 0 {
+		return nil, ErrInvalidTolerance
+	}
+
 	lastPoint := ring[len(ring)-1]
 	closed := ring[0][0] == lastPoint[0] && ring[0][1] == lastPoint[1]
 
 	var err error
 
-	points := make([]orb.Point, 0, len(ring))
-	dr := orb.Ring(points)
-	dr = append(dr, ring[0])
+	dr := append(dst, ring[0])
 	for i := 1; i < len(ring); i++ {
-		ps, err2 := DensifySegment(ring[i-1], ring[i], model, refModel, tolerance)
+		var err2 error
+		dr, err2 = appendDensifiedSegment(dr, ring[i-1], ring[i], i-1, model, refModel, tolerance, config)
 		if err2 != nil {
 			if !errors.Is(err2, ErrToleranceTooLow) {
 				return nil, err2
@@ -92,16 +224,11 @@ func DensifyRing(ring orb.Ring, model, refModel geod.EarthModel, tolerance units
 
 			err = err2
 		}
-
-		if len(ps) > 1 {
-			dr = append(dr, ps[1:]...)
-		} else {
-			return nil, ErrInternalError
-		}
 	}
 
 	if !closed {
-		ps, err2 := DensifySegment(lastPoint, ring[0], model, refModel, tolerance)
+		var err2 error
+		dr, err2 = appendDensifiedSegment(dr, lastPoint, ring[0], len(ring)-1, model, refModel, tolerance, config)
 		if err2 != nil {
 			if !errors.Is(err2, ErrToleranceTooLow) {
 				return nil, err2
@@ -109,12 +236,6 @@ func DensifyRing(ring orb.Ring, model, refModel geod.EarthModel, tolerance units
 
 			err = err2
 		}
-
-		if len(ps) > 1 {
-			dr = append(dr, ps[1:]...)
-		} else {
-			return nil, ErrInternalError
-		}
 	}
 
 	return dr, err
@@ -124,22 +245,102 @@ func DensifyRing(ring orb.Ring, model, refModel geod.EarthModel, tolerance units
 // until the maximum distance between planar geometry and the given model is less than the tolerance.
 // If the required tolerance if too low, this function won't exhaust the available memory, but return
 // a densified polygon that doesn't meet required tolerance and ErrToleranceTooLow.
-func DensifySegment(p0, p1 orb.Point, model, refModel geod.EarthModel, tolerance units.Distance) ([]orb.Point, error) {
+func DensifySegment(p0, p1 orb.Point, model, refModel geod.EarthModel, tolerance units.Distance, opts ...DensifyOption) ([]orb.Point, error) {
 	if tolerance.Metre() <= 0 {
 		return nil, ErrInvalidTolerance
 	}
 
+	return appendDensifiedSegment([]orb.Point{p0}, p0, p1, 0, model, refModel, tolerance, newDensifyConfig(opts))
+}
+
+// appendDensifiedSegment appends the intermediate points of the segment p0-p1 and p1 (but not p0) to `dst`, and adds
+// the segment to the report, if any, as segment `segment` of the ring.
+func appendDensifiedSegment(dst []orb.Point, p0, p1 orb.Point, segment int, model, refModel geod.EarthModel, tolerance units.Distance, config densifyConfig) ([]orb.Point, error) {
 	ll0 := geod.LatLon{Longitude: geod.Degrees(p0[0]), Latitude: geod.Degrees(p0[1])}
 	ll1 := geod.LatLon{Longitude: geod.Degrees(p1[0]), Latitude: geod.Degrees(p1[1])}
 
+	sr := SegmentReport{Polygon: config.polygon, Ring: config.ring, Segment: segment, MaxError: units.Metre(0)}
+	n := len(dst)
+
+	if ll0.Equals(ll1) {
+		if config.report != nil {
+			config.report.Segments = append(config.report.Segments, sr)
+		}
+
+		return append(dst, p1), nil
+	}
+
+	d := newSegmentDensifier(ll0, ll1, model, refModel, tolerance)
+	d.config = config
+
 	// max 15 deep recursion, allows adding up to 2^14=16364 point per segment, "ought to be enough for anybody"
-	return densifySegment(ll0, ll1, p0, p1, 0, 1, model, refModel, tolerance, 15)
+	dst, err := d.densify(dst, p0, p1, 0, 1, 15)
+
+	if config.report != nil && dst != nil {
+		sr.PointsAdded = len(dst) - n - 1
+		sr.MaxError = d.maxError
+		sr.DepthLimitReached = d.depthLimitReached
+		config.report.Segments = append(config.report.Segments, sr)
+	}
+
+	return dst, err
 }
 
-// To avoid reducing the accuracy of the intermediate points through repeated interval halving, this function passes
-// through the start and end points of the segment (ll0 and ll1) unchanged, and we calculate the fraction where the point
-// should be added. The starting and ending points of the part of the section we are densifying (pf and pt) are passed in
-// the recursive step, along with the fractions where those points were added (from, to).
+// segmentDensifier holds the state shared by all levels of recursion when densifying a segment, so that the model
+// and the distance and bearing along the segment are only calculated once.
+type segmentDensifier struct {
+	ll1       geod.LatLon
+	m0        geod.Model
+	refModel  geod.EarthModel
+	model     geod.EarthModel
+	tolerance units.Metre
+	config    densifyConfig
+
+	// for models where the intermediate points are found by travelling along the initial bearing, the distance
+	// (in metres) and initial bearing of the segment
+	direct   bool
+	distance float64
+	bearing  geod.Degrees
+
+	// the largest error of the parts of the segment that were not densified further, and whether any of them was
+	// not densified further because of the recursion limit
+	maxError          units.Metre
+	depthLimitReached bool
+}
+
+func newSegmentDensifier(ll0, ll1 geod.LatLon, model, refModel geod.EarthModel, tolerance units.Distance) *segmentDensifier {
+	d := &segmentDensifier{
+		ll1:       ll1,
+		m0:        model(ll0),
+		refModel:  refModel,
+		model:     model,
+		tolerance: tolerance.Metre(),
+	}
+
+	switch d.m0.(type) {
+	case geod.LatLonEllipsoidalVincenty, geod.LatLonRhumb:
+		d.distance = float64(d.m0.DistanceTo(ll1).Metre())
+		d.bearing = d.m0.InitialBearingTo(ll1)
+		d.direct = true
+	}
+
+	return d
+}
+
+// intermediatePoint returns the point at `fraction` along the segment, using the model.
+func (d *segmentDensifier) intermediatePoint(fraction float64) geod.LatLon {
+	if d.direct {
+		return d.m0.DestinationPoint(d.distance*fraction, d.bearing)
+	}
+
+	return d.m0.IntermediatePointTo(d.ll1, fraction)
+}
+
+// To avoid reducing the accuracy of the intermediate points through repeated interval halving, intermediate points
+// are always calculated from the start and end points of the whole segment, and we calculate the fraction where the
+// point should be added. The starting and ending points of the part of the section we are densifying (pf and pt) are
+// passed in the recursive step, along with the fractions where those points were added (from, to). The points after
+// pf, up to and including pt, are appended to `dst`, so no intermediate slices are built.
 //
 // For example, when densifying the 2nd quarter of the segment:
 //
@@ -147,34 +348,48 @@ func DensifySegment(p0, p1 orb.Point, model, refModel geod.EarthModel, tolerance
 //  X          |<=========>|                     X
 // ll0         pf          pt                   ll1
 //           from=0.25   to=0.5
-func densifySegment(ll0, ll1 geod.LatLon, pf, pt orb.Point, from, to float64, model, refModel geod.EarthModel, tolerance units.Distance, recDepth int) ([]orb.Point, error) {
+func (d *segmentDensifier) densify(dst []orb.Point, pf, pt orb.Point, from, to float64, recDepth int) ([]orb.Point, error) {
 	recDepth -= 1
 	mid := (from + to) / 2
 
-	mp := geod.IntermediatePoint(ll0, ll1, mid, model)
+	mp := d.intermediatePoint(mid)
 
 	llf := geod.LatLon{Latitude: geod.Degrees(pf[1]), Longitude: geod.Degrees(pf[0])}
 	llt := geod.LatLon{Latitude: geod.Degrees(pt[1]), Longitude: geod.Degrees(pt[0])}
-	refMp := geod.IntermediatePoint(llf, llt, 0.5, refModel)
-	e := geod.Distance(mp, refMp, model).Metre()
+	refMf := d.refModel(llf)
 
-	if e <= tolerance.Metre() {
-		return []orb.Point{pf, pt}, nil
+	var e units.Metre
+	if d.config.maxErrorSamples > 0 {
+		errorAt := func(fraction float64) float64 {
+			p := d.intermediatePoint(from + (to-from)*fraction)
+			return float64(d.model(p).DistanceTo(refMf.IntermediatePointTo(llt, fraction)).Metre())
+		}
+		e0, _ := maximiseOnSegment(errorAt, d.config.maxErrorSamples, segmentSearchTolerance)
+		e = units.Metre(e0)
+	} else {
+		refMp := refMf.IntermediatePointTo(llt, 0.5)
+		e = d.model(mp).DistanceTo(refMp).Metre()
 	}
 
-	if recDepth == 0 {
-		return []orb.Point{pf, pt}, ErrToleranceTooLow
+	if e <= d.tolerance || recDepth == 0 {
+		if e > d.maxError {
+			d.maxError = e
+		}
+
+		if e <= d.tolerance {
+			return append(dst, pt), nil
+		}
+
+		d.depthLimitReached = true
+		return append(dst, pt), ErrToleranceTooLow
 	}
 
-	var (
-		left, right []orb.Point
-		err, err2   error
-	)
+	var err, err2 error
 
 	// middle point (mp) as orb.Point
 	omp := orb.Point{float64(mp.Longitude), float64(mp.Latitude)}
 
-	left, err2 = densifySegment(ll0, ll1, pf, omp, from, mid, model, refModel, tolerance, recDepth)
+	dst, err2 = d.densify(dst, pf, omp, from, mid, recDepth)
 	if err2 != nil {
 		if !errors.Is(err2, ErrToleranceTooLow) {
 			return nil, err2
@@ -183,7 +398,7 @@ func densifySegment(ll0, ll1 geod.LatLon, pf, pt orb.Point, from, to float64, mo
 		err = err2
 	}
 
-	right, err2 = densifySegment(ll0, ll1, omp, pt, mid, to, model, refModel, tolerance, recDepth)
+	dst, err2 = d.densify(dst, omp, pt, mid, to, recDepth)
 	if err2 != nil {
 		if !errors.Is(err2, ErrToleranceTooLow) {
 			return nil, err2
@@ -192,11 +407,7 @@ func densifySegment(ll0, ll1 geod.LatLon, pf, pt orb.Point, from, to float64, mo
 		err = err2
 	}
 
-	ds := make([]orb.Point, 0, len(left)+len(right)-1)
-	ds = append(ds, left...)
-	ds = append(ds, right[1:]...)
-
-	return ds, err
+	return dst, err
 }
 
 // SegmentError calculates the distance between the middle point of a segment calculated using planar geometry
@@ -222,3 +433,82 @@ func SegmentError(p0, p1 orb.Point, model, refModel geod.EarthModel) units.Dista
 
 	return geod.Distance(llMid, llRef, model)
 }
+
+// MaxSegmentError calculates the largest distance between the segment calculated using the given Model and using the
+// reference model. The error is calculated at `samples` evenly spaced points along the segment and the largest is then
+// refined using golden-section search, so it's found even when it's not at the middle of the segment.
+//
+// Returns the largest error and the fraction along the segment where it occurs.
+func MaxSegmentError(p0, p1 orb.Point, model, refModel geod.EarthModel, samples int) (units.Distance, float64) {
+	ll0 := geod.LatLon{Latitude: geod.Degrees(p0[1]), Longitude: geod.Degrees(p0[0])}
+	ll1 := geod.LatLon{Latitude: geod.Degrees(p1[1]), Longitude: geod.Degrees(p1[0])}
+
+	if ll0.Equals(ll1) {
+		return units.Metre(0), 0
+	}
+
+	m0 := model(ll0)
+	ref0 := refModel(ll0)
+
+	errorAt := func(fraction float64) float64 {
+		ll := m0.IntermediatePointTo(ll1, fraction)
+		if ll.Longitude == -180 {
+			// see SegmentError
+			ll.Longitude = 180
+		}
+
+		return float64(model(ll).DistanceTo(ref0.IntermediatePointTo(ll1, fraction)).Metre())
+	}
+
+	e, fraction := maximiseOnSegment(errorAt, samples, segmentSearchTolerance)
+
+	return units.Metre(e), fraction
+}
+
+// segmentSearchTolerance is the precision of the fraction found by maximiseOnSegment for the densification errors
+const segmentSearchTolerance = 1e-6
+
+// maximiseOnSegment finds the maximum of `f` in the 0..1 range, by evaluating it at `samples` points and
+// refining the largest using golden-section search until the fraction is within `tolerance`. Returns the maximum and
+// where it is.
+func maximiseOnSegment(f func(float64) float64, samples int, tolerance float64) (float64, float64) {
+	if samples < 1 {
+		samples = 1
+	}
+
+	// the ends of the segment are not sampled, they are only reached by the refinement
+	step := 1 / float64(samples+1)
+	best, bestE := 0.5, math.Inf(-1)
+	for i := 1; i <= samples; i++ {
+		x := float64(i) * step
+		if e := f(x); e > bestE {
+			best, bestE = x, e
+		}
+	}
+
+	φ := (math.Sqrt(5) - 1) / 2
+	a, b := best-step, best+step
+	c := b - φ*(b-a)
+	d := a + φ*(b-a)
+	ec, ed := f(c), f(d)
+	for b-a > tolerance {
+		if ec > ed {
+			b, d, ed = d, c, ec
+			c = b - φ*(b-a)
+			ec = f(c)
+		} else {
+			a, c, ec = c, d, ed
+			d = a + φ*(b-a)
+			ed = f(d)
+		}
+	}
+
+	if ec > bestE {
+		best, bestE = c, ec
+	}
+	if ed > bestE {
+		best, bestE = d, ed
+	}
+
+	return bestE, best
+}
@@ -1,7 +1,7 @@
 package utils_test
 
 import (
-	"fmt"
+	"math"
 	"os"
 	"testing"
 
@@ -11,46 +11,21 @@ import (
 	geod "github.com/starboard-nz/go-geodesy"
 	"github.com/starboard-nz/go-geodesy/utils"
 	"github.com/starboard-nz/orb"
-	"github.com/starboard-nz/orb/geojson"
 	"github.com/starboard-nz/units"
 )
 
 func saveToGeoJSON(fname string, geoms []orb.Geometry, points []orb.Point) error {
-	colours := []string{"#ff3300", "#00ff33", "#0033ff", "#6633ee", "#ee6633", "#33ee66"}
-	style := func(colour string) geojson.Properties {
-		return geojson.Properties{
-			"style": map[string]interface{}{
-				"color":     colour,
-				"opacity":   0.7,
-				"dashArray": "",
-				"weight":    3,
-			},
-		}
-	}
-
-	fc := geojson.NewFeatureCollection()
-	for i := range geoms {
-		fc.Append(&geojson.Feature{
-			Type:       "Feature",
-			Geometry:   geoms[i],
-			Properties: style(colours[i%len(colours)]),
-		})
+	// the points are all drawn in the colour after the geometries
+	all := append([]orb.Geometry(nil), geoms...)
+	for _, p := range points {
+		all = append(all, p)
 	}
-
-	if len(points) > 0 {
-		st := style(colours[len(geoms)%len(colours)])
-		for i := range points {
-			fc.Append(&geojson.Feature{
-				Type:       "Feature",
-				Geometry:   points[i],
-				Properties: st,
-			})
+	style := func(i int, geom orb.Geometry) utils.Style {
+		if i > len(geoms) {
+			i = len(geoms)
 		}
-	}
 
-	rawJSON, err := fc.MarshalJSON()
-	if err != nil {
-		return err
+		return utils.DefaultStyle(i, geom)
 	}
 
 	f, err := os.Create(fname)
@@ -59,12 +34,7 @@ func saveToGeoJSON(fname string, geoms []orb.Geometry, points []orb.Point) error
 	}
 	defer f.Close()
 
-	_, err = fmt.Fprintf(f, "%s\n", rawJSON)
-	if err != nil {
-		return err
-	}
-
-	return nil
+	return utils.WriteGeoJSON(f, all, style)
 }
 
 func TestSegmentError(t *testing.T) {
@@ -82,6 +52,96 @@ func TestSegmentError(t *testing.T) {
 	assert.InDelta(t, float64(e.Km()), 18.2367, 0.0001)
 }
 
+func TestMaxSegmentError(t *testing.T) {
+	p0 := orb.Point{-154.5000, -35}
+	p1 := orb.Point{-180.0000, -35}
+
+	// symmetric segment, the largest error is in the middle
+	e, f := utils.MaxSegmentError(p0, p1, geod.SphericalModel, geod.PlanarModel, 10)
+	assert.InDelta(t, 75.0483, float64(e.Km()), 0.0001)
+	assert.InDelta(t, 0.5, f, 1e-4)
+
+	// long high latitude segment
+	p0 = orb.Point{0, 60}
+	p1 = orb.Point{100, 85}
+	mid := utils.SegmentError(p0, p1, geod.SphericalModel, geod.PlanarModel)
+	e, f = utils.MaxSegmentError(p0, p1, geod.SphericalModel, geod.PlanarModel, 10)
+	assert.Greater(t, float64(e.Metre()), float64(mid.Metre()))
+	assert.Less(t, f, 0.49)
+
+	e, _ = utils.MaxSegmentError(p0, p0, geod.VincentyModel, geod.PlanarModel, 10)
+	assert.Equal(t, 0.0, float64(e.Metre()))
+}
+
+func TestDensifyMaxErrorSearch(t *testing.T) {
+	p0 := orb.Point{0, 10}
+	p1 := orb.Point{179, -80}
+	tolerance := units.Metre(10)
+
+	maxError := func(ps []orb.Point) float64 {
+		var worst float64
+		for i := 1; i < len(ps); i++ {
+			e, _ := utils.MaxSegmentError(ps[i-1], ps[i], geod.RhumbModel, geod.PlanarModel, 10)
+			worst = math.Max(worst, float64(e.Metre()))
+		}
+
+		return worst
+	}
+
+	// checking the middle of the segments only isn't enough here
+	ps, err := utils.DensifySegment(p0, p1, geod.RhumbModel, geod.PlanarModel, tolerance)
+	require.NoError(t, err)
+	assert.Greater(t, maxError(ps), float64(tolerance.Metre()))
+
+	ps, err = utils.DensifySegment(p0, p1, geod.RhumbModel, geod.PlanarModel, tolerance, utils.WithMaxErrorSearch(10))
+	require.NoError(t, err)
+	assert.LessOrEqual(t, maxError(ps), float64(tolerance.Metre()))
+}
+
+func TestDensifyReport(t *testing.T) {
+	square := orb.Ring{{170, -40}, {180, -40}, {180, -30}, {170, -30}, {170, -40}}
+	hole := orb.Ring{{174, -36}, {176, -36}, {176, -34}, {174, -34}, {174, -36}}
+	mp := orb.MultiPolygon{{square}, {square, hole}}
+	tolerance := units.Km(1)
+
+	var report utils.DensifyReport
+	dmp, err := utils.DensifyMultiPolygon(mp, geod.SphericalModel, geod.PlanarModel, tolerance, utils.WithReport(&report))
+	require.NoError(t, err)
+	require.Len(t, report.Segments, 12)
+	assert.True(t, report.ToleranceMet())
+	assert.LessOrEqual(t, float64(report.MaxError().Metre()), float64(tolerance.Metre()))
+	assert.Greater(t, float64(report.MaxError().Metre()), 0.0)
+
+	// the reports of the polygons are in order
+	last := report.Segments[len(report.Segments)-1]
+	assert.Equal(t, 1, last.Polygon)
+	assert.Equal(t, 1, last.Ring)
+	assert.Equal(t, 3, last.Segment)
+
+	added := 0
+	for i, poly := range dmp {
+		for j, ring := range poly {
+			added += len(ring) - len(mp[i][j])
+		}
+	}
+	assert.Equal(t, added, report.PointsAdded())
+
+	// segments along meridians don't need densifying
+	assert.Equal(t, 0, report.Segments[1].PointsAdded)
+	assert.InDelta(t, 0, float64(report.Segments[1].MaxError.Metre()), 1e-6)
+
+	// the segments of further calls are appended
+	_, err = utils.DensifySegment(orb.Point{0, 60}, orb.Point{100, 60}, geod.SphericalModel, geod.PlanarModel,
+		units.Metre(1e-6), utils.WithReport(&report))
+	assert.ErrorIs(t, err, utils.ErrToleranceTooLow)
+	require.Len(t, report.Segments, 13)
+	sr := report.Segments[12]
+	assert.True(t, sr.DepthLimitReached)
+	assert.Greater(t, float64(sr.MaxError.Metre()), 1e-6)
+	assert.Equal(t, 1<<14-1, sr.PointsAdded)
+	assert.False(t, report.ToleranceMet())
+}
+
 func TestDensifyRing(t *testing.T) {
 	t.Run("Simple Spherical", func(t *testing.T) {
 		p0 := orb.Point{-154.5000, -35}
@@ -281,3 +341,55 @@ func TestDensifyErrors(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Len(t, denseRing, 14499, "Got %v", len(denseRing))
 }
+
+func TestDensifyDuplicatePoints(t *testing.T) {
+	// Vincenty can't calculate a bearing between identical points
+	p0 := orb.Point{174.5, -36.5}
+	ps, err := utils.DensifySegment(p0, p0, geod.VincentyModel, geod.PlanarModel, units.Metre(1))
+	require.NoError(t, err)
+	assert.Equal(t, []orb.Point{p0, p0}, ps)
+}
+
+func TestDensifyRingInto(t *testing.T) {
+	ring := orb.Ring{{150, -10}, {-150, -55}, {170, -60}, {150, -10}}
+	expected, err := utils.DensifyRing(ring, geod.SphericalModel, geod.PlanarModel, units.Metre(100))
+	require.NoError(t, err)
+
+	buf := make(orb.Ring, 0, len(expected))
+	dr, err := utils.DensifyRingInto(buf, ring, geod.SphericalModel, geod.PlanarModel, units.Metre(100))
+	require.NoError(t, err)
+	assert.Equal(t, expected, dr)
+	assert.Equal(t, &buf[:1][0], &dr[0], "buffer not reused")
+
+	prefix := orb.Ring{{0, 0}}
+	dr, err = utils.DensifyRingInto(prefix, ring, geod.SphericalModel, geod.PlanarModel, units.Metre(100))
+	require.NoError(t, err)
+	assert.Equal(t, append(orb.Ring{{0, 0}}, expected...), dr)
+}
+
+func BenchmarkDensifyRingInto(b *testing.B) {
+	ring := orb.Ring{{150, -10}, {-150, -55}, {170, -60}, {150, -10}}
+	var buf orb.Ring
+
+	for i := 0; i < b.N; i++ {
+		buf, _ = utils.DensifyRingInto(buf[:0], ring, geod.SphericalModel, geod.PlanarModel, units.Metre(100))
+	}
+}
+
+func BenchmarkDensifySegmentVincenty(b *testing.B) {
+	p0 := orb.Point{150, -10}
+	p1 := orb.Point{-150, -55}
+
+	for i := 0; i < b.N; i++ {
+		_, _ = utils.DensifySegment(p0, p1, geod.VincentyModel, geod.PlanarModel, units.Metre(1))
+	}
+}
+
+func BenchmarkDensifySegmentRhumb(b *testing.B) {
+	p0 := orb.Point{150, -10}
+	p1 := orb.Point{-150, -55}
+
+	for i := 0; i < b.N; i++ {
+		_, _ = utils.DensifySegment(p0, p1, geod.RhumbModel, geod.PlanarModel, units.Metre(1))
+	}
+}
@@ -0,0 +1,155 @@
+package utils
+
+import (
+	"math"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+// SegmentDistance returns the minimum distance between the segments p1-p2 and q1-q2, and the closest points on each
+// segment. The distance is 0 if the segments intersect, in which case both points are the intersection.
+//
+// The intersection is found with SegmentIntersection, so the segments should be short enough for that to be
+// accurate for the model. Otherwise the closest points are found with NearestPointOnSegment from the ends of
+// each segment to the other.
+func SegmentDistance(p1, p2, q1, q2 orb.Point, model geod.EarthModel) (units.Distance, orb.Point, orb.Point) {
+	if is := SegmentIntersection(p1, p2, q1, q2); is != nil {
+		return units.Metre(0), *is, *is
+	}
+
+	best := math.Inf(1)
+	var bp, bq orb.Point
+
+	for _, p := range []orb.Point{p1, p2} {
+		q, d := NearestPointOnSegment(p, q1, q2, model)
+		if float64(d.Metre()) < best {
+			best, bp, bq = float64(d.Metre()), p, q
+		}
+	}
+	for _, q := range []orb.Point{q1, q2} {
+		p, d := NearestPointOnSegment(q, p1, p2, model)
+		if float64(d.Metre()) < best {
+			best, bp, bq = float64(d.Metre()), p, q
+		}
+	}
+
+	return units.Metre(best), bp, bq
+}
+
+// LineStringDistance returns the minimum distance between the line strings, and the closest points on each. The
+// distance is 0 if they intersect. A line string with a single point is treated as that point. Returns NaN if either
+// line string is empty. See SegmentDistance.
+func LineStringDistance(l1, l2 orb.LineString, model geod.EarthModel) (units.Distance, orb.Point, orb.Point) {
+	if len(l1) == 0 || len(l2) == 0 {
+		return units.Metre(math.NaN()), orb.Point{}, orb.Point{}
+	}
+
+	segment := func(ls orb.LineString, i int) (orb.Point, orb.Point) {
+		if len(ls) == 1 {
+			return ls[0], ls[0]
+		}
+
+		return ls[i], ls[i+1]
+	}
+	segments := func(ls orb.LineString) int {
+		return int(math.Max(1, float64(len(ls)-1)))
+	}
+
+	best := math.Inf(1)
+	var bp, bq orb.Point
+
+	for i := 0; i < segments(l1); i++ {
+		p1, p2 := segment(l1, i)
+		for j := 0; j < segments(l2); j++ {
+			q1, q2 := segment(l2, j)
+
+			d, p, q := SegmentDistance(p1, p2, q1, q2, model)
+			if float64(d.Metre()) < best {
+				best, bp, bq = float64(d.Metre()), p, q
+				if best == 0 {
+					return d, bp, bq
+				}
+			}
+		}
+	}
+
+	return units.Metre(best), bp, bq
+}
+
+// HausdorffDistance returns the Hausdorff distance between the geometries: the largest distance from a point of
+// either geometry to the nearest point of the other. It is a measure of how different two versions of a boundary
+// or line are. Polygons are compared by their rings, so the result is the largest displacement of the boundary.
+//
+// The distances are calculated from the vertices of each geometry, and from points inserted along their segments
+// every `densify` metres (or less), to the segments of the other geometry, using NearestPointOnSegment. With
+// `densify` 0 or negative only the vertices are used, which is fast, but can underestimate the distance where the
+// geometries differ in the middle of long segments. Returns NaN if either geometry is empty.
+func HausdorffDistance(g1, g2 orb.Geometry, model geod.EarthModel, densify units.Distance) units.Distance {
+	c1, c2 := newComponents(g1), newComponents(g2)
+	if c1.dimension() < 0 || c2.dimension() < 0 {
+		return units.Metre(math.NaN())
+	}
+
+	return units.Metre(math.Max(
+		directedHausdorff(c1, c2, model, float64(densify.Metre())),
+		directedHausdorff(c2, c1, model, float64(densify.Metre())),
+	))
+}
+
+// directedHausdorff returns the largest distance from the points of `from`, densified every `densify` metres, to
+// `to`
+func directedHausdorff(from, to *components, model geod.EarthModel, densify float64) float64 {
+	lines := to.linework()
+	distanceTo := func(p orb.Point) float64 {
+		d := math.Inf(1)
+		for _, q := range to.points {
+			if _, dq := NearestPointOnSegment(p, q, q, model); float64(dq.Metre()) < d {
+				d = float64(dq.Metre())
+			}
+		}
+		for _, ls := range lines {
+			if len(ls) == 1 {
+				ls = orb.LineString{ls[0], ls[0]}
+			}
+			for i := 0; i < len(ls)-1 && d > 0; i++ {
+				if _, ds := NearestPointOnSegment(p, ls[i], ls[i+1], model); float64(ds.Metre()) < d {
+					d = float64(ds.Metre())
+				}
+			}
+		}
+
+		return d
+	}
+
+	maxDist := 0.0
+	from.vertices(func(p orb.Point) bool {
+		maxDist = math.Max(maxDist, distanceTo(p))
+
+		return true
+	})
+
+	if densify <= 0 {
+		return maxDist
+	}
+
+	for _, ls := range from.linework() {
+		for i := 0; i < len(ls)-1; i++ {
+			ll0 := geod.LatLon{Latitude: geod.Degrees(ls[i][1]), Longitude: geod.Degrees(ls[i][0])}
+			ll1 := geod.LatLon{Latitude: geod.Degrees(ls[i+1][1]), Longitude: geod.Degrees(ls[i+1][0])}
+			if ll0.Equals(ll1) {
+				continue
+			}
+
+			m0 := model(ll0)
+			n := int(math.Ceil(float64(m0.DistanceTo(ll1).Metre()) / densify))
+			for k := 1; k < n; k++ {
+				ll := m0.IntermediatePointTo(ll1, float64(k)/float64(n))
+				maxDist = math.Max(maxDist, distanceTo(orb.Point{float64(ll.Longitude), float64(ll.Latitude)}))
+			}
+		}
+	}
+
+	return maxDist
+}
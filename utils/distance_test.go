@@ -0,0 +1,80 @@
+package utils_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+func TestSegmentDistance(t *testing.T) {
+	// crossing
+	d, p, q := utils.SegmentDistance(orb.Point{0, 0}, orb.Point{2, 2}, orb.Point{0, 2}, orb.Point{2, 0}, geod.RhumbModel)
+	assert.Equal(t, 0.0, float64(d.Metre()))
+	assert.Equal(t, p, q)
+	assert.InDelta(t, 1, p[0], 1e-3)
+
+	// meridians converge, the closest points are the northern ends
+	d, p, q = utils.SegmentDistance(orb.Point{0, 0}, orb.Point{0, 10}, orb.Point{1, 10}, orb.Point{1, 0}, geod.SphericalModel)
+	assert.InDelta(t, 109505.6, float64(d.Metre()), 0.1)
+	assert.Equal(t, orb.Point{0, 10}, p)
+	assert.Equal(t, orb.Point{1, 10}, q)
+
+	// T shape, the closest point is in the middle of the other segment
+	d, p, q = utils.SegmentDistance(orb.Point{0, 1}, orb.Point{0, 2}, orb.Point{-1, 0}, orb.Point{1, 0}, geod.RhumbModel)
+	assert.InDelta(t, 111194.9, float64(d.Metre()), 0.1)
+	assert.Equal(t, orb.Point{0, 1}, p)
+	assert.InDelta(t, 0, q[0], 1e-6)
+	assert.InDelta(t, 0, q[1], 1e-6)
+}
+
+func TestLineStringDistance(t *testing.T) {
+	l1 := orb.LineString{{0, 0}, {1, 0}, {2, 0}}
+	l2 := orb.LineString{{5, 5}, {1.5, 0.1}, {3, 3}}
+
+	d, p, q := utils.LineStringDistance(l1, l2, geod.SphericalModel)
+	assert.InDelta(t, 11119.5, float64(d.Metre()), 1)
+	assert.InDelta(t, 1.5, p[0], 1e-6)
+	assert.Equal(t, orb.Point{1.5, 0.1}, q)
+
+	d, _, _ = utils.LineStringDistance(l1, orb.LineString{{1, -1}, {1, 1}}, geod.SphericalModel)
+	assert.Equal(t, 0.0, float64(d.Metre()))
+
+	d, p, _ = utils.LineStringDistance(orb.LineString{{1, 1}}, l1, geod.SphericalModel)
+	assert.InDelta(t, 111194.9, float64(d.Metre()), 0.1)
+	assert.Equal(t, orb.Point{1, 1}, p)
+
+	d, _, _ = utils.LineStringDistance(nil, l1, geod.SphericalModel)
+	assert.True(t, math.IsNaN(float64(d.Metre())))
+}
+
+func TestHausdorffDistance(t *testing.T) {
+	degree := 111194.93
+
+	l1 := orb.LineString{{0, 0}, {2, 0}}
+	l2 := orb.LineString{{0, 0}, {1, 0.1}, {2, 0}}
+	d := utils.HausdorffDistance(l1, l2, geod.SphericalModel, units.Metre(0))
+	assert.InDelta(t, 0.1*degree, float64(d.Metre()), 1)
+	assert.Equal(t, d, utils.HausdorffDistance(l2, l1, geod.SphericalModel, units.Metre(0)))
+
+	// the middle of the line is only checked when densified
+	ends := orb.MultiPoint{{0, 0}, {2, 0}}
+	d = utils.HausdorffDistance(l1, ends, geod.SphericalModel, units.Metre(0))
+	assert.Equal(t, 0.0, float64(d.Metre()))
+	d = utils.HausdorffDistance(l1, ends, geod.SphericalModel, units.Metre(1000))
+	assert.InDelta(t, degree, float64(d.Metre()), 500)
+	assert.LessOrEqual(t, float64(d.Metre()), degree)
+
+	// boundary moved north
+	b1 := orb.Bound{Min: orb.Point{0, 0}, Max: orb.Point{1, 1}}.ToPolygon()
+	b2 := orb.Bound{Min: orb.Point{0, 0.1}, Max: orb.Point{1, 1.1}}.ToPolygon()
+	d = utils.HausdorffDistance(b1, b2, geod.RhumbModel, units.Metre(1000))
+	assert.InDelta(t, 0.1*degree, float64(d.Metre()), 1)
+
+	assert.True(t, math.IsNaN(float64(utils.HausdorffDistance(l1, orb.LineString{}, geod.SphericalModel, units.Metre(0)).Metre())))
+}
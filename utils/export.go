@@ -0,0 +1,238 @@
+package utils
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/orb/geojson"
+)
+
+// Style is how an exported geometry is drawn. In GeoJSON it's written as the "style" property of the feature, as
+// understood by Leaflet based viewers, and the name as the "name" property. In KML it's the style of the placemark.
+type Style struct {
+	// Name is the name of the feature or placemark, not written if empty
+	Name string
+	// Color is the colour of lines, points and polygons, as "#rrggbb"
+	Color string
+	// Opacity is from 0 (transparent) to 1 (opaque)
+	Opacity float64
+	// Weight is the width of lines in pixels
+	Weight float64
+}
+
+// StyleFunc returns the style of the i-th exported geometry.
+type StyleFunc func(i int, geom orb.Geometry) Style
+
+// defaultColours are the colours used by DefaultStyle, in turn
+var defaultColours = []string{"#ff3300", "#00ff33", "#0033ff", "#6633ee", "#ee6633", "#33ee66"}
+
+// DefaultStyle is the StyleFunc used when none is given: the geometries are drawn in a few distinct colours, in turn.
+func DefaultStyle(i int, _ orb.Geometry) Style {
+	return Style{Color: defaultColours[i%len(defaultColours)], Opacity: 0.7, Weight: 3}
+}
+
+// LineStringFromLatLons returns the points, for example waypoints, as a line string for exporting.
+func LineStringFromLatLons(lls []geod.LatLon) orb.LineString {
+	ls := make(orb.LineString, len(lls))
+	for i, ll := range lls {
+		ls[i] = orb.Point{float64(ll.Longitude), float64(ll.Latitude)}
+	}
+
+	return ls
+}
+
+// RingFromLatLons returns the points, for example of a circle returned by geod.SmallCircleArc, as a ring for
+// exporting. The ring is closed if the last point isn't the same as the first.
+func RingFromLatLons(lls []geod.LatLon) orb.Ring {
+	r := orb.Ring(LineStringFromLatLons(lls))
+	if len(r) > 0 && !r.Closed() {
+		r = append(r, r[0])
+	}
+
+	return r
+}
+
+// ToFeatureCollection returns the geometries as a GeoJSON feature collection, with the properties of the features set
+// from `style`, or DefaultStyle if nil.
+func ToFeatureCollection(geoms []orb.Geometry, style StyleFunc) *geojson.FeatureCollection {
+	if style == nil {
+		style = DefaultStyle
+	}
+
+	fc := geojson.NewFeatureCollection()
+	for i, geom := range geoms {
+		s := style(i, geom)
+
+		f := geojson.NewFeature(geom)
+		f.Properties["style"] = map[string]interface{}{
+			"color":     s.Color,
+			"opacity":   s.Opacity,
+			"dashArray": "",
+			"weight":    s.Weight,
+		}
+		if s.Name != "" {
+			f.Properties["name"] = s.Name
+		}
+
+		fc.Append(f)
+	}
+
+	return fc
+}
+
+// WriteGeoJSON writes the geometries as a GeoJSON feature collection, styled with `style`, or DefaultStyle if nil,
+// for example to check computed rings, tracks or corridors in a viewer.
+//
+// Example:
+//
+//	circle := utils.RingFromLatLons(geod.SmallCircleArc(centre, units.NM(12), 0, 0, 73, geod.VincentyModel))
+//	err := utils.WriteGeoJSON(f, []orb.Geometry{zone, circle}, nil)
+func WriteGeoJSON(w io.Writer, geoms []orb.Geometry, style StyleFunc) error {
+	rawJSON, err := ToFeatureCollection(geoms, style).MarshalJSON()
+	if err != nil {
+		return err
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", rawJSON)
+
+	return err
+}
+
+// WriteKML writes the geometries as the placemarks of a KML document, styled with `style`, or DefaultStyle if nil.
+// Rings and Bounds are written as polygons, and Multi geometries and Collections as MultiGeometries.
+func WriteKML(w io.Writer, geoms []orb.Geometry, style StyleFunc) error {
+	if style == nil {
+		style = DefaultStyle
+	}
+
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<kml xmlns="http://www.opengis.net/kml/2.2"><Document>` + "\n")
+
+	for i, geom := range geoms {
+		s := style(i, geom)
+
+		b.WriteString("<Placemark>")
+		if s.Name != "" {
+			b.WriteString("<name>")
+			if err := xml.EscapeText(&b, []byte(s.Name)); err != nil {
+				return err
+			}
+			b.WriteString("</name>")
+		}
+
+		colour := kmlColour(s.Color, s.Opacity)
+		fmt.Fprintf(&b, "<Style><LineStyle><color>%s</color><width>%s</width></LineStyle>", colour,
+			strconv.FormatFloat(s.Weight, 'f', -1, 64))
+		fmt.Fprintf(&b, "<PolyStyle><color>%s</color></PolyStyle>", colour)
+		fmt.Fprintf(&b, "<IconStyle><color>%s</color></IconStyle></Style>", colour)
+
+		writeKMLGeometry(&b, geom)
+		b.WriteString("</Placemark>\n")
+	}
+
+	b.WriteString("</Document></kml>\n")
+
+	_, err := io.WriteString(w, b.String())
+
+	return err
+}
+
+// kmlColour returns the "#rrggbb" colour with the opacity in the aabbggrr format of KML, white if the colour is
+// invalid
+func kmlColour(colour string, opacity float64) string {
+	rgb, err := strconv.ParseUint(strings.TrimPrefix(colour, "#"), 16, 32)
+	if err != nil || len(colour) != 7 {
+		rgb = 0xffffff
+	}
+
+	alpha := int(opacity*255 + 0.5)
+	if alpha < 0 {
+		alpha = 0
+	} else if alpha > 255 {
+		alpha = 255
+	}
+
+	return fmt.Sprintf("%02x%02x%02x%02x", alpha, rgb&0xff, rgb>>8&0xff, rgb>>16)
+}
+
+// writeKMLCoordinates writes the points as KML coordinates, closing them if `closed` is true
+func writeKMLCoordinates(b *strings.Builder, points []orb.Point, closed bool) {
+	b.WriteString("<coordinates>")
+	for i, p := range points {
+		if i > 0 {
+			b.WriteByte(' ')
+		}
+		b.WriteString(strconv.FormatFloat(p[0], 'f', -1, 64))
+		b.WriteByte(',')
+		b.WriteString(strconv.FormatFloat(p[1], 'f', -1, 64))
+	}
+	if closed && len(points) > 0 && points[0] != points[len(points)-1] {
+		fmt.Fprintf(b, " %s,%s", strconv.FormatFloat(points[0][0], 'f', -1, 64),
+			strconv.FormatFloat(points[0][1], 'f', -1, 64))
+	}
+	b.WriteString("</coordinates>")
+}
+
+// writeKMLGeometry writes the geometry as a KML geometry
+func writeKMLGeometry(b *strings.Builder, geom orb.Geometry) {
+	switch g := geom.(type) {
+	case orb.Point:
+		b.WriteString("<Point>")
+		writeKMLCoordinates(b, []orb.Point{g}, false)
+		b.WriteString("</Point>")
+	case orb.LineString:
+		b.WriteString("<LineString>")
+		writeKMLCoordinates(b, g, false)
+		b.WriteString("</LineString>")
+	case orb.Ring:
+		writeKMLGeometry(b, orb.Polygon{g})
+	case orb.Bound:
+		writeKMLGeometry(b, g.ToPolygon())
+	case orb.Polygon:
+		b.WriteString("<Polygon>")
+		for i, r := range g {
+			if i == 0 {
+				b.WriteString("<outerBoundaryIs><LinearRing>")
+			} else {
+				b.WriteString("<innerBoundaryIs><LinearRing>")
+			}
+			writeKMLCoordinates(b, r, true)
+			if i == 0 {
+				b.WriteString("</LinearRing></outerBoundaryIs>")
+			} else {
+				b.WriteString("</LinearRing></innerBoundaryIs>")
+			}
+		}
+		b.WriteString("</Polygon>")
+	case orb.MultiPoint:
+		b.WriteString("<MultiGeometry>")
+		for _, p := range g {
+			writeKMLGeometry(b, p)
+		}
+		b.WriteString("</MultiGeometry>")
+	case orb.MultiLineString:
+		b.WriteString("<MultiGeometry>")
+		for _, ls := range g {
+			writeKMLGeometry(b, ls)
+		}
+		b.WriteString("</MultiGeometry>")
+	case orb.MultiPolygon:
+		b.WriteString("<MultiGeometry>")
+		for _, p := range g {
+			writeKMLGeometry(b, p)
+		}
+		b.WriteString("</MultiGeometry>")
+	case orb.Collection:
+		b.WriteString("<MultiGeometry>")
+		for _, part := range g {
+			writeKMLGeometry(b, part)
+		}
+		b.WriteString("</MultiGeometry>")
+	}
+}
@@ -0,0 +1,67 @@
+package utils_test
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/orb/geojson"
+	"github.com/starboard-nz/units"
+)
+
+func TestExport(t *testing.T) {
+	centre := geod.NewLatLon(-41.29, 174.78)
+	circle := utils.RingFromLatLons(geod.SmallCircleArc(centre, units.Km(10), 0, 90, 10, geod.SphericalModel))
+	require.Len(t, circle, 11)
+	assert.True(t, circle.Closed())
+
+	waypoints := utils.LineStringFromLatLons([]geod.LatLon{centre, geod.NewLatLon(-41, 175)})
+	assert.Equal(t, orb.LineString{{174.78, -41.29}, {175, -41}}, waypoints)
+
+	holed := orb.Polygon{{{0, 0}, {10, 0}, {10, 10}, {0, 0}}, {{2, 1}, {8, 1}, {8, 7}}}
+	geoms := []orb.Geometry{circle, waypoints, orb.Point{174.78, -41.29}, holed}
+	style := func(i int, geom orb.Geometry) utils.Style {
+		s := utils.DefaultStyle(i, geom)
+		if i == 1 {
+			s.Name = "route <A & B>"
+		}
+
+		return s
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, utils.WriteGeoJSON(&buf, geoms, style))
+	fc, err := geojson.UnmarshalFeatureCollection(buf.Bytes())
+	require.NoError(t, err)
+	require.Len(t, fc.Features, 4)
+	assert.Equal(t, "route <A & B>", fc.Features[1].Properties.MustString("name"))
+	assert.Equal(t, "#00ff33", fc.Features[1].Properties["style"].(map[string]interface{})["color"])
+	assert.Equal(t, orb.Point{174.78, -41.29}, fc.Features[2].Geometry)
+
+	buf.Reset()
+	require.NoError(t, utils.WriteKML(&buf, geoms, style))
+	kml := buf.String()
+	assert.Equal(t, 4, strings.Count(kml, "<Placemark>"))
+	assert.Contains(t, kml, "<name>route &lt;A &amp; B&gt;</name>")
+	// #ff3300 at 70% opacity
+	assert.Contains(t, kml, "<color>b30033ff</color>")
+	assert.Contains(t, kml, "<LineString><coordinates>174.78,-41.29 175,-41</coordinates></LineString>")
+	// the hole is closed
+	assert.Contains(t, kml, "<innerBoundaryIs><LinearRing><coordinates>2,1 8,1 8,7 2,1</coordinates>")
+
+	// well formed
+	d := xml.NewDecoder(strings.NewReader(kml))
+	for {
+		if _, err := d.Token(); err != nil {
+			assert.Equal(t, "EOF", err.Error())
+			break
+		}
+	}
+}
@@ -0,0 +1,115 @@
+package utils
+
+import (
+	"math"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+// FrechetOption changes how FrechetDistance compares the tracks.
+type FrechetOption func(*frechetConfig)
+
+// maxResamplePoints limits the number of points of each track resampled by FrechetDistance
+const maxResamplePoints = 1 << 16
+
+type frechetConfig struct {
+	spacing float64
+}
+
+// WithResampling makes FrechetDistance resample both tracks to points evenly spaced along them, at most `spacing`
+// apart, before comparing them. The discrete Fréchet distance is then within `spacing` of the continuous one, and
+// doesn't depend on how often the positions of each track were reported. A track that would need more than 65536
+// points is compared without resampling.
+func WithResampling(spacing units.Distance) FrechetOption {
+	return func(c *frechetConfig) {
+		c.spacing = float64(spacing.Metre())
+	}
+}
+
+// FrechetDistance returns the discrete Fréchet distance between the tracks: the shortest leash that allows walking
+// along both tracks, from start to end, without going backwards, stepping from point to point. Unlike the Hausdorff
+// distance it takes the direction and order of the points into account, so it can be used to find tracks that
+// follow the same path, for example to detect duplicates. The distances between points are calculated with `model`.
+//
+// The discrete distance is only close to the continuous one if the points are close together compared to the
+// distance between the tracks; see WithResampling. Returns NaN if either track is empty.
+func FrechetDistance(track1, track2 orb.LineString, model geod.EarthModel, opts ...FrechetOption) units.Distance {
+	var c frechetConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	if len(track1) == 0 || len(track2) == 0 {
+		return units.Metre(math.NaN())
+	}
+
+	ll1, ll2 := resampleTrack(track1, c.spacing, model), resampleTrack(track2, c.spacing, model)
+
+	// only the previous row of the coupling table is kept
+	prev := make([]float64, len(ll2))
+	row := make([]float64, len(ll2))
+	for i, p := range ll1 {
+		m := model(p)
+		for j, q := range ll2 {
+			d := float64(m.DistanceTo(q).Metre())
+
+			switch {
+			case i == 0 && j == 0:
+				row[j] = d
+			case i == 0:
+				row[j] = math.Max(row[j-1], d)
+			case j == 0:
+				row[j] = math.Max(prev[j], d)
+			default:
+				row[j] = math.Max(math.Min(prev[j], math.Min(prev[j-1], row[j-1])), d)
+			}
+		}
+		prev, row = row, prev
+	}
+
+	return units.Metre(prev[len(ll2)-1])
+}
+
+// resampleTrack returns the points of the track, with points inserted along each segment so that they are at most
+// `spacing` metres apart, if `spacing` is positive. The points of the track are returned as they are if that would
+// need more than maxResamplePoints points.
+func resampleTrack(track orb.LineString, spacing float64, model geod.EarthModel) []geod.LatLon {
+	lls := make([]geod.LatLon, len(track))
+	for i, p := range track {
+		lls[i] = geod.LatLon{Latitude: geod.Degrees(p[1]), Longitude: geod.Degrees(p[0])}
+	}
+	if !(spacing > 0) {
+		return lls
+	}
+
+	// the number of pieces of each segment, counted before allocating
+	pieces := make([]int, len(lls))
+	total := float64(len(lls))
+	for i := 1; i < len(lls); i++ {
+		if lls[i-1].Equals(lls[i]) {
+			continue
+		}
+
+		n := math.Ceil(float64(model(lls[i-1]).DistanceTo(lls[i]).Metre()) / spacing)
+		if total += n - 1; !(total <= maxResamplePoints) {
+			return lls
+		}
+		pieces[i] = int(n)
+	}
+
+	resampled := make([]geod.LatLon, 0, int(total))
+	for i, ll := range lls {
+		if pieces[i] > 1 {
+			m := model(lls[i-1])
+			for k := 1; k < pieces[i]; k++ {
+				resampled = append(resampled, m.IntermediatePointTo(ll, float64(k)/float64(pieces[i])))
+			}
+		}
+
+		resampled = append(resampled, ll)
+	}
+
+	return resampled
+}
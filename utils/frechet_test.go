@@ -0,0 +1,45 @@
+package utils_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+func TestFrechetDistance(t *testing.T) {
+	degree := 111194.93
+
+	track := orb.LineString{{0, 0}, {1, 0}, {2, 0}, {3, 0}}
+	assert.Equal(t, 0.0, float64(utils.FrechetDistance(track, track, geod.SphericalModel).Metre()))
+
+	// parallel track
+	shifted := orb.LineString{{0, 0.1}, {1, 0.1}, {2, 0.1}, {3, 0.1}}
+	d := utils.FrechetDistance(track, shifted, geod.SphericalModel)
+	assert.InDelta(t, 0.1*degree, float64(d.Metre()), 1)
+	assert.Equal(t, d, utils.FrechetDistance(shifted, track, geod.SphericalModel))
+
+	// the same path in the opposite direction is as far as the ends, the Hausdorff distance is 0
+	reversed := orb.LineString{{3, 0}, {2, 0}, {1, 0}, {0, 0}}
+	d = utils.FrechetDistance(track, reversed, geod.SphericalModel)
+	assert.InDelta(t, 3*degree, float64(d.Metre()), 1)
+	assert.Equal(t, 0.0, float64(utils.HausdorffDistance(track, reversed, geod.SphericalModel, units.Metre(0)).Metre()))
+
+	// the same path reported less often
+	sparse := orb.LineString{{0, 0}, {3, 0}}
+	d = utils.FrechetDistance(track, sparse, geod.SphericalModel)
+	assert.InDelta(t, degree, float64(d.Metre()), 1)
+	d = utils.FrechetDistance(track, sparse, geod.SphericalModel, utils.WithResampling(units.Metre(1000)))
+	assert.Less(t, float64(d.Metre()), 1000.0)
+
+	// too many points to resample, the tracks are compared as they are
+	d = utils.FrechetDistance(track, sparse, geod.SphericalModel, utils.WithResampling(units.Metre(1e-3)))
+	assert.InDelta(t, degree, float64(d.Metre()), 1)
+
+	assert.True(t, math.IsNaN(float64(utils.FrechetDistance(track, nil, geod.SphericalModel).Metre())))
+}
@@ -0,0 +1,129 @@
+package utils
+
+import (
+	"time"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+// GeofenceState is the state of a tracked object relative to the zone of a Geofence.
+type GeofenceState int
+
+const (
+	// GeofenceOutside is further from the zone than the approach distance
+	GeofenceOutside GeofenceState = iota
+	// GeofenceApproaching is outside the zone, within the approach distance of its boundary
+	GeofenceApproaching
+	// GeofenceInside is inside the zone, but not for the dwell time yet
+	GeofenceInside
+	// GeofenceDwelling is inside the zone, and has been for at least the dwell time
+	GeofenceDwelling
+)
+
+// String returns the name of the state, e.g. "approaching".
+func (s GeofenceState) String() string {
+	switch s {
+	case GeofenceOutside:
+		return "outside"
+	case GeofenceApproaching:
+		return "approaching"
+	case GeofenceInside:
+		return "inside"
+	case GeofenceDwelling:
+		return "dwelling"
+	}
+
+	return "unknown"
+}
+
+// GeofenceEvent is a change of the state of a tracked object, at the position and time it was detected.
+type GeofenceEvent struct {
+	From, To GeofenceState
+	TrackPoint
+}
+
+// Geofence follows the state of a tracked object relative to a zone one position at a time, for alerting: whether it
+// is approaching the zone (within the approach distance of the boundary, i.e. inside the zone buffered by that
+// distance), inside it, or has remained inside for the dwell time.
+//
+// The positions are tested with MultiPolygonWithBoundContains, and the approach distance is measured to the nearest
+// edge of the zone with NearestPolygon, with the shape of the edges and the distances defined by the model (see the
+// NOTE on containment about densification). The dwell time is measured from the first position reported inside the
+// zone, and leaving the zone resets it.
+type Geofence struct {
+	zone     orb.MultiPolygon
+	bounds   orb.MultiPolygonBounds
+	approach float64
+	dwell    time.Duration
+	model    geod.EarthModel
+
+	state   GeofenceState
+	entered time.Time
+}
+
+// NewGeofence returns a Geofence for the zone, with the approach warning distance (0 for no approach warnings) and
+// the dwell time (0 to be dwelling as soon as the object is inside). The zone must not be modified while the Geofence
+// is used.
+//
+// Example:
+//
+//	fence := utils.NewGeofence(zone, units.NM(2), 10*time.Minute, geod.SphericalModel)
+//	for _, report := range reports {
+//		if event, ok := fence.Push(report.LatLon, report.Time); ok && event.To == utils.GeofenceDwelling {
+//			alert(report)
+//		}
+//	}
+func NewGeofence(zone orb.MultiPolygon, approach units.Distance, dwell time.Duration, model geod.EarthModel) *Geofence {
+	return &Geofence{
+		zone:     zone,
+		bounds:   orb.MultiPolygonBoundsFromMultiPolygon(zone),
+		approach: float64(approach.Metre()),
+		dwell:    dwell,
+		model:    model,
+	}
+}
+
+// State returns the state after the last position pushed, GeofenceOutside if none was pushed yet.
+func (g *Geofence) State() GeofenceState {
+	return g.state
+}
+
+// Push updates the state with the next position of the object, reported at time `t`, and returns the change of state
+// and true if the state changed, including on the first position unless it is outside. The positions must be pushed
+// in time order.
+func (g *Geofence) Push(ll geod.LatLon, t time.Time) (GeofenceEvent, bool) {
+	state := g.stateAt(ll, t)
+
+	event := GeofenceEvent{From: g.state, To: state, TrackPoint: TrackPoint{LatLon: ll, Time: t}}
+	changed := state != g.state
+	g.state = state
+
+	return event, changed
+}
+
+// stateAt returns the state of the object at `ll` at time `t`, and starts the dwell time when it enters the zone
+func (g *Geofence) stateAt(ll geod.LatLon, t time.Time) GeofenceState {
+	point := orb.Point{float64(ll.Longitude), float64(ll.Latitude)}
+
+	if MultiPolygonWithBoundContains(g.zone, g.bounds, point, g.model) {
+		if g.state != GeofenceInside && g.state != GeofenceDwelling {
+			g.entered = t
+		}
+
+		if t.Sub(g.entered) >= g.dwell {
+			return GeofenceDwelling
+		}
+
+		return GeofenceInside
+	}
+
+	if g.approach > 0 {
+		if _, _, d := NearestPolygon(g.zone, point, g.model); float64(d.Metre()) <= g.approach {
+			return GeofenceApproaching
+		}
+	}
+
+	return GeofenceOutside
+}
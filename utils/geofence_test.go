@@ -0,0 +1,84 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+func TestGeofence(t *testing.T) {
+	zone := orb.MultiPolygon{{{{0, 0}, {0.1, 0}, {0.1, 0.1}, {0, 0.1}, {0, 0}}}}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	fence := utils.NewGeofence(zone, units.Km(2), 10*time.Minute, geod.SphericalModel)
+	assert.Equal(t, utils.GeofenceOutside, fence.State())
+
+	var events []utils.GeofenceEvent
+	push := func(lon float64, minutes int) {
+		if event, ok := fence.Push(geod.NewLatLon(0.05, lon), start.Add(time.Duration(minutes)*time.Minute)); ok {
+			events = append(events, event)
+		}
+	}
+
+	// approaching from the west, 1.1km per minute, inside for 11 minutes, out for a minute and back in
+	for i := 0; i <= 10; i++ {
+		push(-0.05+float64(i)*0.01, i)
+	}
+	push(0.09, 14)
+	push(0.09, 15)
+	push(0.11, 16)
+	push(0.09, 17)
+	push(0.09, 26)
+	push(0.09, 27)
+
+	expected := []struct {
+		to      utils.GeofenceState
+		minutes int
+	}{
+		{utils.GeofenceApproaching, 4},
+		{utils.GeofenceInside, 5},
+		{utils.GeofenceDwelling, 15},
+		{utils.GeofenceApproaching, 16},
+		{utils.GeofenceInside, 17},
+		{utils.GeofenceDwelling, 27},
+	}
+	if assert.Len(t, events, len(expected)) {
+		for i, e := range expected {
+			assert.Equal(t, e.to, events[i].To, i)
+			assert.Equal(t, start.Add(time.Duration(e.minutes)*time.Minute), events[i].Time, i)
+			if i > 0 {
+				assert.Equal(t, expected[i-1].to, events[i].From, i)
+			}
+		}
+	}
+	assert.Equal(t, utils.GeofenceDwelling, fence.State())
+	assert.Equal(t, "dwelling", fence.State().String())
+
+	// no approach warnings and no dwell time
+	fence = utils.NewGeofence(zone, units.Metre(0), 0, geod.SphericalModel)
+	_, ok := fence.Push(geod.NewLatLon(0.05, -0.001), start)
+	assert.False(t, ok)
+	event, ok := fence.Push(geod.NewLatLon(0.05, 0.05), start)
+	assert.True(t, ok)
+	assert.Equal(t, utils.GeofenceOutside, event.From)
+	assert.Equal(t, utils.GeofenceDwelling, event.To)
+}
+
+func TestGeofenceMultiPolygonApproach(t *testing.T) {
+	// the great circle edge of a bulges north, within the approach distance, and b is a decoy further away
+	a := orb.Polygon{{{0, 50}, {90, 50}, {90, 60}, {0, 60}, {0, 50}}}
+	b := orb.Polygon{{{-136, 86.5}, {-135, 86.5}, {-135, 87.5}, {-136, 87.5}, {-136, 86.5}}}
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for _, zone := range []orb.MultiPolygon{{a, b}, {b, a}} {
+		fence := utils.NewGeofence(zone, units.Km(1370), 0, geod.SphericalModel)
+		event, ok := fence.Push(geod.NewLatLon(80, 45), start)
+		assert.True(t, ok)
+		assert.Equal(t, utils.GeofenceApproaching, event.To)
+	}
+}
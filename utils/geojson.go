@@ -0,0 +1,159 @@
+package utils
+
+import (
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/orb/geojson"
+	"github.com/starboard-nz/units"
+)
+
+// The functions below are shortcuts for applying the model-aware operations of this package to GeoJSON features and
+// feature collections: they read the geometries of the features, and write the results back into the features,
+// either replacing the geometry or setting a property.
+
+// FeatureContains returns true if the point is inside the Polygon, MultiPolygon, Ring or Bound geometry of the
+// feature, or inside one of the areal geometries of a Collection, with the same rules as PolygonContains.
+// Features with other geometries, or without geometry, contain nothing.
+func FeatureContains(f *geojson.Feature, point orb.Point, model geod.EarthModel, opts ...ContainsOption) bool {
+	return geometryContains(f.Geometry, point, model, opts)
+}
+
+// geometryContains returns true if the areal geometry contains the point
+func geometryContains(geom orb.Geometry, point orb.Point, model geod.EarthModel, opts []ContainsOption) bool {
+	switch g := geom.(type) {
+	case orb.Ring:
+		return RingContains(g, point, false, model, opts...)
+	case orb.Polygon:
+		return PolygonContains(g, point, model, opts...)
+	case orb.MultiPolygon:
+		return MultiPolygonContains(g, point, model, opts...)
+	case orb.Bound:
+		return PolygonContains(g.ToPolygon(), point, model, opts...)
+	case orb.Collection:
+		for _, part := range g {
+			if geometryContains(part, point, model, opts) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// FeaturesContaining returns the features of the collection that contain the point, see FeatureContains.
+//
+// Example:
+//
+//	for _, zone := range utils.FeaturesContaining(zones, orb.Point{174.78, -41.29}, geod.SphericalModel) {
+//		fmt.Println(zone.Properties.MustString("name"))
+//	}
+func FeaturesContaining(fc *geojson.FeatureCollection, point orb.Point, model geod.EarthModel, opts ...ContainsOption) []*geojson.Feature {
+	var features []*geojson.Feature
+	for _, f := range fc.Features {
+		if FeatureContains(f, point, model, opts...) {
+			features = append(features, f)
+		}
+	}
+
+	return features
+}
+
+// FeatureIntersects returns true if the geometry of the feature intersects `geom`, see Intersects.
+// Features without geometry intersect nothing.
+func FeatureIntersects(f *geojson.Feature, geom orb.Geometry, model geod.EarthModel) bool {
+	if f.Geometry == nil || geom == nil {
+		return false
+	}
+
+	return Intersects(f.Geometry, geom, model)
+}
+
+// FeaturesIntersecting returns the features of the collection whose geometries intersect `geom`, see Intersects.
+func FeaturesIntersecting(fc *geojson.FeatureCollection, geom orb.Geometry, model geod.EarthModel) []*geojson.Feature {
+	var features []*geojson.Feature
+	for _, f := range fc.Features {
+		if FeatureIntersects(f, geom, model) {
+			features = append(features, f)
+		}
+	}
+
+	return features
+}
+
+// DensifyFeature replaces the geometry of the feature with the geometry densified with DensifyOp (see DensifyPolygon
+// for the arguments). If the tolerance couldn't be met everywhere the densified geometry is still set, and
+// ErrToleranceTooLow is returned. The geometry is left unchanged for other errors.
+func DensifyFeature(f *geojson.Feature, model, refModel geod.EarthModel, tolerance units.Distance, opts ...DensifyOption) error {
+	if f.Geometry == nil {
+		return nil
+	}
+
+	geom, err := Apply(f.Geometry, DensifyOp(model, refModel, tolerance, opts...))
+	if geom != nil {
+		f.Geometry = geom
+	}
+
+	return err
+}
+
+// DensifyFeatures densifies the geometries of all the features of the collection with DensifyFeature, and returns
+// the first error.
+//
+// Example:
+//
+//	err := utils.DensifyFeatures(fc, geod.SphericalModel, geod.PlanarModel, units.Km(1))
+func DensifyFeatures(fc *geojson.FeatureCollection, model, refModel geod.EarthModel, tolerance units.Distance, opts ...DensifyOption) error {
+	var err error
+	for _, f := range fc.Features {
+		if err2 := DensifyFeature(f, model, refModel, tolerance, opts...); err2 != nil && err == nil {
+			err = err2
+		}
+	}
+
+	return err
+}
+
+// setProperty sets a property of the feature, creating the properties if needed
+func setProperty(f *geojson.Feature, name string, value interface{}) {
+	if f.Properties == nil {
+		f.Properties = make(geojson.Properties)
+	}
+
+	f.Properties[name] = value
+}
+
+// SetFeatureLength sets the property `name` of the feature to the length of its geometry in metres, measured with
+// LengthOp, and returns the length.
+func SetFeatureLength(f *geojson.Feature, name string, model geod.EarthModel) units.Distance {
+	length, _ := Apply(f.Geometry, LengthOp(model))
+	setProperty(f, name, float64(length.Metre()))
+
+	return length
+}
+
+// SetLengths sets the property `name` of all the features of the collection to their length, see SetFeatureLength.
+//
+// Example:
+//
+//	utils.SetLengths(tracks, "length_m", geod.VincentyModel)
+func SetLengths(fc *geojson.FeatureCollection, name string, model geod.EarthModel) {
+	for _, f := range fc.Features {
+		SetFeatureLength(f, name, model)
+	}
+}
+
+// SetFeatureArea sets the property `name` of the feature to the area of its geometry in square metres, measured with
+// AreaOp, and returns the area.
+func SetFeatureArea(f *geojson.Feature, name string, model geod.EarthModel) float64 {
+	area, _ := Apply(f.Geometry, AreaOp(model))
+	setProperty(f, name, area)
+
+	return area
+}
+
+// SetAreas sets the property `name` of all the features of the collection to their area, see SetFeatureArea.
+func SetAreas(fc *geojson.FeatureCollection, name string, model geod.EarthModel) {
+	for _, f := range fc.Features {
+		SetFeatureArea(f, name, model)
+	}
+}
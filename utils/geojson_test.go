@@ -0,0 +1,54 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/orb/geojson"
+	"github.com/starboard-nz/units"
+)
+
+func TestGeoJSON(t *testing.T) {
+	square := orb.Polygon{{{0, 0}, {10, 0}, {10, 10}, {0, 10}, {0, 0}}}
+	line := orb.LineString{{-5, 5}, {15, 5}}
+	far := orb.Bound{Min: orb.Point{20, 20}, Max: orb.Point{30, 30}}
+
+	fc := geojson.NewFeatureCollection()
+	fc.Append(geojson.NewFeature(square))
+	fc.Append(geojson.NewFeature(line))
+	fc.Append(geojson.NewFeature(far))
+	fc.Append(&geojson.Feature{Type: "Feature"})
+
+	inside := utils.FeaturesContaining(fc, orb.Point{5, 5}, geod.SphericalModel)
+	require.Len(t, inside, 1)
+	assert.Equal(t, fc.Features[0], inside[0])
+	assert.True(t, utils.FeatureContains(fc.Features[2], orb.Point{25, 25}, geod.RhumbModel))
+	assert.False(t, utils.FeatureContains(fc.Features[1], orb.Point{5, 5}, geod.RhumbModel))
+
+	crossing := utils.FeaturesIntersecting(fc, orb.LineString{{5, -5}, {5, 15}}, geod.SphericalModel)
+	assert.Equal(t, []*geojson.Feature{fc.Features[0], fc.Features[1]}, crossing)
+
+	utils.SetLengths(fc, "length", geod.SphericalModel)
+	length, _ := utils.Apply(line, utils.LengthOp(geod.SphericalModel))
+	assert.Equal(t, float64(length.Metre()), fc.Features[1].Properties["length"])
+	assert.Equal(t, 0.0, fc.Features[3].Properties["length"])
+
+	utils.SetAreas(fc, "area", geod.RhumbModel)
+	area, _ := utils.Apply(square, utils.AreaOp(geod.RhumbModel))
+	assert.Equal(t, area, fc.Features[0].Properties["area"])
+	assert.Equal(t, 0.0, fc.Features[1].Properties["area"])
+
+	require.NoError(t, utils.DensifyFeatures(fc, geod.SphericalModel, geod.PlanarModel, units.Km(1)))
+	assert.Greater(t, len(fc.Features[0].Geometry.(orb.Polygon)[0]), len(square[0]))
+	assert.Greater(t, len(fc.Features[1].Geometry.(orb.LineString)), len(line))
+	assert.IsType(t, orb.Polygon{}, fc.Features[2].Geometry)
+	assert.Nil(t, fc.Features[3].Geometry)
+
+	assert.ErrorIs(t, utils.DensifyFeature(fc.Features[1], geod.SphericalModel, geod.PlanarModel, units.Metre(0)), utils.ErrInvalidTolerance)
+	assert.Greater(t, len(fc.Features[1].Geometry.(orb.LineString)), len(line))
+}
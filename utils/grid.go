@@ -0,0 +1,86 @@
+package utils
+
+import (
+	"errors"
+	"math"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+// ErrInvalidSpacing is returned by Grid and SmoothPath if the spacing is not positive
+var ErrInvalidSpacing = errors.New("invalid value for spacing - must be positive")
+
+// gridDensifyTolerance is the tolerance used for densifying the cells, as a fraction of the spacing
+const gridDensifyTolerance = 0.01
+
+// Grid returns cells covering the bound, approximately `spacing` wide and tall on the ground according to the model.
+// Rows of cells have equal heights in latitude, and the cells in a row have equal widths in longitude, with the
+// number of cells in each row chosen so that the width is as close to `spacing` as possible at the middle of the row.
+//
+// The cells are returned row by row, from south to north and west to east, densified for the model with a tolerance
+// of 1% of the spacing. Cells of bounds crossing the antimeridian use longitudes over 180, as expected by the
+// containment functions.
+func Grid(bound geod.Bound, spacing units.Distance, model geod.EarthModel) ([]orb.Polygon, error) {
+	if !(spacing.Metre() > 0) {
+		return nil, ErrInvalidSpacing
+	}
+
+	s := float64(spacing.Metre())
+	south := float64(bound.Min.Latitude)
+	north := float64(bound.Max.Latitude)
+	west := float64(bound.Min.Longitude)
+	lonSpan := float64(bound.Max.Longitude - bound.Min.Longitude)
+	if lonSpan < 0 {
+		lonSpan += 360
+	}
+
+	height := float64(geod.Distance(
+		geod.LatLon{Latitude: bound.Min.Latitude},
+		geod.LatLon{Latitude: bound.Max.Latitude},
+		model).Metre())
+	rows := int(math.Max(1, math.Round(height/s)))
+	dLat := (north - south) / float64(rows)
+
+	var (
+		cells []orb.Polygon
+		err   error
+	)
+
+	for r := 0; r < rows; r++ {
+		s0 := south + float64(r)*dLat
+		n0 := s0 + dLat
+		if r == rows-1 {
+			n0 = north
+		}
+
+		// length of a degree of longitude in the middle of the row
+		mid := geod.Degrees((s0 + n0) / 2)
+		degree := float64(geod.Distance(geod.LatLon{Latitude: mid}, geod.LatLon{Latitude: mid, Longitude: 1}, model).Metre())
+		cols := int(math.Max(1, math.Round(lonSpan*degree/s)))
+		dLon := lonSpan / float64(cols)
+
+		for c := 0; c < cols; c++ {
+			w0 := west + float64(c)*dLon
+			e0 := w0 + dLon
+			if c == cols-1 {
+				e0 = west + lonSpan
+			}
+
+			cell := orb.Polygon{{{w0, s0}, {e0, s0}, {e0, n0}, {w0, n0}, {w0, s0}}}
+			dc, err2 := DensifyPolygon(cell, model, geod.PlanarModel, units.Metre(s*gridDensifyTolerance))
+			if err2 != nil {
+				if !errors.Is(err2, ErrToleranceTooLow) {
+					return nil, err2
+				}
+
+				err = err2
+			}
+
+			cells = append(cells, dc)
+		}
+	}
+
+	return cells, err
+}
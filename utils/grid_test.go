@@ -0,0 +1,57 @@
+package utils_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+func TestGrid(t *testing.T) {
+	bound := geod.NewBound(geod.NewLatLon(-40, 175), geod.NewLatLon(-35, -175))
+	spacing := units.Km(100)
+
+	cells, err := utils.Grid(bound, spacing, geod.SphericalModel)
+	require.NoError(t, err)
+	require.NotEmpty(t, cells)
+
+	for _, cell := range cells {
+		b := cell.Bound()
+		sw := geod.NewLatLon(b.Min[1], b.Min[0])
+		se := geod.NewLatLon(b.Min[1], b.Max[0])
+		nw := geod.NewLatLon(b.Max[1], b.Min[0])
+		w := geod.Distance(sw, se, geod.SphericalModel).Km()
+		h := geod.Distance(sw, nw, geod.SphericalModel).Km()
+		assert.InDelta(t, 100, float64(w), 15)
+		assert.InDelta(t, 100, float64(h), 15)
+	}
+
+	// the cells cover the bound without overlaps
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		p := orb.Point{175 + rng.Float64()*10, -40 + rng.Float64()*5}
+		n := 0
+		for _, cell := range cells {
+			if utils.PolygonContains(cell, p, geod.SphericalModel) {
+				n++
+			}
+		}
+		assert.Equal(t, 1, n, "%v", p)
+	}
+
+	// rhumb cells don't need densifying
+	cells, err = utils.Grid(bound, spacing, geod.RhumbModel)
+	require.NoError(t, err)
+	for _, cell := range cells {
+		assert.Len(t, cell[0], 5)
+	}
+
+	_, err = utils.Grid(bound, units.Metre(0), geod.RhumbModel)
+	assert.ErrorIs(t, err, utils.ErrInvalidSpacing)
+}
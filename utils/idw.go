@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"math"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/units"
+)
+
+// Sample is an observation of a value at a position, for example of the sea surface temperature or the wind speed.
+type Sample struct {
+	geod.LatLon
+	Value float64
+}
+
+// IDW returns the value at `query` interpolated from the samples with inverse distance weighting (Shepard's method):
+// the average of the values of the samples weighted by 1/dᵖ, where d is the distance from `query` to the sample,
+// measured with the model, and p is `power` (usually 2). Higher powers give more weight to the nearest samples.
+// Returns the value of the sample if `query` is at a sample, and NaN if there are no samples.
+//
+// All the samples are used, see IDWInterpolator for interpolating many values from many samples.
+//
+// Example:
+//
+//	sst := utils.IDW(samples, geod.NewLatLon(-41.5, 174.5), 2, geod.SphericalModel)
+func IDW(samples []Sample, query geod.LatLon, power float64, model geod.EarthModel) float64 {
+	m := model(query)
+
+	var w idwSum
+	for _, s := range samples {
+		if w.add(query, m, s, power) {
+			break
+		}
+	}
+
+	return w.value()
+}
+
+// IDWInterpolator interpolates values from the samples with inverse distance weighting, like IDW, using only the
+// samples within a search radius of the query, which are found with a SiteClassifier of the samples.
+type IDWInterpolator struct {
+	samples []Sample
+	power   float64
+	radius  units.Distance
+	model   geod.EarthModel
+	index   *SiteClassifier
+}
+
+// NewIDWInterpolator returns an IDWInterpolator for the samples, with the given power and search radius. The samples
+// must not be modified while the interpolator is used.
+//
+// Example:
+//
+//	ip := utils.NewIDWInterpolator(samples, 2, units.Km(50), geod.SphericalModel)
+//	for i, cell := range grid {
+//		values[i] = ip.Value(cell)
+//	}
+func NewIDWInterpolator(samples []Sample, power float64, radius units.Distance, model geod.EarthModel) *IDWInterpolator {
+	sites := make([]geod.LatLon, len(samples))
+	for i, s := range samples {
+		sites[i] = s.LatLon
+	}
+
+	return &IDWInterpolator{
+		samples: samples,
+		power:   power,
+		radius:  radius,
+		model:   model,
+		index:   NewSiteClassifier(sites, model),
+	}
+}
+
+// Value returns the value at `query` interpolated from the samples within the search radius, see IDW. Returns NaN if
+// there are no samples within the radius.
+func (ip *IDWInterpolator) Value(query geod.LatLon) float64 {
+	m := ip.model(query)
+
+	var w idwSum
+	for _, i := range ip.index.SitesWithin(query, ip.radius) {
+		if w.add(query, m, ip.samples[i], ip.power) {
+			break
+		}
+	}
+
+	return w.value()
+}
+
+// idwSum is the weighted sum of the values of the samples
+type idwSum struct {
+	sum, weights float64
+}
+
+// add adds the sample to the sum, and returns true if the query is at the sample, whose value is then the result, so
+// no more samples are needed
+func (w *idwSum) add(query geod.LatLon, m geod.Model, s Sample, power float64) bool {
+	d := float64(m.DistanceTo(s.LatLon).Metre())
+
+	if d == 0 {
+		w.sum, w.weights = s.Value, 1
+
+		return true
+	}
+
+	weight := math.Pow(d, -power)
+	w.sum += weight * s.Value
+	w.weights += weight
+
+	return false
+}
+
+// value returns the interpolated value, NaN if no samples were added
+func (w *idwSum) value() float64 {
+	if w.weights == 0 {
+		return math.NaN()
+	}
+
+	return w.sum / w.weights
+}
@@ -0,0 +1,62 @@
+package utils_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/units"
+)
+
+func TestIDW(t *testing.T) {
+	samples := []utils.Sample{
+		{LatLon: geod.NewLatLon(0, 0), Value: 10},
+		{LatLon: geod.NewLatLon(0, 2), Value: 20},
+	}
+
+	// halfway, and 3 times closer to the first sample
+	assert.InDelta(t, 15, utils.IDW(samples, geod.NewLatLon(0, 1), 2, geod.SphericalModel), 1e-9)
+	assert.InDelta(t, 11, utils.IDW(samples, geod.NewLatLon(0, 0.5), 2, geod.SphericalModel), 1e-6)
+	assert.InDelta(t, 12.5, utils.IDW(samples, geod.NewLatLon(0, 0.5), 1, geod.VincentyModel), 1e-6)
+
+	assert.Equal(t, 20.0, utils.IDW(samples, geod.NewLatLon(0, 2), 2, geod.VincentyModel))
+	assert.True(t, math.IsNaN(utils.IDW(nil, geod.NewLatLon(0, 2), 2, geod.SphericalModel)))
+}
+
+func TestIDWInterpolator(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	samples := make([]utils.Sample, 500)
+	for i := range samples {
+		ll := geod.NewLatLon(rng.Float64()*10-45, rng.Float64()*10+170)
+		samples[i] = utils.Sample{LatLon: ll, Value: float64(ll.Latitude)}
+	}
+
+	radius := units.Km(100)
+	ip := utils.NewIDWInterpolator(samples, 2, radius, geod.SphericalModel)
+
+	// the same as IDW of the samples within the radius
+	for n := 0; n < 100; n++ {
+		query := geod.NewLatLon(rng.Float64()*10-45, rng.Float64()*10+170)
+
+		var near []utils.Sample
+		for _, s := range samples {
+			if geod.Distance(query, s.LatLon, geod.SphericalModel).Metre() <= radius.Metre() {
+				near = append(near, s)
+			}
+		}
+
+		expected := utils.IDW(near, query, 2, geod.SphericalModel)
+		if len(near) == 0 {
+			assert.True(t, math.IsNaN(ip.Value(query)))
+		} else {
+			assert.InDelta(t, expected, ip.Value(query), 1e-9)
+		}
+	}
+
+	assert.Equal(t, samples[7].Value, ip.Value(samples[7].LatLon))
+	assert.True(t, math.IsNaN(ip.Value(geod.NewLatLon(0, 0))))
+}
@@ -9,25 +9,53 @@ package utils
 // TODO: combine all of the above provide functions that take an EarthModel argument
 
 import (
+	"math"
+	"sort"
+
 	geod "github.com/starboard-nz/go-geodesy"
 	"github.com/starboard-nz/orb"
 )
 
-// LineStringIntersections finds the intersections of 2 LineStrings (if exists).
+// LineStringIntersections finds the intersections of 2 LineStrings (if exists). The intersections are ordered by the
+// segment of l1 they are on, then by the segment of l2. Only the pairs of segments that overlap in longitude are
+// intersected, see segmentPairs for the complexity.
 func LineStringIntersections(l1, l2 orb.LineString) []orb.Point {
 	if len(l1) < 2 || len(l2) < 2 {
 		return nil
 	}
 
-	var intersections []orb.Point
+	type intersection struct {
+		i, j  int
+		point orb.Point
+	}
 
-	for i := 1; i < len(l1); i++ {
-		for j := 1; j < len(l2); j++ {
-			is := SegmentIntersection(l1[i-1], l1[i], l2[j-1], l2[j])
-			if is != nil {
-				intersections = append(intersections, *is)
-			}
+	var found []intersection
+
+	m1, m2 := mercatorPoints(l1), mercatorPoints(l2)
+	segmentPairs(l1, l2, func(i, j int) bool {
+		var is *orb.Point
+		if mercatorSegmentIntersection(m1[i], m1[i+1], m2[j], m2[j+1], &is) {
+			found = append(found, intersection{i: i, j: j, point: *is})
+		}
+
+		return true
+	})
+
+	if len(found) == 0 {
+		return nil
+	}
+
+	sort.Slice(found, func(a, b int) bool {
+		if found[a].i != found[b].i {
+			return found[a].i < found[b].i
 		}
+
+		return found[a].j < found[b].j
+	})
+
+	intersections := make([]orb.Point, 0, len(found))
+	for _, f := range found {
+		intersections = append(intersections, f.point)
 	}
 
 	return intersections
@@ -39,15 +67,87 @@ func LineStringsIntersect(l1, l2 orb.LineString) bool {
 		return false
 	}
 
-	for i := 1; i < len(l1); i++ {
-		for j := 1; j < len(l2); j++ {
-			if SegmentsIntersect(l1[i-1], l1[i], l2[j-1], l2[j]) {
-				return true
+	m1, m2 := mercatorPoints(l1), mercatorPoints(l2)
+
+	return !segmentPairs(l1, l2, func(i, j int) bool {
+		return !mercatorSegmentIntersection(m1[i], m1[i+1], m2[j], m2[j+1], nil)
+	})
+}
+
+// sweepMinPairs is the number of pairs of segments below which segmentPairs checks all pairs instead of sweeping
+const sweepMinPairs = 256
+
+// segmentPairs calls `f` with the indexes of the segments of l1 and l2 (segment i is from point i to point i+1)
+// whose bounding boxes overlap, until `f` returns false. Returns false if `f` did.
+//
+// This is a sweep and prune, not a Bentley-Ottmann sweep: the segments are swept from west to east in order of their
+// minimum longitude, keeping the segments of each line that span the longitude of the sweep, so only the pairs of
+// segments overlapping in longitude are checked. That takes O((n+m)·log(n+m) + k) time for k such pairs, which is
+// much less than n·m for tracks and boundaries spread east-west, but it is still O(n·m) for lines whose segments
+// mostly overlap in longitude, e.g. tracks going north-south.
+func segmentPairs(l1, l2 orb.LineString, f func(i, j int) bool) bool {
+	n1, n2 := len(l1)-1, len(l2)-1
+
+	if n1*n2 < sweepMinPairs {
+		for i := 0; i < n1; i++ {
+			for j := 0; j < n2; j++ {
+				if boundsOverlap(l1[i], l1[i+1], l2[j], l2[j+1]) && !f(i, j) {
+					return false
+				}
+			}
+		}
+
+		return true
+	}
+
+	type segment struct {
+		line       int
+		index      int
+		minX, maxX float64
+	}
+
+	segments := make([]segment, 0, n1+n2)
+	for line, ls := range []orb.LineString{l1, l2} {
+		for i := 0; i < len(ls)-1; i++ {
+			segments = append(segments, segment{
+				line:  line,
+				index: i,
+				minX:  math.Min(ls[i][0], ls[i+1][0]),
+				maxX:  math.Max(ls[i][0], ls[i+1][0]),
+			})
+		}
+	}
+
+	sort.Slice(segments, func(a, b int) bool { return segments[a].minX < segments[b].minX })
+
+	var active [2][]segment
+	for _, s := range segments {
+		other := 1 - s.line
+
+		// drop the segments of the other line that end before this one starts
+		kept := active[other][:0]
+		for _, a := range active[other] {
+			if a.maxX >= s.minX {
+				kept = append(kept, a)
 			}
 		}
+		active[other] = kept
+
+		for _, a := range kept {
+			i, j := s.index, a.index
+			if s.line == 1 {
+				i, j = j, i
+			}
+
+			if boundsOverlap(l1[i], l1[i+1], l2[j], l2[j+1]) && !f(i, j) {
+				return false
+			}
+		}
+
+		active[s.line] = append(active[s.line], s)
 	}
 
-	return false
+	return true
 }
 
 // SegmentIntersection returns the intersections of 2 segments (p1, p2) and (q1, q2) (if exists).
@@ -63,7 +163,116 @@ func SegmentsIntersect(p1, p2, q1, q2 orb.Point) bool {
 	return segmentIntersection(p1, p2, q1, q2, nil)
 }
 
+// SegmentIntersectionParams returns the intersection of 2 segments (p1, p2) and (q1, q2) like SegmentIntersection,
+// and the fractions of the segments (p1, p2) and (q1, q2) where it is, `t` and `s`, so that several intersections
+// can be ordered along a path and segments split at them. The fractions are measured in the Mercator projection, so
+// they are only proportional to the distance on the ground along segments following a parallel.
+//
+// If the segments are collinear and overlap, `overlap` is true, and the intersection returned is the first point of
+// the overlap along (p1, p2). Returns nil if the segments don't intersect.
+func SegmentIntersectionParams(p1, p2, q1, q2 orb.Point) (is *orb.Point, t, s float64, overlap bool) {
+	if !boundsOverlap(p1, p2, q1, q2) {
+		return nil, 0, 0, false
+	}
+
+	mp1 := geod.LatLon{Latitude: geod.Degrees(p1[1]), Longitude: geod.Degrees(p1[0])}.MercatorPoint()
+	mp2 := geod.LatLon{Latitude: geod.Degrees(p2[1]), Longitude: geod.Degrees(p2[0])}.MercatorPoint()
+	mq1 := geod.LatLon{Latitude: geod.Degrees(q1[1]), Longitude: geod.Degrees(q1[0])}.MercatorPoint()
+	mq2 := geod.LatLon{Latitude: geod.Degrees(q2[1]), Longitude: geod.Degrees(q2[0])}.MercatorPoint()
+
+	s1x, s1y := mp2.X-mp1.X, mp2.Y-mp1.Y
+	s2x, s2y := mq2.X-mq1.X, mq2.Y-mq1.Y
+	dx, dy := mq1.X-mp1.X, mq1.Y-mp1.Y
+
+	l1, l2 := math.Hypot(s1x, s1y), math.Hypot(s2x, s2y)
+	denom := s1x*s2y - s1y*s2x
+
+	if math.Abs(denom) > collinearTolerance*l1*l2 {
+		t = (dx*s2y - dy*s2x) / denom
+		s = (dx*s1y - dy*s1x) / denom
+		if !(t >= 0 && t <= 1 && s >= 0 && s <= 1) {
+			return nil, 0, 0, false
+		}
+
+		ll := geod.MercatorPoint{X: mp1.X + t*s1x, Y: mp1.Y + t*s1y}.LatLon()
+
+		return &orb.Point{float64(ll.Longitude), float64(ll.Latitude)}, t, s, false
+	}
+
+	// parallel: only collinear segments can intersect
+	if l1 == 0 && l2 == 0 {
+		if dx != 0 || dy != 0 {
+			return nil, 0, 0, false
+		}
+
+		return &orb.Point{p1[0], p1[1]}, 0, 0, true
+	}
+
+	if l1 == 0 {
+		// p1 is a point, swap the segments
+		is, s, t, overlap = SegmentIntersectionParams(q1, q2, p1, p2)
+
+		return is, t, s, overlap
+	}
+
+	if math.Abs(dx*s1y-dy*s1x) > collinearTolerance*l1*math.Hypot(dx, dy) {
+		return nil, 0, 0, false
+	}
+
+	// the fractions of q1 and q2 along (p1, p2)
+	tq1 := (dx*s1x + dy*s1y) / (l1 * l1)
+	tq2 := ((mq2.X-mp1.X)*s1x + (mq2.Y-mp1.Y)*s1y) / (l1 * l1)
+	t = math.Max(0, math.Min(tq1, tq2))
+	if t > math.Min(1, math.Max(tq1, tq2)) {
+		return nil, 0, 0, false
+	}
+
+	if tq1 != tq2 {
+		s = (t - tq1) / (tq2 - tq1)
+	}
+
+	if t == 0 {
+		return &orb.Point{p1[0], p1[1]}, t, s, true
+	}
+	if s == 0 {
+		return &orb.Point{q1[0], q1[1]}, t, s, true
+	}
+
+	ll := geod.MercatorPoint{X: mp1.X + t*s1x, Y: mp1.Y + t*s1y}.LatLon()
+
+	return &orb.Point{float64(ll.Longitude), float64(ll.Latitude)}, t, s, true
+}
+
+// collinearTolerance is the sine of the angle between segments below which SegmentIntersectionParams considers them
+// parallel
+const collinearTolerance = 1e-12
+
+// mercatorPoints returns the line string projected to Mercator, so that the points are only projected once when
+// intersecting each segment with many others
+func mercatorPoints(ls orb.LineString) []geod.MercatorPoint {
+	mps := make([]geod.MercatorPoint, len(ls))
+	for i, p := range ls {
+		mps[i] = geod.LatLon{Latitude: geod.Degrees(p[1]), Longitude: geod.Degrees(p[0])}.MercatorPoint()
+	}
+
+	return mps
+}
+
 func segmentIntersection(p1, p2, q1, q2 orb.Point, is **orb.Point) bool {
+	if !boundsOverlap(p1, p2, q1, q2) {
+		return false
+	}
+
+	mp1 := geod.LatLon{Latitude: geod.Degrees(p1[1]), Longitude: geod.Degrees(p1[0])}.MercatorPoint()
+	mp2 := geod.LatLon{Latitude: geod.Degrees(p2[1]), Longitude: geod.Degrees(p2[0])}.MercatorPoint()
+	mq1 := geod.LatLon{Latitude: geod.Degrees(q1[1]), Longitude: geod.Degrees(q1[0])}.MercatorPoint()
+	mq2 := geod.LatLon{Latitude: geod.Degrees(q2[1]), Longitude: geod.Degrees(q2[0])}.MercatorPoint()
+
+	return mercatorSegmentIntersection(mp1, mp2, mq1, mq2, is)
+}
+
+// boundsOverlap returns true if the bounding boxes of the segments (p1, p2) and (q1, q2) overlap
+func boundsOverlap(p1, p2, q1, q2 orb.Point) bool {
 	var pMin, pMax, qMin, qMax float64
 
 	if p1[0] < p2[0] {
@@ -92,15 +301,11 @@ func segmentIntersection(p1, p2, q1, q2 orb.Point, is **orb.Point) bool {
 		qMin, qMax = q2[1], q1[1]
 	}
 
-	if pMax < qMin || qMax < pMin {
-		return false
-	}
-
-	mp1 := geod.LatLon{Latitude: geod.Degrees(p1[1]), Longitude: geod.Degrees(p1[0])}.MercatorPoint()
-	mp2 := geod.LatLon{Latitude: geod.Degrees(p2[1]), Longitude: geod.Degrees(p2[0])}.MercatorPoint()
-	mq1 := geod.LatLon{Latitude: geod.Degrees(q1[1]), Longitude: geod.Degrees(q1[0])}.MercatorPoint()
-	mq2 := geod.LatLon{Latitude: geod.Degrees(q2[1]), Longitude: geod.Degrees(q2[0])}.MercatorPoint()
+	return !(pMax < qMin || qMax < pMin)
+}
 
+// mercatorSegmentIntersection intersects the segments (mp1, mp2) and (mq1, mq2) projected to Mercator
+func mercatorSegmentIntersection(mp1, mp2, mq1, mq2 geod.MercatorPoint, is **orb.Point) bool {
 	s1x := mp2.X - mp1.X
 	s1y := mp2.Y - mp1.Y
 	s2x := mq2.X - mq1.X
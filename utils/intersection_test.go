@@ -231,3 +231,93 @@ func BenchmarkSegmentsIntersect(b *testing.B) {
 		_ = utils.SegmentsIntersect(testP1[n%N], testP2[n%N], testP3[n%N], testP4[n%N])
 	}
 }
+
+// randomTrack returns a random walk heading east
+func randomTrack(rng *rand.Rand, n int) orb.LineString {
+	ls := make(orb.LineString, n)
+	ls[0] = orb.Point{-180 + rng.Float64(), rng.Float64()*2 - 1}
+	for i := 1; i < n; i++ {
+		ls[i] = orb.Point{ls[i-1][0] + rng.Float64()*0.05, ls[i-1][1] + rng.Float64()*0.2 - 0.1}
+	}
+
+	return ls
+}
+
+func TestLineStringIntersectionsSweep(t *testing.T) {
+	rng := rand.New(rand.NewSource(1)) // nolint:gosec
+	l1, l2 := randomTrack(rng, 1000), randomTrack(rng, 1000)
+
+	// brute force
+	var expected []orb.Point
+	for i := 1; i < len(l1); i++ {
+		for j := 1; j < len(l2); j++ {
+			if is := utils.SegmentIntersection(l1[i-1], l1[i], l2[j-1], l2[j]); is != nil {
+				expected = append(expected, *is)
+			}
+		}
+	}
+
+	require.NotEmpty(t, expected)
+	assert.Equal(t, expected, utils.LineStringIntersections(l1, l2))
+	assert.True(t, utils.LineStringsIntersect(l1, l2))
+	assert.False(t, utils.LineStringsIntersect(l1, randomTrack(rng, 10)))
+}
+
+func BenchmarkLineStringIntersections(b *testing.B) {
+	rng := rand.New(rand.NewSource(1)) // nolint:gosec
+	l1, l2 := randomTrack(rng, 10000), randomTrack(rng, 10000)
+
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		_ = utils.LineStringIntersections(l1, l2)
+	}
+}
+
+func TestSegmentIntersectionParams(t *testing.T) {
+	// crossing
+	is, tp, sp, overlap := utils.SegmentIntersectionParams(orb.Point{0, 0}, orb.Point{4, 0}, orb.Point{1, -1}, orb.Point{1, 3})
+	require.NotNil(t, is)
+	assert.False(t, overlap)
+	assert.InDelta(t, 1, is[0], 1e-9)
+	assert.InDelta(t, 0, is[1], 1e-9)
+	assert.InDelta(t, 0.25, tp, 1e-12)
+	assert.Equal(t, utils.SegmentIntersection(orb.Point{0, 0}, orb.Point{4, 0}, orb.Point{1, -1}, orb.Point{1, 3}), is)
+
+	// the fraction along the second segment is in Mercator, where latitudes further from the equator are stretched
+	assert.Less(t, sp, 0.25)
+	assert.Greater(t, sp, 0.249)
+
+	is, _, _, _ = utils.SegmentIntersectionParams(orb.Point{0, 0}, orb.Point{4, 0}, orb.Point{5, -1}, orb.Point{5, 3})
+	assert.Nil(t, is)
+
+	// collinear and overlapping
+	is, tp, sp, overlap = utils.SegmentIntersectionParams(orb.Point{0, 0}, orb.Point{4, 0}, orb.Point{6, 0}, orb.Point{2, 0})
+	require.NotNil(t, is)
+	assert.True(t, overlap)
+	assert.InDelta(t, 2, is[0], 1e-9)
+	assert.InDelta(t, 0.5, tp, 1e-12)
+	assert.InDelta(t, 1, sp, 1e-12)
+
+	is, tp, sp, overlap = utils.SegmentIntersectionParams(orb.Point{2, 0}, orb.Point{4, 0}, orb.Point{0, 0}, orb.Point{6, 0})
+	require.NotNil(t, is)
+	assert.True(t, overlap)
+	assert.Equal(t, orb.Point{2, 0}, *is)
+	assert.Equal(t, 0.0, tp)
+	assert.InDelta(t, 1.0/3, sp, 1e-12)
+
+	// collinear, not overlapping
+	is, _, _, _ = utils.SegmentIntersectionParams(orb.Point{0, 0}, orb.Point{1, 1}, orb.Point{2, 2}, orb.Point{3, 3})
+	assert.Nil(t, is)
+
+	// parallel
+	is, _, _, _ = utils.SegmentIntersectionParams(orb.Point{0, 0}, orb.Point{4, 0}, orb.Point{0, 0.1}, orb.Point{4, 0.1})
+	assert.Nil(t, is)
+
+	// a point on a segment
+	is, tp, sp, overlap = utils.SegmentIntersectionParams(orb.Point{3, 0}, orb.Point{3, 0}, orb.Point{0, 0}, orb.Point{4, 0})
+	require.NotNil(t, is)
+	assert.True(t, overlap)
+	assert.Equal(t, 0.0, tp)
+	assert.InDelta(t, 0.75, sp, 1e-12)
+}
@@ -0,0 +1,430 @@
+package utils
+
+import (
+	"math"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+// predicateTolerance is how close points have to be to be considered equal, or on a line or boundary, by the
+// topological predicates
+const predicateTolerance = units.Metre(0.01)
+
+// Topological predicates
+//
+// Intersects, Within, Overlaps and Touches follow the definitions of the DE-9IM model as used by OGC / JTS, but
+// are evaluated with the given EarthModel: points are tested against polygons with PolygonContains and OnBoundary,
+// distances to lines with NearestPointOnSegment, and edges are intersected with SegmentIntersection, so the same NOTE
+// about densification as for the containment functions applies. Points within 1cm of each other, of a line or of a
+// boundary are considered to be on it.
+//
+// The geometries can be Points, MultiPoints, LineStrings, MultiLineStrings, Rings, Polygons, MultiPolygons, Bounds
+// and Collections of these. Polygons crossing the antimeridian should use 0..360 longitudes, as for the containment
+// functions, and the other geometry must use the same range.
+
+// location of a point relative to an area
+type location int
+
+const (
+	exterior location = iota
+	boundary
+	interior
+)
+
+// components holds the parts of a geometry, grouped by dimension
+type components struct {
+	points []orb.Point
+	lines  []orb.LineString
+	polys  []orb.Polygon
+	bounds []orb.PolygonBounds
+}
+
+// dimension returns the highest dimension of the components, -1 if empty
+func (c *components) dimension() int {
+	switch {
+	case len(c.polys) > 0:
+		return 2
+	case len(c.lines) > 0:
+		return 1
+	case len(c.points) > 0:
+		return 0
+	}
+
+	return -1
+}
+
+// add adds the parts of the geometry
+func (c *components) add(geom orb.Geometry) {
+	switch g := geom.(type) {
+	case orb.Point:
+		c.points = append(c.points, g)
+	case orb.MultiPoint:
+		c.points = append(c.points, g...)
+	case orb.LineString:
+		if len(g) > 0 {
+			c.lines = append(c.lines, g)
+		}
+	case orb.MultiLineString:
+		for _, ls := range g {
+			c.add(ls)
+		}
+	case orb.Ring:
+		c.add(orb.Polygon{g})
+	case orb.Polygon:
+		if len(g) > 0 && len(g[0]) > 0 {
+			c.polys = append(c.polys, g)
+			c.bounds = append(c.bounds, orb.PolygonBoundsFromPolygon(g))
+		}
+	case orb.MultiPolygon:
+		for _, p := range g {
+			c.add(p)
+		}
+	case orb.Bound:
+		c.add(g.ToPolygon())
+	case orb.Collection:
+		for _, child := range g {
+			c.add(child)
+		}
+	}
+}
+
+func newComponents(geom orb.Geometry) *components {
+	c := &components{}
+	c.add(geom)
+
+	return c
+}
+
+// locate returns the location of the point relative to the polygons
+func (c *components) locate(p orb.Point, model geod.EarthModel) location {
+	loc := exterior
+	for i, poly := range c.polys {
+		if OnBoundary(poly, p, predicateTolerance, model) {
+			loc = boundary
+		} else if PolygonWithBoundContains(poly, c.bounds[i], p, model) {
+			return interior
+		}
+	}
+
+	return loc
+}
+
+// onLine returns true if the point is on one of the lines
+func (c *components) onLine(p orb.Point, model geod.EarthModel) bool {
+	for _, ls := range c.lines {
+		if len(ls) == 1 && pointsEqual(p, ls[0], model) {
+			return true
+		}
+		for i := 0; i < len(ls)-1; i++ {
+			if _, d := NearestPointOnSegment(p, ls[i], ls[i+1], model); d.Metre() <= predicateTolerance {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// onLineBoundary returns true if the point is at an end of one of the (open) lines
+func (c *components) onLineBoundary(p orb.Point, model geod.EarthModel) bool {
+	for _, ls := range c.lines {
+		if ls[0] == ls[len(ls)-1] {
+			continue
+		}
+		if pointsEqual(p, ls[0], model) || pointsEqual(p, ls[len(ls)-1], model) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasPoint returns true if the point is one of the points
+func (c *components) hasPoint(p orb.Point, model geod.EarthModel) bool {
+	for _, q := range c.points {
+		if pointsEqual(p, q, model) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// pointsEqual returns true if the points are within predicateTolerance of each other
+func pointsEqual(p, q orb.Point, model geod.EarthModel) bool {
+	if p == q {
+		return true
+	}
+
+	ll := geod.LatLon{Latitude: geod.Degrees(p[1]), Longitude: geod.Degrees(p[0])}
+	other := geod.LatLon{Latitude: geod.Degrees(q[1]), Longitude: geod.Degrees(q[0])}
+
+	return model(ll).DistanceTo(other).Metre() <= predicateTolerance
+}
+
+// edges returns the indexes of the segments of the lines and polygon rings, for segmentCuts
+func (c *components) edges() []*SegmentIndex {
+	var edges []orb.Ring
+	for _, ls := range c.lines {
+		edges = append(edges, orb.Ring(ls))
+	}
+	for _, p := range c.polys {
+		edges = append(edges, p...)
+	}
+
+	return newSegmentIndexes(edges)
+}
+
+// pieces calls `f` with the midpoint of each piece of the segments of the lines, split where they meet `edges`,
+// until `f` returns false. Returns false if `f` did.
+func pieces(lines []orb.LineString, edges []*SegmentIndex, model geod.EarthModel, f func(orb.Point) bool) bool {
+	for _, ls := range lines {
+		for i := 0; i < len(ls)-1; i++ {
+			cuts, _ := segmentCuts(ls[i], ls[i+1], edges, model)
+
+			ll0 := geod.LatLon{Latitude: geod.Degrees(ls[i][1]), Longitude: geod.Degrees(ls[i][0])}
+			ll1 := geod.LatLon{Latitude: geod.Degrees(ls[i+1][1]), Longitude: geod.Degrees(ls[i+1][0])}
+			if ll0.Equals(ll1) {
+				continue
+			}
+
+			m0 := model(ll0)
+			for j := 0; j < len(cuts)-1; j++ {
+				ll := m0.IntermediatePointTo(ll1, (cuts[j].fraction+cuts[j+1].fraction)/2)
+				if !f(orb.Point{float64(ll.Longitude), float64(ll.Latitude)}) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// vertices calls `f` with each point, vertex of the lines and vertex of the polygons until `f` returns false.
+// Returns false if `f` did.
+func (c *components) vertices(f func(orb.Point) bool) bool {
+	for _, p := range c.points {
+		if !f(p) {
+			return false
+		}
+	}
+	for _, ls := range c.lines {
+		for _, p := range ls {
+			if !f(p) {
+				return false
+			}
+		}
+	}
+	for _, poly := range c.polys {
+		for _, r := range poly {
+			for _, p := range r {
+				if !f(p) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// linework returns the lines and the rings of the polygons as line strings
+func (c *components) linework() []orb.LineString {
+	lines := append([]orb.LineString(nil), c.lines...)
+	for _, poly := range c.polys {
+		for _, r := range poly {
+			lines = append(lines, orb.LineString(r))
+		}
+	}
+
+	return lines
+}
+
+// Intersects returns true if the geometries have at least one point in common, including their boundaries.
+// See the notes on the topological predicates.
+func Intersects(a, b orb.Geometry, model geod.EarthModel) bool {
+	ca, cb := newComponents(a), newComponents(b)
+
+	return componentsIntersect(ca, cb, model)
+}
+
+func componentsIntersect(ca, cb *components, model geod.EarthModel) bool {
+	// vertices of one on the other, the callbacks return false to stop when one is found
+	on := func(c *components) func(orb.Point) bool {
+		return func(p orb.Point) bool {
+			return !(c.hasPoint(p, model) || c.onLine(p, model) || c.locate(p, model) != exterior)
+		}
+	}
+	if !ca.vertices(on(cb)) || !cb.vertices(on(ca)) {
+		return true
+	}
+
+	// crossing edges
+	ea, eb := ca.edges(), cb.edges()
+	for _, ia := range ea {
+		la := ia.Line()
+		for i := 0; i < len(la)-1; i++ {
+			for _, ib := range eb {
+				for _, j := range ib.Search(math.Min(la[i][0], la[i+1][0]), math.Max(la[i][0], la[i+1][0])) {
+					q0, q1 := ib.Segment(j)
+					if SegmentsIntersect(la[i], la[i+1], q0, q1) {
+						return true
+					}
+				}
+			}
+		}
+	}
+
+	return false
+}
+
+// interiorsIntersect returns true if the interiors of the geometries have a point in common
+func interiorsIntersect(ca, cb *components, model geod.EarthModel) bool {
+	// the interior of a point is the point, the interior of a line excludes the ends of open lines
+	inInterior := func(c *components, p orb.Point) bool {
+		return c.hasPoint(p, model) || (c.onLine(p, model) && !c.onLineBoundary(p, model)) ||
+			c.locate(p, model) == interior
+	}
+
+	for _, p := range ca.points {
+		if inInterior(cb, p) {
+			return true
+		}
+	}
+	for _, p := range cb.points {
+		if inInterior(ca, p) {
+			return true
+		}
+	}
+
+	// pieces of the lines of one in the interior of the other
+	linesIn := func(lines []orb.LineString, c *components) bool {
+		return !pieces(lines, c.edges(), model, func(p orb.Point) bool {
+			return !inInterior(c, p)
+		})
+	}
+	if linesIn(ca.lines, cb) || linesIn(cb.lines, ca) {
+		return true
+	}
+
+	// lines crossing each other away from their ends
+	for _, la := range ca.lines {
+		for _, lb := range cb.lines {
+			for i := 0; i < len(la)-1; i++ {
+				for j := 0; j < len(lb)-1; j++ {
+					is := SegmentIntersection(la[i], la[i+1], lb[j], lb[j+1])
+					if is != nil && !ca.onLineBoundary(*is, model) && !cb.onLineBoundary(*is, model) {
+						return true
+					}
+				}
+			}
+		}
+	}
+
+	if len(ca.polys) == 0 || len(cb.polys) == 0 {
+		return false
+	}
+
+	// pieces of the boundaries of the polygons of one in the interior of the other
+	ringsA := (&components{polys: ca.polys}).linework()
+	ringsB := (&components{polys: cb.polys}).linework()
+	polysA := &components{polys: ca.polys, bounds: ca.bounds}
+	polysB := &components{polys: cb.polys, bounds: cb.bounds}
+	inPolys := func(c *components) func(orb.Point) bool {
+		return func(p orb.Point) bool {
+			return c.locate(p, model) != interior
+		}
+	}
+	if !pieces(ringsA, polysB.edges(), model, inPolys(polysB)) ||
+		!pieces(ringsB, polysA.edges(), model, inPolys(polysA)) {
+		return true
+	}
+
+	// the boundaries are on each other, so the polygons are the same or on either side of the boundaries
+	return polygonsCover(polysA, polysB, model) || polygonsCover(polysB, polysA, model)
+}
+
+// polygonsCover returns true if no part of the boundary of the polygons of `cb` is outside the polygons of `ca`
+func polygonsCover(ca, cb *components, model geod.EarthModel) bool {
+	rings := (&components{polys: cb.polys}).linework()
+
+	return pieces(rings, ca.edges(), model, func(p orb.Point) bool {
+		return ca.locate(p, model) != exterior
+	}) && cb.vertices(func(p orb.Point) bool {
+		return ca.locate(p, model) != exterior
+	})
+}
+
+// Within returns true if `a` is inside `b`: no point of `a` is outside `b`, and their interiors have at least one
+// point in common, so a line along the boundary of a polygon is not within it. See the notes on the topological
+// predicates.
+func Within(a, b orb.Geometry, model geod.EarthModel) bool {
+	ca, cb := newComponents(a), newComponents(b)
+	if ca.dimension() < 0 || ca.dimension() > cb.dimension() {
+		return false
+	}
+
+	return covered(ca, cb, model) && interiorsIntersect(ca, cb, model)
+}
+
+// covered returns true if no point of `ca` is outside `cb`
+func covered(ca, cb *components, model geod.EarthModel) bool {
+	in := func(p orb.Point) bool {
+		return cb.hasPoint(p, model) || cb.onLine(p, model) || cb.locate(p, model) != exterior
+	}
+
+	if !ca.vertices(in) || !pieces(ca.linework(), cb.edges(), model, in) {
+		return false
+	}
+
+	// holes of `cb` inside the polygons of `ca`
+	for _, poly := range cb.polys {
+		for _, hole := range poly[1:] {
+			for _, p := range hole {
+				if ca.locate(p, model) == interior {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
+
+// Overlaps returns true if the geometries have the same dimension, their interiors intersect in that dimension, and
+// neither is within the other, so lines overlap if they share a piece, not if they just cross. See the notes on the
+// topological predicates.
+func Overlaps(a, b orb.Geometry, model geod.EarthModel) bool {
+	ca, cb := newComponents(a), newComponents(b)
+	if ca.dimension() < 0 || ca.dimension() != cb.dimension() {
+		return false
+	}
+
+	intersect := interiorsIntersect(ca, cb, model)
+	if ca.dimension() == 1 {
+		intersect = linesShare(ca, cb, model)
+	}
+
+	return intersect && !covered(ca, cb, model) && !covered(cb, ca, model)
+}
+
+// linesShare returns true if a piece of the lines of `ca` is in the interior of the lines of `cb`
+func linesShare(ca, cb *components, model geod.EarthModel) bool {
+	return !pieces(ca.lines, cb.edges(), model, func(p orb.Point) bool {
+		return !cb.onLine(p, model) || cb.onLineBoundary(p, model)
+	})
+}
+
+// Touches returns true if the geometries have at least one point in common, but their interiors don't intersect,
+// for example polygons that share an edge. See the notes on the topological predicates.
+func Touches(a, b orb.Geometry, model geod.EarthModel) bool {
+	ca, cb := newComponents(a), newComponents(b)
+	if ca.dimension() == 0 && cb.dimension() == 0 {
+		return false
+	}
+
+	return componentsIntersect(ca, cb, model) && !interiorsIntersect(ca, cb, model)
+}
@@ -0,0 +1,69 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+)
+
+func TestPredicates(t *testing.T) {
+	model := geod.RhumbModel
+
+	square := orb.Polygon{{{0, 0}, {2, 0}, {2, 2}, {0, 2}, {0, 0}}}
+	inner := orb.Polygon{{{0.5, 0.5}, {1, 0.5}, {1, 1}, {0.5, 1}, {0.5, 0.5}}}
+	adjacent := orb.Polygon{{{2, 0}, {3, 0}, {3, 2}, {2, 2}, {2, 0}}}
+	overlapping := orb.Polygon{{{1, 1}, {3, 1}, {3, 3}, {1, 3}, {1, 1}}}
+	far := orb.Polygon{{{10, 10}, {11, 10}, {11, 11}, {10, 11}, {10, 10}}}
+	holed := orb.Polygon{square[0], {{0.4, 0.4}, {1.2, 0.4}, {1.2, 1.2}, {0.4, 1.2}, {0.4, 0.4}}}
+
+	tests := []struct {
+		name                                  string
+		a, b                                  orb.Geometry
+		intersects, within, overlaps, touches bool
+	}{
+		{"inner polygon", inner, square, true, true, false, false},
+		{"containing polygon", square, inner, true, false, false, false},
+		{"same polygon", square, square, true, true, false, false},
+		{"adjacent polygons", adjacent, square, true, false, false, true},
+		{"overlapping polygons", overlapping, square, true, false, true, false},
+		{"disjoint polygons", far, square, false, false, false, false},
+		{"polygon in a hole", inner, holed, false, false, false, false},
+		{"hole inside", square, holed, true, false, false, false},
+		{"point inside", orb.Point{1, 1}, square, true, true, false, false},
+		{"point on boundary", orb.Point{2, 1}, square, true, false, false, true},
+		{"point outside", orb.Point{5, 5}, square, false, false, false, false},
+		{"point on line", orb.Point{1, 0}, orb.LineString{{0, 0}, {2, 0}}, true, true, false, false},
+		{"point at end of line", orb.Point{0, 0}, orb.LineString{{0, 0}, {2, 0}}, true, false, false, true},
+		{"same points", orb.Point{1, 1}, orb.Point{1, 1}, true, true, false, false},
+		{"line inside", orb.LineString{{0.5, 0.5}, {1.5, 1.5}}, square, true, true, false, false},
+		{"line crossing", orb.LineString{{1, 1}, {3, 1}}, square, true, false, false, false},
+		{"line along boundary", orb.LineString{{0, 0}, {2, 0}}, square, true, false, false, true},
+		{"line touching from outside", orb.LineString{{2, 1}, {3, 1}}, square, true, false, false, true},
+		{"crossing lines", orb.LineString{{0, 0}, {2, 2}}, orb.LineString{{0, 2}, {2, 0}}, true, false, false, false},
+		{"lines sharing a piece", orb.LineString{{0, 0}, {2, 0}}, orb.LineString{{1, 0}, {3, 0}}, true, false, true, false},
+		{"line along another", orb.LineString{{0.5, 0}, {1.5, 0}}, orb.LineString{{0, 0}, {2, 0}}, true, true, false, false},
+		{"lines meeting at the ends", orb.LineString{{0, 0}, {1, 1}}, orb.LineString{{1, 1}, {2, 0}}, true, false, false, true},
+		{"multipolygon", orb.MultiPolygon{inner, far}, orb.MultiPolygon{square, far}, true, true, false, false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			assert.Equal(t, test.intersects, utils.Intersects(test.a, test.b, model), "Intersects")
+			assert.Equal(t, test.intersects, utils.Intersects(test.b, test.a, model), "Intersects reversed")
+			assert.Equal(t, test.within, utils.Within(test.a, test.b, model), "Within")
+			assert.Equal(t, test.overlaps, utils.Overlaps(test.a, test.b, model), "Overlaps")
+			assert.Equal(t, test.overlaps, utils.Overlaps(test.b, test.a, model), "Overlaps reversed")
+			assert.Equal(t, test.touches, utils.Touches(test.a, test.b, model), "Touches")
+			assert.Equal(t, test.touches, utils.Touches(test.b, test.a, model), "Touches reversed")
+		})
+	}
+
+	// across the antimeridian
+	am := orb.Polygon{{{179, -1}, {181, -1}, {181, 1}, {179, 1}, {179, -1}}}
+	assert.True(t, utils.Within(orb.Point{180.5, 0}, am, geod.SphericalModel))
+	assert.True(t, utils.Intersects(orb.LineString{{178, 0}, {180, 0}}, am, geod.SphericalModel))
+}
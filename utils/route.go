@@ -0,0 +1,72 @@
+package utils
+
+import (
+	"math"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+// RouteMatch is the position of a fix of a track matched to a route.
+type RouteMatch struct {
+	// Matched is false if the fix is further than the maximum offset from the route, in which case the other
+	// fields are those of the nearest point of the route
+	Matched bool
+	// Point is the nearest point of the route to the fix
+	Point orb.Point
+	// Segment is the index of the segment of the route (from point Segment to point Segment+1) with the point
+	Segment int
+	// Offset is the distance of the fix from the route
+	Offset units.Distance
+	// Along is the distance along the route from its start to the point
+	Along units.Distance
+}
+
+// MatchToRoute snaps each fix of the track to the nearest point of the route, returning the snapped positions and
+// their distances along the route, for example to measure the progress of a vessel along a shipping lane. Fixes more
+// than `maxOffset` from the route are not matched. The shape of the segments and the distances are defined by
+// `model`.
+//
+// Each fix is matched independently, so where the route passes close to itself a fix can be matched to either part.
+// Returns nil if the route is empty.
+func MatchToRoute(track, route orb.LineString, maxOffset units.Distance, model geod.EarthModel) []RouteMatch {
+	if len(route) == 0 {
+		return nil
+	}
+
+	if len(route) == 1 {
+		route = orb.LineString{route[0], route[0]}
+	}
+
+	// distance along the route to the start of each segment
+	starts := make([]float64, len(route)-1)
+	for i := 1; i < len(route)-1; i++ {
+		starts[i] = starts[i-1] + pointDistance(route[i-1], route[i], model)
+	}
+
+	matches := make([]RouteMatch, 0, len(track))
+	for _, fix := range track {
+		best := RouteMatch{Offset: units.Metre(math.Inf(1))}
+		for i := 0; i < len(route)-1; i++ {
+			p, d := NearestPointOnSegment(fix, route[i], route[i+1], model)
+			if d.Metre() < best.Offset.Metre() {
+				best = RouteMatch{Point: p, Segment: i, Offset: d}
+			}
+		}
+
+		best.Matched = best.Offset.Metre() <= maxOffset.Metre()
+		best.Along = units.Metre(starts[best.Segment] + pointDistance(route[best.Segment], best.Point, model))
+		matches = append(matches, best)
+	}
+
+	return matches
+}
+
+// pointDistance returns the distance between the points in metres
+func pointDistance(p, q orb.Point, model geod.EarthModel) float64 {
+	ll := geod.LatLon{Latitude: geod.Degrees(p[1]), Longitude: geod.Degrees(p[0])}
+	other := geod.LatLon{Latitude: geod.Degrees(q[1]), Longitude: geod.Degrees(q[0])}
+
+	return float64(model(ll).DistanceTo(other).Metre())
+}
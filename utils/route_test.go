@@ -0,0 +1,44 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+func TestMatchToRoute(t *testing.T) {
+	degree := 111194.93
+
+	route := orb.LineString{{0, 0}, {1, 0}, {1, 1}}
+	track := orb.LineString{{0.5, 0.01}, {1.01, 0.5}, {0.5, 0.5}, {2, 2}}
+
+	matches := utils.MatchToRoute(track, route, units.Metre(5000), geod.SphericalModel)
+	assert.Len(t, matches, 4)
+
+	assert.True(t, matches[0].Matched)
+	assert.Equal(t, 0, matches[0].Segment)
+	assert.InDelta(t, 0.5, matches[0].Point[0], 1e-6)
+	assert.InDelta(t, 0, matches[0].Point[1], 1e-6)
+	assert.InDelta(t, 0.01*degree, float64(matches[0].Offset.Metre()), 1)
+	assert.InDelta(t, 0.5*degree, float64(matches[0].Along.Metre()), 1)
+
+	assert.True(t, matches[1].Matched)
+	assert.Equal(t, 1, matches[1].Segment)
+	assert.InDelta(t, 1, matches[1].Point[0], 1e-6)
+	assert.InDelta(t, 0.5, matches[1].Point[1], 1e-4)
+	assert.InDelta(t, 1.5*degree, float64(matches[1].Along.Metre()), 20)
+
+	// too far from the route
+	assert.False(t, matches[2].Matched)
+	assert.InDelta(t, 0.5*degree, float64(matches[2].Offset.Metre()), 100)
+	assert.False(t, matches[3].Matched)
+	assert.Equal(t, orb.Point{1, 1}, matches[3].Point)
+	assert.InDelta(t, 2*degree, float64(matches[3].Along.Metre()), 1)
+
+	assert.Nil(t, utils.MatchToRoute(track, nil, units.Metre(5000), geod.SphericalModel))
+}
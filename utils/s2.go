@@ -0,0 +1,135 @@
+package utils
+
+import (
+	"math"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+)
+
+// s2CoveringMinLevel is the level S2Covering starts testing cells at. Coarser cells are too large for their edges
+// to be compared as straight lines.
+const s2CoveringMinLevel = 4
+
+// s2BoundPadding is the fraction of the size of a cell's bound added on each side, to allow for the curvature of
+// the cell edges when rejecting cells outside the polygon
+const s2BoundPadding = 0.1
+
+// S2Covering returns the S2 cells covering the polygon, sorted by ID. Cells on the boundary of the polygon are at
+// `maxLevel`, and cells entirely inside the polygon are at the coarsest level possible (but not below level 4), so
+// the covering is compact but no coarser than needed at the edges.
+//
+// The edges of the cells and of the polygon are compared as straight lines in latitude/longitude, and the inside
+// of the polygon is tested with PolygonContains with the given model, so the same NOTE about densification applies.
+// Polygons crossing the antimeridian should use 0..360 longitudes, as for the containment functions.
+func S2Covering(poly orb.Polygon, maxLevel int, model geod.EarthModel) []geod.S2CellID {
+	if len(poly) == 0 || len(poly[0]) < 3 {
+		return nil
+	}
+
+	if maxLevel > geod.S2MaxLevel {
+		maxLevel = geod.S2MaxLevel
+	}
+
+	c := s2Coverer{
+		poly:     poly,
+		bounds:   orb.PolygonBoundsFromPolygon(poly),
+		model:    model,
+		maxLevel: maxLevel,
+		minLevel: s2CoveringMinLevel,
+	}
+	if c.minLevel > maxLevel {
+		c.minLevel = maxLevel
+	}
+
+	// the centre of the polygon's longitudes, so cells can be shifted to the same range
+	c.lonCentre = (c.bounds[0].Min[0] + c.bounds[0].Max[0]) / 2
+
+	var cells []geod.S2CellID
+	for face := 0; face < 6; face++ {
+		cells = c.cover(geod.S2FaceCell(face), cells)
+	}
+
+	return cells
+}
+
+// s2Coverer holds the state of S2Covering
+type s2Coverer struct {
+	poly      orb.Polygon
+	bounds    orb.PolygonBounds
+	model     geod.EarthModel
+	maxLevel  int
+	minLevel  int
+	lonCentre float64
+}
+
+// cover appends the cells covering the polygon within `cell`
+func (c *s2Coverer) cover(cell geod.S2CellID, cells []geod.S2CellID) []geod.S2CellID {
+	level := cell.Level()
+	if level < c.minLevel {
+		for _, child := range cell.Children() {
+			cells = c.cover(child, cells)
+		}
+
+		return cells
+	}
+
+	inside, boundary := c.relation(cell)
+	switch {
+	case boundary && level < c.maxLevel:
+		for _, child := range cell.Children() {
+			cells = c.cover(child, cells)
+		}
+	case boundary || inside:
+		cells = append(cells, cell)
+	}
+
+	return cells
+}
+
+// relation returns whether the cell is entirely inside the polygon, or crosses its boundary
+func (c *s2Coverer) relation(cell geod.S2CellID) (bool, bool) {
+	ring := cell.Polygon()[0]
+
+	// move the cell to the longitudes of the polygon
+	shift := 0.0
+	for ring[0][0]+shift < c.lonCentre-180 {
+		shift += 360
+	}
+	for ring[0][0]+shift > c.lonCentre+180 {
+		shift -= 360
+	}
+	for i := range ring {
+		ring[i][0] += shift
+	}
+
+	b := ring.Bound()
+	b = b.Pad(s2BoundPadding * math.Max(b.Max[0]-b.Min[0], b.Max[1]-b.Min[1]))
+	if !b.Intersects(c.bounds[0]) {
+		return false, false
+	}
+
+	for _, r := range c.poly {
+		for i := 1; i < len(r); i++ {
+			for j := 1; j < len(ring); j++ {
+				if SegmentsIntersect(r[i-1], r[i], ring[j-1], ring[j]) {
+					return false, true
+				}
+			}
+		}
+
+		// rings entirely inside the cell
+		for _, p := range r {
+			ll := geod.LatLon{Latitude: geod.Degrees(p[1]), Longitude: geod.Degrees(p[0])}
+			if cell.Contains(geod.ToS2CellID(ll, geod.S2MaxLevel)) {
+				return false, true
+			}
+		}
+	}
+
+	centre := cell.LatLon()
+	lon := ring[0][0] + float64(geod.Wrap180(centre.Longitude-geod.Degrees(ring[0][0])))
+	p := orb.Point{lon, float64(centre.Latitude)}
+
+	return PolygonWithBoundContains(c.poly, c.bounds, p, c.model), false
+}
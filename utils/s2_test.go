@@ -0,0 +1,73 @@
+package utils_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+// coveringContains returns true if one of the cells contains the point
+func coveringContains(cells []geod.S2CellID, p orb.Point) bool {
+	leaf := geod.ToS2CellID(geod.NewLatLon(p[1], p[0]), geod.S2MaxLevel)
+	for _, cell := range cells {
+		if cell.Contains(leaf) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func TestS2Covering(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	polys := []orb.Polygon{
+		// Cook Strait
+		{{{174.2, -41.6}, {175.0, -41.6}, {175.0, -41.0}, {174.6, -40.8}, {174.2, -41.0}, {174.2, -41.6}}},
+		// crossing the antimeridian, with a hole
+		{
+			{{178, -18}, {182, -18}, {182, -15}, {178, -15}, {178, -18}},
+			{{179.5, -17}, {180.5, -17}, {180.5, -16}, {179.5, -16}, {179.5, -17}},
+		},
+	}
+
+	for _, poly := range polys {
+		cells := utils.S2Covering(poly, 12, geod.RhumbModel)
+		require.NotEmpty(t, cells)
+
+		levels := map[int]int{}
+		for i, cell := range cells {
+			levels[cell.Level()]++
+			assert.LessOrEqual(t, cell.Level(), 12)
+			if i > 0 {
+				assert.Less(t, uint64(cells[i-1]), uint64(cell))
+			}
+		}
+		// interior cells are coarser
+		assert.Greater(t, len(levels), 1)
+
+		for _, p := range utils.SamplePoints(poly, 1000, geod.RhumbModel, rng) {
+			assert.True(t, coveringContains(cells, p), p)
+		}
+
+		// the covering doesn't extend far beyond the polygon
+		padded := geod.BoundFromOrb(poly.Bound()).Pad(units.Km(5))
+		for _, cell := range cells {
+			assert.True(t, padded.Contains(cell.LatLon()), cell.String())
+		}
+	}
+
+	// the hole is not covered at level 12, cells are around 2km
+	cells := utils.S2Covering(polys[1], 12, geod.RhumbModel)
+	assert.False(t, coveringContains(cells, orb.Point{180, -16.5}))
+	assert.False(t, coveringContains(cells, orb.Point{-179.8, -16.5}))
+
+	assert.Nil(t, utils.S2Covering(orb.Polygon{}, 12, geod.RhumbModel))
+}
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"math"
+	"math/rand"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+)
+
+// maxSampleAttempts limits the number of random points tried per point returned by SamplePoints
+const maxSampleAttempts = 10000
+
+// SamplePoints returns `n` random points inside the polygon, distributed uniformly by area on the sphere.
+// Points are generated within the bound of the polygon and kept if PolygonContains returns true with the given model,
+// so the same NOTE about densification applies. Polygons crossing the antimeridian should use 0..360 longitudes, as for
+// the containment functions, and the points are returned in the same range.
+//
+// Fewer points are returned if the polygon covers a very small part of its bound, such that on average more than
+// 10000 points need to be tried for each one inside.
+func SamplePoints(poly orb.Polygon, n int, model geod.EarthModel, rng *rand.Rand) []orb.Point {
+	if len(poly) == 0 || n <= 0 {
+		return nil
+	}
+
+	bounds := orb.PolygonBoundsFromPolygon(poly)
+	b := bounds[0]
+
+	// uniform in sin(latitude) gives uniform area on a sphere
+	zMin := math.Sin(geod.Degrees(b.Min[1]).Radians())
+	zMax := math.Sin(geod.Degrees(b.Max[1]).Radians())
+
+	points := make([]orb.Point, 0, n)
+	for attempts := 0; len(points) < n && attempts < n*maxSampleAttempts; attempts++ {
+		lat := geod.DegreesFromRadians(math.Asin(zMin + rng.Float64()*(zMax-zMin)))
+		lon := b.Min[0] + rng.Float64()*(b.Max[0]-b.Min[0])
+
+		p := orb.Point{lon, float64(lat)}
+		if PolygonWithBoundContains(poly, bounds, p, model) {
+			points = append(points, p)
+		}
+	}
+
+	return points
+}
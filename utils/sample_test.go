@@ -0,0 +1,47 @@
+package utils_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+)
+
+func TestSamplePoints(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+
+	// 0..60N, the northern half has sin(60)-sin(30) / sin(60) = 42% of the area
+	poly := orb.Polygon{{{0, 0}, {10, 0}, {10, 60}, {0, 60}, {0, 0}}}
+	points := utils.SamplePoints(poly, 10000, geod.RhumbModel, rng)
+	require.Len(t, points, 10000)
+
+	north := 0
+	for _, p := range points {
+		assert.True(t, utils.PolygonContains(poly, p, geod.RhumbModel))
+		if p[1] > 30 {
+			north++
+		}
+	}
+	expected := (math.Sin(math.Pi/3) - math.Sin(math.Pi/6)) / math.Sin(math.Pi/3)
+	assert.InDelta(t, expected, float64(north)/float64(len(points)), 0.02)
+
+	// with a hole, across the antimeridian
+	poly = orb.Polygon{
+		{{170, -10}, {190, -10}, {190, 10}, {170, 10}, {170, -10}},
+		{{175, -5}, {175, 5}, {185, 5}, {185, -5}, {175, -5}},
+	}
+	points = utils.SamplePoints(poly, 1000, geod.RhumbModel, rng)
+	require.Len(t, points, 1000)
+	for _, p := range points {
+		assert.True(t, utils.PolygonContains(poly, p, geod.RhumbModel))
+		assert.False(t, p[0] > 175 && p[0] < 185 && p[1] > -5 && p[1] < 5)
+	}
+
+	assert.Nil(t, utils.SamplePoints(orb.Polygon{}, 10, geod.RhumbModel, rng))
+}
@@ -0,0 +1,241 @@
+package utils
+
+import (
+	"math"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+// webMercatorRadius is the radius of the sphere used by the Web Mercator projection, in metres
+const webMercatorRadius = 6378137
+
+// webMercatorTileSize is the size of map tiles in pixels assumed by SimplifyForZoom
+const webMercatorTileSize = 256
+
+// Simplify simplifies the geometry using the Douglas-Peucker algorithm, with the distances of points from the
+// simplified lines measured on the ground according to `model`, so that the result is within `tolerance` of the
+// original everywhere. The shape of the segments is also defined by `model`.
+//
+// Unlike the planar simplifiers of orb, the geometry is not modified; a simplified copy is returned. As for orb,
+// holes that collapse to 2 points or less are removed, as are polygons whose outer ring collapses, and nil is
+// returned if nothing is left. Points and MultiPoints are returned unchanged.
+func Simplify(geom orb.Geometry, tolerance units.Distance, model geod.EarthModel) orb.Geometry {
+	s := simplifier{tolerance: float64(tolerance.Metre()), model: model}
+
+	return s.geometry(geom)
+}
+
+// SimplifyForZoom simplifies the geometry for rendering on a Web Mercator map with 256 pixel tiles at the zoom level,
+// such that the result is within `pixelTolerance` pixels of the original.
+//
+// The size of a pixel on the ground at the latitude of the centre of the geometry's bound is used to convert the
+// tolerance to a distance, and the geometry is simplified with Simplify using the rhumb line model, as straight lines
+// on a Mercator map are rhumb lines.
+func SimplifyForZoom(geom orb.Geometry, zoom int, pixelTolerance float64) orb.Geometry {
+	if geom == nil {
+		return nil
+	}
+
+	b := geom.Bound()
+	lat := geod.Degrees((b.Min[1] + b.Max[1]) / 2)
+	pixelSize := math.Cos(lat.Radians()) * 2 * math.Pi * webMercatorRadius / (webMercatorTileSize * math.Exp2(float64(zoom)))
+
+	return Simplify(geom, units.Metre(pixelTolerance*pixelSize), geod.RhumbModel)
+}
+
+// simplifier holds the parameters of Simplify
+type simplifier struct {
+	tolerance float64
+	model     geod.EarthModel
+}
+
+// geometry simplifies any geometry
+func (s simplifier) geometry(geom orb.Geometry) orb.Geometry {
+	switch g := geom.(type) {
+	case nil:
+		return nil
+	case orb.LineString:
+		ls := s.lineString(g)
+		if len(ls) == 0 {
+			return nil
+		}
+
+		return ls
+	case orb.MultiLineString:
+		mls := make(orb.MultiLineString, 0, len(g))
+		for _, ls := range g {
+			mls = append(mls, s.lineString(ls))
+		}
+		if len(mls) == 0 {
+			return nil
+		}
+
+		return mls
+	case orb.Ring:
+		r := orb.Ring(s.lineString(orb.LineString(g)))
+		if len(r) == 0 {
+			return nil
+		}
+
+		return r
+	case orb.Polygon:
+		p := s.polygon(g)
+		if len(p) == 0 {
+			return nil
+		}
+
+		return p
+	case orb.MultiPolygon:
+		mp := make(orb.MultiPolygon, 0, len(g))
+		for _, p := range g {
+			if sp := s.polygon(p); len(sp) > 0 {
+				mp = append(mp, sp)
+			}
+		}
+		if len(mp) == 0 {
+			return nil
+		}
+
+		return mp
+	case orb.Collection:
+		c := make(orb.Collection, 0, len(g))
+		for _, child := range g {
+			c = append(c, s.geometry(child))
+		}
+
+		return c
+	default:
+		// points and bounds
+		return geom
+	}
+}
+
+// polygon simplifies the rings of the polygon, returning nil if the outer ring collapses
+func (s simplifier) polygon(p orb.Polygon) orb.Polygon {
+	sp := make(orb.Polygon, 0, len(p))
+	for i, r := range p {
+		sr := orb.Ring(s.lineString(orb.LineString(r)))
+		if len(sr) <= 2 {
+			if i == 0 {
+				return nil
+			}
+
+			continue
+		}
+
+		sp = append(sp, sr)
+	}
+
+	return sp
+}
+
+// lineString returns a simplified copy of the line string
+func (s simplifier) lineString(ls orb.LineString) orb.LineString {
+	if len(ls) <= 2 {
+		return append(orb.LineString(nil), ls...)
+	}
+
+	keep := make([]bool, len(ls))
+	keep[0], keep[len(ls)-1] = true, true
+
+	stack := [][2]int{{0, len(ls) - 1}}
+	for len(stack) > 0 {
+		start, end := stack[len(stack)-1][0], stack[len(stack)-1][1]
+		stack = stack[:len(stack)-1]
+
+		maxDist, maxIndex := -1.0, -1
+		for i := start + 1; i < end; i++ {
+			_, d := NearestPointOnSegment(ls[i], ls[start], ls[end], s.model)
+			if float64(d.Metre()) > maxDist {
+				maxDist, maxIndex = float64(d.Metre()), i
+			}
+		}
+
+		if maxIndex >= 0 && maxDist > s.tolerance {
+			keep[maxIndex] = true
+			stack = append(stack, [2]int{start, maxIndex}, [2]int{maxIndex, end})
+		}
+	}
+
+	simplified := make(orb.LineString, 0, len(ls))
+	for i, p := range ls {
+		if keep[i] {
+			simplified = append(simplified, p)
+		}
+	}
+
+	return simplified
+}
+
+// SimplifyTrack simplifies the track using the Douglas-Peucker algorithm with the synchronized distance: the distance
+// of each fix from the position interpolated at the time of the fix along the simplified segment, according to
+// `model`. This includes both the distance across the segment and the error of the time along it, so positions
+// interpolated by time along the simplified track are within `tolerance` of the dropped fixes, as well as its shape.
+//
+// Segments between fixes with the same time use the distance from the segment instead, as Simplify does. The first
+// and last fixes are always kept, and the track is not modified; a simplified copy is returned.
+//
+// Example:
+// simplified := utils.SimplifyTrack(track, units.Metre(50), geod.SphericalModel)
+func SimplifyTrack(track geod.Track, tolerance units.Distance, model geod.EarthModel) geod.Track {
+	if len(track) <= 2 {
+		return append(geod.Track(nil), track...)
+	}
+
+	limit := float64(tolerance.Metre())
+	point := func(ll geod.LatLon) orb.Point {
+		return orb.Point{float64(ll.Longitude), float64(ll.Latitude)}
+	}
+
+	// the distance of fix i from the segment from fix `start` to fix `end`
+	deviation := func(i, start, end int) float64 {
+		p0, p1, p := track[start], track[end], track[i]
+
+		dt := p1.Time.Sub(p0.Time)
+		if dt <= 0 {
+			_, d := NearestPointOnSegment(point(p.LatLon), point(p0.LatLon), point(p1.LatLon), model)
+
+			return float64(d.Metre())
+		}
+
+		expected := p0.LatLon
+		if !p0.LatLon.Equals(p1.LatLon) {
+			f := float64(p.Time.Sub(p0.Time)) / float64(dt)
+			expected = model(p0.LatLon).IntermediatePointTo(p1.LatLon, f)
+		}
+
+		return pointDistance(point(p.LatLon), point(expected), model)
+	}
+
+	keep := make([]bool, len(track))
+	keep[0], keep[len(track)-1] = true, true
+
+	stack := [][2]int{{0, len(track) - 1}}
+	for len(stack) > 0 {
+		start, end := stack[len(stack)-1][0], stack[len(stack)-1][1]
+		stack = stack[:len(stack)-1]
+
+		maxDist, maxIndex := -1.0, -1
+		for i := start + 1; i < end; i++ {
+			if d := deviation(i, start, end); d > maxDist {
+				maxDist, maxIndex = d, i
+			}
+		}
+
+		if maxIndex >= 0 && maxDist > limit {
+			keep[maxIndex] = true
+			stack = append(stack, [2]int{start, maxIndex}, [2]int{maxIndex, end})
+		}
+	}
+
+	simplified := make(geod.Track, 0, len(track))
+	for i, p := range track {
+		if keep[i] {
+			simplified = append(simplified, p)
+		}
+	}
+
+	return simplified
+}
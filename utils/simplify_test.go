@@ -0,0 +1,96 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+func TestSimplify(t *testing.T) {
+	// a line along the equator with a 0.001° (~111m) wiggle and a 0.1° (~11km) bump
+	ls := orb.LineString{{0, 0}, {1, 0.001}, {2, 0}, {3, 0.1}, {4, 0}}
+
+	s := utils.Simplify(ls, units.Km(1), geod.SphericalModel)
+	assert.Equal(t, orb.LineString{{0, 0}, {2, 0}, {3, 0.1}, {4, 0}}, s)
+	s = utils.Simplify(ls, units.Metre(50), geod.SphericalModel)
+	assert.Equal(t, ls, s)
+	s = utils.Simplify(ls, units.Km(20), geod.SphericalModel)
+	assert.Equal(t, orb.LineString{{0, 0}, {4, 0}}, s)
+
+	// the input is not modified
+	assert.Equal(t, orb.LineString{{0, 0}, {1, 0.001}, {2, 0}, {3, 0.1}, {4, 0}}, ls)
+
+	// great circles bulge towards the pole compared to the straight line in degrees, rhumb lines don't
+	ls = orb.LineString{{0, 60}, {10, 60.3777}, {20, 60}}
+	assert.Len(t, utils.Simplify(ls, units.Km(1), geod.SphericalModel), 2)
+	assert.Len(t, utils.Simplify(ls, units.Km(1), geod.RhumbModel), 3)
+
+	// holes that collapse are removed
+	poly := orb.Polygon{
+		{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}},
+		{{0.5, 0.5}, {0.5001, 0.5}, {0.5001, 0.5001}, {0.5, 0.5001}, {0.5, 0.5}},
+	}
+	sp, ok := utils.Simplify(poly, units.Km(1), geod.RhumbModel).(orb.Polygon)
+	require.True(t, ok)
+	require.Len(t, sp, 1)
+	assert.Equal(t, poly[0], sp[0])
+
+	mp := orb.MultiPolygon{poly, {{{5, 5}, {5.0001, 5}, {5.0001, 5.0001}, {5, 5}}}}
+	smp, ok := utils.Simplify(mp, units.Km(1), geod.RhumbModel).(orb.MultiPolygon)
+	require.True(t, ok)
+	assert.Len(t, smp, 1)
+
+	assert.Nil(t, utils.Simplify(nil, units.Km(1), geod.RhumbModel))
+	assert.Equal(t, orb.Point{1, 2}, utils.Simplify(orb.Point{1, 2}, units.Km(1), geod.RhumbModel))
+}
+
+func TestSimplifyForZoom(t *testing.T) {
+	// ~111m wiggle, a pixel is ~9.8km at zoom 4 and ~19m at zoom 13 at 30°S
+	ls := orb.LineString{{170, -30}, {170.5, -30.001}, {171, -30}}
+
+	assert.Len(t, utils.SimplifyForZoom(ls, 4, 1), 2)
+	assert.Len(t, utils.SimplifyForZoom(ls, 13, 1), 3)
+	assert.Nil(t, utils.SimplifyForZoom(nil, 13, 1))
+}
+
+func TestSimplifyTrack(t *testing.T) {
+	// along the equator at 0.1° per minute, stopping for 10 minutes half way
+	t0 := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	var track geod.Track
+	for i := 0; i <= 30; i++ {
+		lon := 0.1 * float64(i)
+		switch {
+		case i > 20:
+			lon -= 1
+		case i > 10:
+			lon = 1
+		}
+		track = append(track, geod.Position{LatLon: geod.NewLatLon(0, lon), Time: t0.Add(time.Duration(i) * time.Minute)})
+	}
+
+	// the shape is a straight line, but the stop is kept
+	s := utils.SimplifyTrack(track, units.Metre(100), geod.SphericalModel)
+	assert.Equal(t, geod.Track{track[0], track[10], track[20], track[30]}, s)
+	assert.Len(t, track, 31)
+
+	// a wiggle within the tolerance is dropped
+	track[5].Latitude = 0.0001
+	assert.Equal(t, s, utils.SimplifyTrack(track, units.Metre(100), geod.SphericalModel))
+	assert.Equal(t, geod.Track{track[0], track[5], track[10], track[20], track[30]},
+		utils.SimplifyTrack(track, units.Metre(10), geod.SphericalModel))
+
+	// without times, only the shape counts
+	for i := range track {
+		track[i].Time = t0
+	}
+	assert.Equal(t, geod.Track{track[0], track[30]}, utils.SimplifyTrack(track, units.Metre(100), geod.SphericalModel))
+
+	assert.Equal(t, track[:2], utils.SimplifyTrack(track[:2], units.Metre(100), geod.SphericalModel))
+}
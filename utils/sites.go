@@ -0,0 +1,111 @@
+package utils
+
+import (
+	"math"
+	"sort"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/units"
+)
+
+// SiteClassifier assigns positions to the nearest of a set of sites (for example ports or stations), which is
+// equivalent to finding the Voronoi cell of the position.
+type SiteClassifier struct {
+	sites  []geod.LatLon
+	order  []int     // indexes of the sites sorted by latitude
+	lats   []float64 // latitudes in the same order
+	model  geod.EarthModel
+	degree float64
+}
+
+// NewSiteClassifier returns a SiteClassifier for the sites, with distances measured using the given model.
+func NewSiteClassifier(sites []geod.LatLon, model geod.EarthModel) *SiteClassifier {
+	c := &SiteClassifier{
+		sites:  sites,
+		order:  make([]int, len(sites)),
+		lats:   make([]float64, len(sites)),
+		model:  model,
+		degree: minDegreeLatitude(model),
+	}
+
+	for i := range c.order {
+		c.order[i] = i
+	}
+	sort.Slice(c.order, func(a, b int) bool { return sites[c.order[a]].Latitude < sites[c.order[b]].Latitude })
+
+	for k, i := range c.order {
+		c.lats[k] = float64(sites[i].Latitude)
+	}
+
+	return c
+}
+
+// NearestSite returns the index of the site nearest to `ll` and its distance.
+// Returns -1 and an invalid distance if there are no sites.
+//
+// Sites are checked outwards from the latitude of `ll` until the difference in latitude alone is more than the
+// distance to the nearest site found.
+func (c *SiteClassifier) NearestSite(ll geod.LatLon) (int, units.Distance) {
+	m := c.model(ll)
+	lat := float64(ll.Latitude)
+
+	best, bestD := -1, math.Inf(1)
+	check := func(k int) {
+		i := c.order[k]
+
+		d := float64(m.DistanceTo(c.sites[i]).Metre())
+
+		if d < bestD || (d == bestD && i < best) {
+			best, bestD = i, d
+		}
+	}
+
+	up := sort.SearchFloat64s(c.lats, lat)
+	down := up - 1
+	for up < len(c.lats) || down >= 0 {
+		// check the closer of the next sites north and south
+		if down < 0 || (up < len(c.lats) && c.lats[up]-lat <= lat-c.lats[down]) {
+			if (c.lats[up]-lat)*c.degree > bestD {
+				up = len(c.lats)
+				continue
+			}
+			check(up)
+			up++
+		} else {
+			if (lat-c.lats[down])*c.degree > bestD {
+				down = -1
+				continue
+			}
+			check(down)
+			down--
+		}
+	}
+
+	if best < 0 {
+		return -1, units.Metre(math.NaN())
+	}
+
+	return best, units.Metre(bestD)
+}
+
+// SitesWithin returns the indexes of the sites within `radius` of `ll`, in increasing order.
+//
+// Only the sites whose difference in latitude alone is not more than the radius are checked.
+func (c *SiteClassifier) SitesWithin(ll geod.LatLon, radius units.Distance) []int {
+	m := c.model(ll)
+	lat := float64(ll.Latitude)
+	r := float64(radius.Metre())
+
+	from := sort.SearchFloat64s(c.lats, lat-r/c.degree)
+
+	var within []int
+	for k := from; k < len(c.lats) && c.lats[k] <= lat+r/c.degree; k++ {
+		i := c.order[k]
+		if float64(m.DistanceTo(c.sites[i]).Metre()) <= r {
+			within = append(within, i)
+		}
+	}
+	sort.Ints(within)
+
+	return within
+}
@@ -0,0 +1,80 @@
+package utils_test
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/units"
+)
+
+func TestNearestSite(t *testing.T) {
+	sites := []geod.LatLon{
+		{Latitude: -36.8485, Longitude: 174.7633}, // Auckland
+		{Latitude: -33.8688, Longitude: 151.2093}, // Sydney
+		{Latitude: 21.3069, Longitude: -157.8583}, // Honolulu
+		{Latitude: -17.7134, Longitude: 178.0650}, // Fiji
+		{Latitude: 51.5072, Longitude: -0.1276},   // London
+	}
+
+	c := utils.NewSiteClassifier(sites, geod.SphericalModel)
+
+	i, d := c.NearestSite(geod.LatLon{Latitude: -41.2865, Longitude: 174.7762}) // Wellington
+	assert.Equal(t, 0, i)
+	assert.InDelta(t, 493.48, float64(d.Km()), 0.01)
+
+	i, _ = c.NearestSite(geod.LatLon{Latitude: -15, Longitude: -175}) // Samoa, across the antimeridian
+	assert.Equal(t, 3, i)
+
+	i, d = c.NearestSite(sites[4])
+	assert.Equal(t, 4, i)
+	assert.Equal(t, 0.0, float64(d.Metre()))
+
+	// compare with brute force
+	rng := rand.New(rand.NewSource(1))
+	for n := 0; n < 1000; n++ {
+		ll := geod.LatLon{Latitude: geod.Degrees(rng.Float64()*180 - 90), Longitude: geod.Degrees(rng.Float64()*360 - 180)}
+		best, bestD := -1, 0.0
+		for j, s := range sites {
+			if d := float64(geod.Distance(ll, s, geod.SphericalModel).Metre()); best < 0 || d < bestD {
+				best, bestD = j, d
+			}
+		}
+
+		i, d := c.NearestSite(ll)
+		assert.Equal(t, best, i)
+		assert.InDelta(t, bestD, float64(d.Metre()), 1e-6)
+	}
+
+	i, d = utils.NewSiteClassifier(nil, geod.SphericalModel).NearestSite(sites[0])
+	assert.Equal(t, -1, i)
+	assert.False(t, d.Valid())
+}
+
+func TestSitesWithin(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	sites := make([]geod.LatLon, 1000)
+	for i := range sites {
+		sites[i] = geod.LatLon{Latitude: geod.Degrees(rng.Float64()*20 - 50), Longitude: geod.Degrees(rng.Float64()*20 + 165)}
+	}
+
+	c := utils.NewSiteClassifier(sites, geod.VincentyModel)
+	for n := 0; n < 100; n++ {
+		ll := geod.LatLon{Latitude: geod.Degrees(rng.Float64()*20 - 50), Longitude: geod.Degrees(rng.Float64()*20 + 165)}
+
+		var expected []int
+		for i, s := range sites {
+			if geod.Distance(ll, s, geod.VincentyModel).Km() <= 200 {
+				expected = append(expected, i)
+			}
+		}
+
+		assert.Equal(t, expected, c.SitesWithin(ll, units.Km(200)))
+	}
+
+	assert.Equal(t, []int{3}, c.SitesWithin(sites[3], units.Metre(0)))
+	assert.Nil(t, utils.NewSiteClassifier(nil, geod.SphericalModel).SitesWithin(sites[0], units.Km(1)))
+}
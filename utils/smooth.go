@@ -0,0 +1,122 @@
+package utils
+
+import (
+	"math"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/units"
+)
+
+// maxSmoothPoints limits the number of points of the paths of SmoothPath
+const maxSmoothPoints = 1 << 20
+
+// SmoothPath returns a smooth path through the points, for example to draw a visually smooth track from sparse
+// fixes: the points, with points added between them at most `spacing` apart, measured with the model.
+//
+// The path is a Catmull-Rom spline on the sphere, built with spherical linear interpolation (slerp) of the unit
+// vectors of the points instead of straight lines, so that its direction changes continuously through each point,
+// near the poles and across the antimeridian too. The ends of the path continue the first and last segments. Unlike
+// the segments of tracks, the path between the points follows the spline rather than the paths of the model.
+//
+// The path has at most about 2²⁰ points, so the points can be further apart than tiny spacings. Returns a copy of the
+// points if there are less than 3, and ErrInvalidSpacing if the spacing is not positive.
+func SmoothPath(points []geod.LatLon, spacing units.Distance, model geod.EarthModel) ([]geod.LatLon, error) {
+	s := float64(spacing.Metre())
+	if !(s > 0) {
+		return nil, ErrInvalidSpacing
+	}
+
+	if len(points) < 3 {
+		return append([]geod.LatLon(nil), points...), nil
+	}
+
+	// the number of pieces of each segment, counted before allocating, and reduced in proportion if there are too
+	// many in total
+	pieces := make([]float64, len(points))
+	total := 0.0
+	for i := 1; i < len(points); i++ {
+		pieces[i] = math.Ceil(float64(model(points[i-1]).DistanceTo(points[i]).Metre()) / s)
+		total += pieces[i]
+	}
+	scale := math.Min(1, maxSmoothPoints/total)
+	budget := maxSmoothPoints
+	counts := make([]int, len(points))
+	for i := 1; i < len(points); i++ {
+		counts[i] = int(math.Max(1, math.Floor(pieces[i]*scale)))
+		budget -= counts[i]
+	}
+
+	vs := make([]geod.Vector3D, len(points)+2)
+	for i, p := range points {
+		vs[i+1] = p.ToNvector()
+	}
+	// phantom points continuing the first and last segments
+	vs[0] = slerp(vs[2], vs[1], 2)
+	vs[len(vs)-1] = slerp(vs[len(vs)-3], vs[len(vs)-2], 2)
+
+	path := make([]geod.LatLon, 1, maxSmoothPoints-budget+1)
+	path[0] = points[0]
+	for i := 1; i < len(points); i++ {
+		p0, p1, p2, p3 := vs[i-1], vs[i], vs[i+1], vs[i+2]
+
+		if points[i-1].Equals(points[i]) {
+			path = append(path, points[i])
+			continue
+		}
+
+		// the spline isn't parameterised by distance, so more points are added until they are close enough, as long
+		// as the total allows
+		n := counts[i]
+		for {
+			piece := make([]geod.LatLon, 0, n)
+			maxGap := 0.0
+			prev := points[i-1]
+			for k := 1; k <= n; k++ {
+				p := points[i]
+				if k < n {
+					p = geod.NvectorToLatLon(catmullRom(p0, p1, p2, p3, float64(k)/float64(n)))
+					piece = append(piece, p)
+				}
+				maxGap = math.Max(maxGap, float64(model(prev).DistanceTo(p).Metre()))
+				prev = p
+			}
+
+			if maxGap <= s || budget <= 0 {
+				path = append(path, piece...)
+				break
+			}
+
+			more := int(math.Min(float64(budget), math.Ceil(float64(n)*maxGap/s+1)-float64(n)))
+			n += more
+			budget -= more
+		}
+		path = append(path, points[i])
+	}
+
+	return path, nil
+}
+
+// catmullRom returns the point at `t` (0..1) between p1 and p2 of the uniform Catmull-Rom spline through p0, p1, p2
+// and p3, using the Barry-Goldman pyramidal formulation with slerp
+func catmullRom(p0, p1, p2, p3 geod.Vector3D, t float64) geod.Vector3D {
+	a1 := slerp(p0, p1, t+1)
+	a2 := slerp(p1, p2, t)
+	a3 := slerp(p2, p3, t-1)
+	b1 := slerp(a1, a2, (t+1)/2)
+	b2 := slerp(a2, a3, t/2)
+
+	return slerp(b1, b2, t)
+}
+
+// slerp returns the unit vector at fraction `f` of the angle from `a` to `b`, along the great circle through them.
+// `f` can be outside 0..1 to extrapolate.
+func slerp(a, b geod.Vector3D, f float64) geod.Vector3D {
+	ω := a.AngleTo(b, nil)
+	if ω < 1e-12 {
+		return a
+	}
+
+	sinω := math.Sin(ω)
+
+	return a.Times(math.Sin((1-f)*ω) / sinω).Plus(b.Times(math.Sin(f*ω) / sinω)).Unit()
+}
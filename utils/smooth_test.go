@@ -0,0 +1,64 @@
+package utils_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/units"
+)
+
+func TestSmoothPath(t *testing.T) {
+	// points along the equator stay on it
+	points := []geod.LatLon{geod.NewLatLon(0, 178), geod.NewLatLon(0, 179), geod.NewLatLon(0, -179), geod.NewLatLon(0, -178)}
+	path, err := utils.SmoothPath(points, units.Km(10), geod.SphericalModel)
+	require.NoError(t, err)
+	for i := 1; i < len(path); i++ {
+		assert.InDelta(t, 0, float64(path[i].Latitude), 1e-9)
+		assert.LessOrEqual(t, float64(path[i-1].DistanceTo(path[i], geod.SphericalModel).Km()), 10.0)
+	}
+
+	// a zig-zag, through all the points with no sharp turns
+	points = []geod.LatLon{
+		geod.NewLatLon(-41, 174), geod.NewLatLon(-40.5, 174.5), geod.NewLatLon(-41, 175), geod.NewLatLon(-40.5, 175.5),
+	}
+	path, err = utils.SmoothPath(points, units.Km(1), geod.RhumbModel)
+	require.NoError(t, err)
+
+	k := 0
+	for i, p := range path {
+		if k < len(points) && p == points[k] {
+			k++
+			if i > 0 && i < len(path)-1 {
+				in := geod.InitialBearing(path[i-1], p, geod.SphericalModel)
+				out := geod.InitialBearing(p, path[i+1], geod.SphericalModel)
+				assert.Less(t, math.Abs(float64(geod.Wrap180(out-in))), 10.0)
+			}
+		}
+	}
+	assert.Equal(t, len(points), k)
+
+	path, err = utils.SmoothPath(points[:2], units.Km(1), geod.RhumbModel)
+	require.NoError(t, err)
+	assert.Equal(t, points[:2], path)
+
+	_, err = utils.SmoothPath(points, units.Metre(0), geod.RhumbModel)
+	assert.ErrorIs(t, err, utils.ErrInvalidSpacing)
+
+	// a tiny spacing is limited to 2²⁰ points in total, shared between the segments by length
+	points = []geod.LatLon{geod.NewLatLon(0, 0), geod.NewLatLon(0, 10), geod.NewLatLon(0, 10.001)}
+	path, err = utils.SmoothPath(points, units.Metre(1e-6), geod.SphericalModel)
+	require.NoError(t, err)
+	assert.LessOrEqual(t, len(path), 1<<20+1)
+	assert.Greater(t, len(path), 1<<19)
+	assert.Contains(t, path, points[1])
+	for i, p := range path {
+		if p == points[1] {
+			assert.InDelta(t, 10000, float64(i)/float64(len(path)-1-i), 100)
+		}
+	}
+}
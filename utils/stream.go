@@ -0,0 +1,104 @@
+package utils
+
+import (
+	"time"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+// TrackPoint is a position of a track and the time it was reported.
+type TrackPoint struct {
+	LatLon geod.LatLon
+	Time   time.Time
+}
+
+// StreamSimplifier simplifies a track one position at a time, for tracks that are too long, or never end, to be
+// simplified with Simplify. It keeps the last position it emitted and the positions after it, up to the size of the
+// window: a position is dropped if all the positions between the last emitted one and the latest are within the
+// tolerance of the line between them (the cross-track distance), otherwise the position before the latest is
+// emitted. When the window is full its last position is emitted regardless, so the memory used is bounded.
+//
+// The result is within the tolerance of the original track, but it keeps more points than Simplify would, as it
+// can't look ahead. The shape of the segments and the distances are defined by the model.
+type StreamSimplifier struct {
+	tolerance float64
+	window    int
+	model     geod.EarthModel
+	started   bool
+	anchor    TrackPoint
+	pending   []TrackPoint
+}
+
+// NewStreamSimplifier returns a StreamSimplifier with the given tolerance that keeps at most `window` positions
+// (at least 1) that haven't been emitted.
+func NewStreamSimplifier(tolerance units.Distance, window int, model geod.EarthModel) *StreamSimplifier {
+	if window < 1 {
+		window = 1
+	}
+
+	return &StreamSimplifier{
+		tolerance: float64(tolerance.Metre()),
+		window:    window,
+		model:     model,
+		pending:   make([]TrackPoint, 0, window),
+	}
+}
+
+// Push adds the next position of the track, and returns the positions of the simplified track that are now final,
+// if any, in order. The first position of the track is always returned immediately.
+func (s *StreamSimplifier) Push(ll geod.LatLon, t time.Time) []TrackPoint {
+	tp := TrackPoint{LatLon: ll, Time: t}
+	if !s.started {
+		s.started = true
+		s.anchor = tp
+
+		return []TrackPoint{tp}
+	}
+
+	var emitted []TrackPoint
+	if len(s.pending) > 0 && !s.fits(tp) {
+		emitted = append(emitted, s.emitLast())
+	}
+
+	s.pending = append(s.pending, tp)
+	if len(s.pending) == s.window {
+		emitted = append(emitted, s.emitLast())
+	}
+
+	return emitted
+}
+
+// Flush returns the last position pushed if it hasn't been emitted yet, ending the current segment of the
+// simplified track. Positions pushed after Flush continue the track from that position.
+func (s *StreamSimplifier) Flush() []TrackPoint {
+	if len(s.pending) == 0 {
+		return nil
+	}
+
+	return []TrackPoint{s.emitLast()}
+}
+
+// emitLast makes the last pending position the anchor, discarding the others, and returns it
+func (s *StreamSimplifier) emitLast() TrackPoint {
+	s.anchor = s.pending[len(s.pending)-1]
+	s.pending = s.pending[:0]
+
+	return s.anchor
+}
+
+// fits returns true if all the pending positions are within the tolerance of the segment from the anchor to `tp`
+func (s *StreamSimplifier) fits(tp TrackPoint) bool {
+	p0 := orb.Point{float64(s.anchor.LatLon.Longitude), float64(s.anchor.LatLon.Latitude)}
+	p1 := orb.Point{float64(tp.LatLon.Longitude), float64(tp.LatLon.Latitude)}
+
+	for _, p := range s.pending {
+		point := orb.Point{float64(p.LatLon.Longitude), float64(p.LatLon.Latitude)}
+		if _, d := NearestPointOnSegment(point, p0, p1, s.model); float64(d.Metre()) > s.tolerance {
+			return false
+		}
+	}
+
+	return true
+}
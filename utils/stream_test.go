@@ -0,0 +1,54 @@
+package utils_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/units"
+)
+
+func TestStreamSimplifier(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := utils.NewStreamSimplifier(units.Metre(100), 100, geod.SphericalModel)
+
+	var out []utils.TrackPoint
+	push := func(lat, lon float64, minutes int) {
+		out = append(out, s.Push(geod.NewLatLon(lat, lon), start.Add(time.Duration(minutes)*time.Minute))...)
+	}
+
+	// along the equator, then north
+	for i := 0; i <= 10; i++ {
+		push(0, float64(i)*0.01, i)
+	}
+	for i := 1; i <= 10; i++ {
+		push(float64(i)*0.01, 0.1, 10+i)
+	}
+
+	// the corner is emitted once the track turns
+	assert.Len(t, out, 2)
+	assert.Equal(t, geod.NewLatLon(0, 0), out[0].LatLon)
+	assert.Equal(t, start, out[0].Time)
+	assert.InDelta(t, 0.1, float64(out[1].LatLon.Longitude), 1e-9)
+	assert.InDelta(t, 0, float64(out[1].LatLon.Latitude), 1e-9)
+	assert.Equal(t, start.Add(10*time.Minute), out[1].Time)
+
+	out = append(out, s.Flush()...)
+	assert.Len(t, out, 3)
+	assert.InDelta(t, 0.1, float64(out[2].LatLon.Latitude), 1e-9)
+	assert.Equal(t, start.Add(20*time.Minute), out[2].Time)
+	assert.Empty(t, s.Flush())
+
+	// a full window is emitted
+	s = utils.NewStreamSimplifier(units.Metre(100), 3, geod.SphericalModel)
+	out = nil
+	for i := 0; i <= 6; i++ {
+		push(0, float64(i)*0.01, i)
+	}
+	assert.Len(t, out, 3)
+	assert.Equal(t, start.Add(3*time.Minute), out[1].Time)
+	assert.Equal(t, start.Add(6*time.Minute), out[2].Time)
+}
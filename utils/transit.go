@@ -0,0 +1,91 @@
+package utils
+
+import (
+	"sort"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/orb"
+	"github.com/starboard-nz/units"
+)
+
+// Transit is a part of a track inside a zone.
+type Transit struct {
+	// Track is the part of the track inside the zone, from the entry point to the exit point
+	Track orb.LineString
+	// Polygon is the index of the polygon of the zone the track is in
+	Polygon int
+	// Entry is where the track enters the polygon, nil if the track starts inside
+	Entry *ZoneCrossing
+	// Exit is where the track leaves the polygon, nil if the track ends inside
+	Exit *ZoneCrossing
+	// Start and End are the distances along the track, from its first point, of the start and end of the transit
+	Start, End units.Distance
+}
+
+// Length returns the distance along the track inside the zone.
+func (t Transit) Length() units.Distance {
+	return units.Metre(t.End.Metre() - t.Start.Metre())
+}
+
+// TransitSegments returns the transits of the track through the zone: the parts of the track inside each polygon of
+// the zone, with the points where the track enters and leaves the polygon and the distances along the track, measured
+// with the model, of the start and end of each part, in order along the track.
+//
+// The parts and crossings are found with ClipLineToPolygon, so the same notes apply. The polygons of the zone
+// shouldn't overlap, otherwise the transits through them overlap too.
+//
+// Example:
+//
+//	for _, transit := range utils.TransitSegments(track, zone, geod.SphericalModel) {
+//		fmt.Printf("%.1fkm in polygon %d\n", transit.Length().Km(), transit.Polygon)
+//	}
+func TransitSegments(track orb.LineString, zone orb.MultiPolygon, model geod.EarthModel) []Transit {
+	if len(track) == 0 || len(zone) == 0 {
+		return nil
+	}
+
+	// distances along the track of its points
+	along := make([]float64, len(track))
+	for i := 1; i < len(track); i++ {
+		along[i] = along[i-1] + pointDistance(track[i-1], track[i], model)
+	}
+
+	crossingDistance := func(c *ZoneCrossing) float64 {
+		return along[c.Segment] + c.Fraction*(along[c.Segment+1]-along[c.Segment])
+	}
+
+	var transits []Transit
+
+	for p, poly := range zone {
+		parts, crossings := ClipLineToPolygon(track, poly, model)
+		if len(parts) == 0 {
+			continue
+		}
+
+		// crossings alternate between entering and leaving, starting with leaving if the track starts inside
+		next := 0
+		for k, part := range parts {
+			transit := Transit{Track: part, Polygon: p, Start: units.Metre(0)}
+
+			if k > 0 || next < len(crossings) && crossings[next].Entering {
+				transit.Entry = &crossings[next]
+				transit.Start = units.Metre(crossingDistance(transit.Entry))
+				next++
+			}
+
+			if next < len(crossings) {
+				transit.Exit = &crossings[next]
+				transit.End = units.Metre(crossingDistance(transit.Exit))
+				next++
+			} else {
+				transit.End = units.Metre(along[len(along)-1])
+			}
+
+			transits = append(transits, transit)
+		}
+	}
+
+	sort.SliceStable(transits, func(i, j int) bool { return transits[i].Start.Metre() < transits[j].Start.Metre() })
+
+	return transits
+}
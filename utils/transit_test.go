@@ -0,0 +1,66 @@
+package utils_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+	"github.com/starboard-nz/orb"
+)
+
+func TestTransitSegments(t *testing.T) {
+	zone := orb.MultiPolygon{
+		{{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}},
+		{{{2, 0}, {3, 0}, {3, 1}, {2, 1}, {2, 0}}},
+	}
+
+	// through both polygons, ending inside the second
+	track := orb.LineString{{-1, 0.5}, {2.5, 0.5}}
+	degree := float64(geod.Distance(geod.NewLatLon(0.5, 0), geod.NewLatLon(0.5, 1), geod.RhumbModel).Metre())
+
+	transits := utils.TransitSegments(track, zone, geod.RhumbModel)
+	require.Len(t, transits, 2)
+
+	assert.Equal(t, 0, transits[0].Polygon)
+	require.NotNil(t, transits[0].Entry)
+	require.NotNil(t, transits[0].Exit)
+	assert.True(t, transits[0].Entry.Entering)
+	assert.False(t, transits[0].Exit.Entering)
+	assert.InDelta(t, 1*degree, float64(transits[0].Start.Metre()), 1)
+	assert.InDelta(t, 2*degree, float64(transits[0].End.Metre()), 1)
+	assert.InDelta(t, degree, float64(transits[0].Length().Metre()), 1)
+	assert.InDelta(t, 0, transits[0].Track[0][0], 1e-9)
+	assert.InDelta(t, 1, transits[0].Track[len(transits[0].Track)-1][0], 1e-9)
+
+	assert.Equal(t, 1, transits[1].Polygon)
+	require.NotNil(t, transits[1].Entry)
+	assert.Nil(t, transits[1].Exit)
+	assert.InDelta(t, 3*degree, float64(transits[1].Start.Metre()), 1)
+	assert.InDelta(t, 3.5*degree, float64(transits[1].End.Metre()), 1)
+
+	// starting inside, reversed: the transits are in order along the track
+	transits = utils.TransitSegments(orb.LineString{{2.5, 0.5}, {-1, 0.5}}, zone, geod.RhumbModel)
+	require.Len(t, transits, 2)
+	assert.Equal(t, 1, transits[0].Polygon)
+	assert.Nil(t, transits[0].Entry)
+	require.NotNil(t, transits[0].Exit)
+	assert.InDelta(t, 0, float64(transits[0].Start.Metre()), 1e-9)
+	assert.InDelta(t, 0.5*degree, float64(transits[0].End.Metre()), 1)
+	assert.Equal(t, 0, transits[1].Polygon)
+
+	// leaving and coming back into the same polygon
+	transits = utils.TransitSegments(orb.LineString{{0.5, 0.5}, {1.5, 0.5}, {1.5, 0.8}, {0.5, 0.8}}, zone, geod.RhumbModel)
+	require.Len(t, transits, 2)
+	assert.Nil(t, transits[0].Entry)
+	assert.NotNil(t, transits[0].Exit)
+	assert.NotNil(t, transits[1].Entry)
+	assert.Nil(t, transits[1].Exit)
+	assert.Equal(t, 2, transits[1].Entry.Segment)
+
+	// outside
+	assert.Empty(t, utils.TransitSegments(orb.LineString{{5, 5}, {6, 6}}, zone, geod.RhumbModel))
+	assert.Nil(t, utils.TransitSegments(nil, zone, geod.RhumbModel))
+}
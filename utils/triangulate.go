@@ -0,0 +1,162 @@
+package utils
+
+import (
+	"math"
+
+	geod "github.com/starboard-nz/go-geodesy"
+)
+
+// Triangle holds the indexes of the 3 vertices of a triangle, in anticlockwise order seen from above (outside the
+// Earth).
+type Triangle [3]int
+
+// hullEpsilon is the distance (on a unit sphere) under which a point is considered to be on a plane
+const hullEpsilon = 1e-12
+
+type hullFace struct {
+	v      Triangle
+	normal geod.Vector3D
+	offset float64 // normal · v[0]
+}
+
+func newHullFace(vs []geod.Vector3D, a, b, c int) hullFace {
+	n := vs[b].Minus(vs[a]).Cross(vs[c].Minus(vs[a])).Unit()
+
+	return hullFace{v: Triangle{a, b, c}, normal: n, offset: n.Dot(vs[a])}
+}
+
+// distance returns the signed distance of `p` from the plane of the face, positive outside
+func (f hullFace) distance(p geod.Vector3D) float64 {
+	return f.normal.Dot(p) - f.offset
+}
+
+// TriangulateOnSphere returns the spherical Delaunay triangulation of the points, found as the convex hull of the
+// points on a unit sphere. The triangles' circumcircles contain no other points.
+//
+// Duplicate points are only used once, and triangles whose circumcircle is larger than a hemisphere, which cover the
+// area outside points that only span part of the globe, are left out.
+// Returns nil if there are fewer than 3 points or all the points are on one circle.
+func TriangulateOnSphere(points []geod.LatLon) []Triangle {
+	vs := make([]geod.Vector3D, len(points))
+	for i, ll := range points {
+		vs[i] = ll.ToNvector()
+	}
+
+	tetra, ok := initialTetrahedron(vs)
+	if !ok {
+		if len(points) == 3 {
+			return singleTriangle(vs)
+		}
+
+		return nil
+	}
+
+	// a point inside the hull, to orient the faces
+	inside := vs[tetra[0]].Plus(vs[tetra[1]]).Plus(vs[tetra[2]]).Plus(vs[tetra[3]]).DividedBy(4)
+
+	faces := make([]hullFace, 0, 4)
+	for _, t := range [][3]int{{0, 1, 2}, {0, 2, 3}, {0, 3, 1}, {1, 3, 2}} {
+		f := newHullFace(vs, tetra[t[0]], tetra[t[1]], tetra[t[2]])
+		if f.distance(inside) > 0 {
+			f = newHullFace(vs, tetra[t[0]], tetra[t[2]], tetra[t[1]])
+		}
+		faces = append(faces, f)
+	}
+
+	used := map[int]bool{tetra[0]: true, tetra[1]: true, tetra[2]: true, tetra[3]: true}
+	for i, p := range vs {
+		if used[i] {
+			continue
+		}
+
+		// directed edges of the faces visible from the point
+		visible := make(map[[2]int]bool)
+		kept := faces[:0:0]
+		for _, f := range faces {
+			if f.distance(p) > hullEpsilon {
+				visible[[2]int{f.v[0], f.v[1]}] = true
+				visible[[2]int{f.v[1], f.v[2]}] = true
+				visible[[2]int{f.v[2], f.v[0]}] = true
+			} else {
+				kept = append(kept, f)
+			}
+		}
+
+		if len(visible) == 0 {
+			// inside the hull, or a duplicate
+			continue
+		}
+
+		// the horizon is formed by edges between visible and hidden faces
+		for e := range visible {
+			if !visible[[2]int{e[1], e[0]}] {
+				kept = append(kept, newHullFace(vs, e[0], e[1], i))
+			}
+		}
+
+		faces = kept
+	}
+
+	triangles := make([]Triangle, 0, len(faces))
+	for _, f := range faces {
+		// leave out faces with the centre of the sphere on the outside
+		if f.offset > hullEpsilon {
+			triangles = append(triangles, f.v)
+		}
+	}
+
+	return triangles
+}
+
+// initialTetrahedron finds 4 points that are not on one plane, returns false if there aren't any.
+func initialTetrahedron(vs []geod.Vector3D) ([4]int, bool) {
+	var t [4]int
+	if len(vs) < 4 {
+		return t, false
+	}
+
+	farthest := func(dist func(geod.Vector3D) float64) (int, float64) {
+		best, bestD := -1, 0.0
+		for i, v := range vs {
+			if d := dist(v); d > bestD {
+				best, bestD = i, d
+			}
+		}
+
+		return best, bestD
+	}
+
+	var d float64
+	t[1], d = farthest(func(v geod.Vector3D) float64 { return v.Minus(vs[0]).Length() })
+	if d < hullEpsilon {
+		return t, false
+	}
+
+	line := vs[t[1]].Minus(vs[0]).Unit()
+	t[2], d = farthest(func(v geod.Vector3D) float64 { return v.Minus(vs[0]).Cross(line).Length() })
+	if d < hullEpsilon {
+		return t, false
+	}
+
+	n := vs[t[1]].Minus(vs[0]).Cross(vs[t[2]].Minus(vs[0])).Unit()
+	t[3], d = farthest(func(v geod.Vector3D) float64 { return math.Abs(v.Minus(vs[0]).Dot(n)) })
+	if d < hullEpsilon {
+		return t, false
+	}
+
+	return t, true
+}
+
+// singleTriangle returns the triangle of 3 points, anticlockwise, or nil if the points are on a great circle
+func singleTriangle(vs []geod.Vector3D) []Triangle {
+	f := newHullFace(vs, 0, 1, 2)
+	if math.IsNaN(f.offset) || math.Abs(f.offset) <= hullEpsilon {
+		return nil
+	}
+
+	if f.offset < 0 {
+		return []Triangle{{0, 2, 1}}
+	}
+
+	return []Triangle{{0, 1, 2}}
+}
@@ -0,0 +1,80 @@
+package utils_test
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	geod "github.com/starboard-nz/go-geodesy"
+	"github.com/starboard-nz/go-geodesy/utils"
+)
+
+func toVector(ll geod.LatLon) geod.Vector3D {
+	φ := ll.Latitude.Radians()
+	λ := ll.Longitude.Radians()
+
+	return geod.Vector3D{X: math.Cos(φ) * math.Cos(λ), Y: math.Cos(φ) * math.Sin(λ), Z: math.Sin(φ)}
+}
+
+// checkDelaunay checks that the triangles are anticlockwise and no point is inside the circumcircle of a triangle
+func checkDelaunay(t *testing.T, points []geod.LatLon, triangles []utils.Triangle) {
+	for _, tr := range triangles {
+		a, b, c := toVector(points[tr[0]]), toVector(points[tr[1]]), toVector(points[tr[2]])
+		n := b.Minus(a).Cross(c.Minus(a)).Unit()
+		require.Greater(t, n.Dot(a), 0.0, "triangle %v is clockwise", tr)
+
+		for _, p := range points {
+			assert.LessOrEqual(t, n.Dot(toVector(p))-n.Dot(a), 1e-9, "point inside circumcircle of %v", tr)
+		}
+	}
+}
+
+func TestTriangulateOnSphere(t *testing.T) {
+	octahedron := []geod.LatLon{
+		{Latitude: 90}, {Latitude: -90},
+		{Longitude: 0}, {Longitude: 90}, {Longitude: 180}, {Longitude: -90},
+	}
+	triangles := utils.TriangulateOnSphere(octahedron)
+	assert.Len(t, triangles, 8)
+	checkDelaunay(t, octahedron, triangles)
+
+	rng := rand.New(rand.NewSource(1))
+
+	// uniformly distributed over the globe, n points form 2n-4 triangles
+	points := make([]geod.LatLon, 200)
+	for i := range points {
+		points[i] = geod.LatLon{
+			Latitude:  geod.DegreesFromRadians(math.Asin(rng.Float64()*2 - 1)),
+			Longitude: geod.Degrees(rng.Float64()*360 - 180),
+		}
+	}
+	triangles = utils.TriangulateOnSphere(points)
+	assert.Len(t, triangles, 2*len(points)-4)
+	checkDelaunay(t, points, triangles)
+
+	// duplicates are ignored
+	triangles = utils.TriangulateOnSphere(append(points, points[:10]...))
+	assert.Len(t, triangles, 2*len(points)-4)
+
+	// a regional set across the antimeridian
+	points = points[:0]
+	for i := 0; i < 100; i++ {
+		points = append(points, geod.LatLon{
+			Latitude:  geod.Degrees(rng.Float64()*10 - 45),
+			Longitude: geod.Wrap180(geod.Degrees(rng.Float64()*10 + 175)),
+		})
+	}
+	triangles = utils.TriangulateOnSphere(points)
+	assert.NotEmpty(t, triangles)
+	checkDelaunay(t, points, triangles)
+
+	triangles = utils.TriangulateOnSphere(points[:3])
+	assert.Len(t, triangles, 1)
+	checkDelaunay(t, points[:3], triangles)
+
+	assert.Nil(t, utils.TriangulateOnSphere(points[:2]))
+	assert.Nil(t, utils.TriangulateOnSphere([]geod.LatLon{{Longitude: 0}, {Longitude: 10}, {Longitude: 20}, {Longitude: 30}}))
+}
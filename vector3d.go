@@ -145,36 +145,7 @@ func (v Vector3D) AngleTo(other Vector3D, n *Vector3D) float64 {
 // `axis` - The axis being rotated around.
 // `angle` - The angle of rotation (in degrees)
 //
-// Returns the rotated vector
+// Returns the rotated vector, normalised to unit length. See Quaternion for composing rotations.
 func (v Vector3D) RotateAround(axis Vector3D, angle Degrees) Vector3D {
-	θ := angle.Radians()
-
-	// en.wikipedia.org/wiki/Rotation_matrix#Rotation_matrix_from_axis_and_angle
-	// en.wikipedia.org/wiki/Quaternions_and_spatial_rotation#Quaternion-derived_rotation_matrix
-	p := v.Unit()
-	a := axis.Unit()
-
-	s := math.Sin(θ)
-	c := math.Cos(θ)
-	t := 1 - c
-	x := a.X
-	y := a.Y
-	z := a.Z
-
-	// rotation matrix for rotation about supplied axis
-	r := [][]float64{
-		{t*x*x + c, t*x*y - s*z, t*x*z + s*y},
-		{t*x*y + s*z, t*y*y + c, t*y*z - s*x},
-		{t*x*z - s*y, t*y*z + s*x, t*z*z + c},
-	}
-
-	// multiply r × p
-	rp := []float64{
-		r[0][0]*p.X + r[0][1]*p.Y + r[0][2]*p.Z,
-		r[1][0]*p.X + r[1][1]*p.Y + r[1][2]*p.Z,
-		r[2][0]*p.X + r[2][1]*p.Y + r[2][2]*p.Z,
-	}
-
-	return Vector3D{rp[0], rp[1], rp[2]}
-	// qv en.wikipedia.org/wiki/Rodrigues'_rotation_formula...
+	return QuaternionFromAxisAngle(axis, angle).Rotate(v.Unit())
 }
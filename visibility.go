@@ -0,0 +1,85 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"math"
+
+	"github.com/starboard-nz/units"
+)
+
+// Coefficients of refraction for the visibility functions. Use 0 to ignore refraction (the geometric horizon).
+const (
+	// StandardRefraction is the usual coefficient of refraction for visible light in the standard atmosphere
+	StandardRefraction = 0.13
+	// RadarRefraction is the usual coefficient of refraction for radar, equivalent to the "4/3 Earth" radius
+	RadarRefraction = 0.25
+)
+
+// effectiveEarthRadius returns the radius of the Earth that makes rays bent by refraction with coefficient
+// `refractionK` straight, i.e. R / (1 - k)
+func effectiveEarthRadius(refractionK float64) float64 {
+	return earthRadius / (1 - refractionK)
+}
+
+// LineOfSight returns true if `p2` can be seen from `p1` (and vice versa), i.e. if the line between the points does
+// not pass below the surface of the Earth, given their heights above the surface in metres. Terrain is ignored.
+//
+// Rays are bent towards the Earth by atmospheric refraction, which is modelled by increasing the radius of the Earth
+// to R / (1 - k), where `refractionK` is the coefficient of refraction: 0 for straight lines, StandardRefraction for
+// visible light or RadarRefraction for radar. The spherical Earth radius is used (see SetEarthRadius).
+//
+// Points on the surface (or below it) can't see any other points on the surface.
+//
+// Example:
+// mast := geod.NewLatLonEllipsodial(-41.29, 174.78, 50)
+// ship := geod.NewLatLonEllipsodial(-41.10, 174.20, 20)
+// visible := geod.LineOfSight(mast, ship, geod.StandardRefraction)
+func LineOfSight(p1, p2 LatLonEllipsoidal, refractionK float64) bool {
+	r := effectiveEarthRadius(refractionK)
+
+	// the angle between the points on the larger Earth
+	θ := float64(Distance(p1.LatLon, p2.LatLon, SphericalModel).Metre()) / r
+
+	// the points on the plane through the centre of the Earth and the points
+	ax, ay := r+p1.Height, 0.0
+	bx, by := (r+p2.Height)*math.Cos(θ), (r+p2.Height)*math.Sin(θ)
+
+	// the point on the line nearest the centre
+	dx, dy := bx-ax, by-ay
+	t := 0.0
+	if l2 := dx*dx + dy*dy; l2 > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/l2))
+	}
+
+	return math.Hypot(ax+t*dx, ay+t*dy) >= r
+}
+
+// MaxVisibleRange returns the maximum distance along the surface of the Earth at which a target at `targetHeight` can
+// be seen from an observer at `observerHeight`, above the surface, i.e. the sum of the HorizonDistance from both
+// heights. Refraction is modelled as in LineOfSight. Negative heights are treated as 0.
+//
+// Example:
+// r := geod.MaxVisibleRange(units.Metre(50), units.Metre(20), geod.StandardRefraction) // about 44km
+func MaxVisibleRange(observerHeight, targetHeight units.Distance, refractionK float64) units.Distance {
+	d1 := HorizonDistance(observerHeight, refractionK).Metre()
+	d2 := HorizonDistance(targetHeight, refractionK).Metre()
+
+	return units.Metre(d1 + d2)
+}
+
+// HorizonDistance returns the distance along the surface of the Earth to the horizon seen from `height` above the
+// surface: the geometric horizon if `refractionK` is 0, the visual horizon with StandardRefraction, or the radar
+// horizon with RadarRefraction. Refraction is modelled as in LineOfSight. Returns 0 for negative heights.
+//
+// Example:
+// d := geod.HorizonDistance(units.Metre(2), geod.StandardRefraction)  // about 5.4km, for an observer on the beach
+func HorizonDistance(height units.Distance, refractionK float64) units.Distance {
+	r := effectiveEarthRadius(refractionK)
+
+	return units.Metre(r * math.Acos(r/(r+math.Max(0, float64(height.Metre())))))
+}
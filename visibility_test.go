@@ -0,0 +1,47 @@
+package geod
+
+import (
+	"math"
+	"testing"
+
+	"github.com/starboard-nz/units"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMaxVisibleRange(t *testing.T) {
+	// the usual approximation √(2⋅R⋅h)
+	assert.InDelta(t, math.Sqrt(2*earthRadius*100), float64(MaxVisibleRange(units.Metre(100), units.Metre(0), 0).Metre()), 1)
+	assert.InDelta(t, math.Sqrt(2*earthRadius/(1-StandardRefraction)*100),
+		float64(MaxVisibleRange(units.Metre(0), units.Metre(100), StandardRefraction).Metre()), 1)
+	assert.InDelta(t, 44200, float64(MaxVisibleRange(units.Metre(50), units.Metre(20), StandardRefraction).Metre()), 100)
+	assert.Equal(t, 0.0, float64(MaxVisibleRange(units.Metre(-1), units.Metre(0), 0).Metre()))
+}
+
+func TestLineOfSight(t *testing.T) {
+	for _, k := range []float64{0, StandardRefraction} {
+		maxRange := float64(MaxVisibleRange(units.Metre(50), units.Metre(20), k).Metre())
+
+		mast := NewLatLonEllipsodial(-41.29, 174.78, 50)
+		m := LatLonSpherical{ll: mast.LatLon}
+		near := m.DestinationPoint(maxRange-100, 250)
+		far := m.DestinationPoint(maxRange+100, 250)
+
+		assert.True(t, LineOfSight(mast, NewLatLonEllipsodial(near.Latitude, near.Longitude, 20), k))
+		assert.True(t, LineOfSight(NewLatLonEllipsodial(near.Latitude, near.Longitude, 20), mast, k))
+		assert.False(t, LineOfSight(mast, NewLatLonEllipsodial(far.Latitude, far.Longitude, 20), k))
+	}
+
+	p := NewLatLonEllipsodial(-41.29, 174.78, 0)
+	assert.True(t, LineOfSight(p, p, 0))
+	assert.True(t, LineOfSight(p, NewLatLonEllipsodial(-41.29, 174.78, 1000), 0))
+	assert.False(t, LineOfSight(p, NewLatLonEllipsodial(-41.2901, 174.78, 0), 0))
+	assert.True(t, LineOfSight(p, NewLatLonEllipsodial(-41.2901, 174.78, 1), 0))
+}
+
+func TestHorizonDistance(t *testing.T) {
+	assert.InDelta(t, 5050, float64(HorizonDistance(units.Metre(2), 0).Metre()), 10)
+	assert.InDelta(t, 5410, float64(HorizonDistance(units.Metre(2), StandardRefraction).Metre()), 10)
+	// radar horizon, the usual approximation 4.12⋅√h km
+	assert.InDelta(t, 4120*math.Sqrt(100), float64(HorizonDistance(units.Metre(100), RadarRefraction).Metre()), 20)
+	assert.Equal(t, 0.0, float64(HorizonDistance(units.Metre(-10), RadarRefraction).Metre()))
+}
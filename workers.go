@@ -0,0 +1,78 @@
+package geod
+
+/**
+ * Copyright (c) 2026, Xerra Earth Observation Institute
+ * All rights reserved. Use is subject to License terms.
+ * See LICENSE in the root directory of this source tree.
+ */
+
+import (
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// maxWorkers is the number of goroutines used by parallel operations, 0 for the default
+var maxWorkers atomic.Int64
+
+// SetMaxWorkers can be used to [globally] limit the number of goroutines used by each parallel operation of the
+// package and of utils, for example IntermediatePointsTo, DensifyMultiPolygon and the containment functions with
+// WithWorkers. Use 1 to disable parallelism, or 0 (or a negative number) to restore the default of GOMAXPROCS.
+//
+// The limit applies to each operation: operations called from within parallel operations (e.g. IntermediatePointsTo
+// while densifying multipolygons) can each use up to `n` goroutines.
+func SetMaxWorkers(n int) {
+	if n < 0 {
+		n = 0
+	}
+	maxWorkers.Store(int64(n))
+}
+
+// MaxWorkers returns the maximum number of goroutines used by each parallel operation, see SetMaxWorkers.
+func MaxWorkers() int {
+	if n := maxWorkers.Load(); n > 0 {
+		return int(n)
+	}
+
+	return runtime.GOMAXPROCS(0)
+}
+
+// ParallelFor calls fn(i) for i = 0..n-1 using up to MaxWorkers goroutines, including the calling goroutine, and
+// returns when all the calls have returned. The calls are made in no particular order, so fn must be safe for
+// concurrent use, for example by writing only to the i-th element of a slice.
+func ParallelFor(n int, fn func(i int)) {
+	workers := MaxWorkers()
+	if workers > n {
+		workers = n
+	}
+
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+
+		return
+	}
+
+	var (
+		next      atomic.Int64
+		waitGroup sync.WaitGroup
+	)
+
+	work := func() {
+		for i := int(next.Add(1) - 1); i < n; i = int(next.Add(1) - 1) {
+			fn(i)
+		}
+	}
+
+	waitGroup.Add(workers - 1)
+	for w := 0; w < workers-1; w++ {
+		go func() {
+			defer waitGroup.Done()
+			work()
+		}()
+	}
+
+	work()
+	waitGroup.Wait()
+}
@@ -0,0 +1,60 @@
+package geod
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelFor(t *testing.T) {
+	defer SetMaxWorkers(0)
+
+	assert.Equal(t, runtime.GOMAXPROCS(0), MaxWorkers())
+
+	for _, workers := range []int{1, 3, 16} {
+		SetMaxWorkers(workers)
+		assert.Equal(t, workers, MaxWorkers())
+
+		var running, peak atomic.Int64
+		counts := make([]int, 100)
+		ParallelFor(len(counts), func(i int) {
+			n := running.Add(1)
+			for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+			}
+			time.Sleep(100 * time.Microsecond)
+			counts[i]++
+			running.Add(-1)
+		})
+
+		for i, c := range counts {
+			assert.Equal(t, 1, c, "index %d", i)
+		}
+		assert.LessOrEqual(t, peak.Load(), int64(workers))
+	}
+
+	ParallelFor(0, func(int) { t.Error("unexpected call") })
+
+	SetMaxWorkers(-1)
+	assert.Equal(t, runtime.GOMAXPROCS(0), MaxWorkers())
+}
+
+func TestIntermediatePointsToWorkers(t *testing.T) {
+	defer SetMaxWorkers(0)
+
+	p1 := NewLatLon(52.205, 0.119)
+	p2 := NewLatLon(48.857, 2.351)
+	fractions := []float64{0, 0.25, 0.5, 0.75, 1}
+
+	for _, model := range []EarthModel{SphericalModel, RhumbModel, VincentyModel} {
+		SetMaxWorkers(4)
+		parallel := IntermediatePoints(p1, p2, fractions, model)
+		SetMaxWorkers(1)
+		assert.Equal(t, parallel, IntermediatePoints(p1, p2, fractions, model))
+		for i, f := range fractions {
+			assert.Equal(t, IntermediatePoint(p1, p2, f, model), parallel[i])
+		}
+	}
+}